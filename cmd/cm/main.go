@@ -46,11 +46,11 @@ func main() {
 		panic("Cluster Monitoring ConfigMap does not contain a config. Using defaults.")
 	}
 
-	err = logancfg.NewConfigFromString(configContent)
+	operatorConfig, err := logancfg.ParseConfigFromString(configContent)
 	if err != nil {
 		log.Error(err, "Cluster Monitoring config could not be parsed. Using defaults: %v")
 		panic(err)
 	}
 
-	fmt.Println("Java App Spec", logancfg.JavaConfig.AppSpec)
+	fmt.Println("Java App Spec", operatorConfig.GetBootConfig("java").AppSpec)
 }
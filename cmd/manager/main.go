@@ -8,6 +8,7 @@ import (
 	mgrwebhook "github.com/logancloud/logan-app-operator/cmd/manager/webhook"
 	"github.com/logancloud/logan-app-operator/pkg/apis"
 	"github.com/logancloud/logan-app-operator/pkg/controller"
+	"github.com/logancloud/logan-app-operator/pkg/controller/operatorconfig"
 	"github.com/logancloud/logan-app-operator/pkg/logan"
 	logancfg "github.com/logancloud/logan-app-operator/pkg/logan/config"
 	"github.com/logancloud/logan-app-operator/version"
@@ -54,6 +55,8 @@ func printVersion() {
 	log.Info(fmt.Sprintf("Logan Operator MutationDefaulter: %t", logan.MutationDefaulter))
 	log.Info(fmt.Sprintf("Logan Operator BizEnvs: %v", logan.BizEnvs))
 	log.Info(fmt.Sprintf("Logan Operator Revision Max History: %d", logan.MaxHistory))
+	log.Info(fmt.Sprintf("Logan Operator Timezone: %s", logan.Location))
+	log.Info(fmt.Sprintf("Logan Operator Config Reload QPS: %v, Burst: %d", logan.ConfigReloadQPS, logan.ConfigReloadBurst))
 }
 
 func main() {
@@ -87,11 +90,12 @@ func main() {
 		os.Exit(0)
 	}
 
-	err := logancfg.InitByFile(configFile)
+	operatorConfig, err := logancfg.ParseConfigFile(configFile)
 	if err != nil {
 		log.Error(err, "Init config file fail")
 		os.Exit(1)
 	}
+	configProvider := logancfg.NewProvider(operatorConfig)
 
 	namespace, err := k8sutil.GetWatchNamespace()
 	if err != nil {
@@ -133,8 +137,15 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Load the LoganOperatorConfig, the config file is used only if it is not found, until the ConfigMap is migrated.
+	// The operator does not start with the config file if the LoganOperatorConfig exists but could not be loaded.
+	if err := operatorconfig.LoadConfig(cfg, mgr.GetScheme(), configProvider); err != nil {
+		log.Error(err, "Could not load LoganOperatorConfig")
+		os.Exit(1)
+	}
+
 	// Setup all Controllers
-	if err := controller.AddToManager(mgr); err != nil {
+	if err := controller.AddToManager(mgr, configProvider); err != nil {
 		log.Error(err, "")
 		os.Exit(1)
 	}
@@ -184,7 +195,7 @@ func main() {
 	}
 
 	if runningInCluster {
-		mgrwebhook.RegisterWebhook(mgr, log, ns)
+		mgrwebhook.RegisterWebhook(mgr, configProvider, log, ns)
 	} else {
 		log.Info("Skipping registering webhook; not running in a cluster.")
 	}
@@ -2,7 +2,9 @@ package webhook
 
 import (
 	"github.com/go-logr/logr"
+	appv1 "github.com/logancloud/logan-app-operator/pkg/apis/app/v1"
 	"github.com/logancloud/logan-app-operator/pkg/logan"
+	"github.com/logancloud/logan-app-operator/pkg/logan/config"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission/builder"
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
@@ -27,14 +29,18 @@ const (
 	mutationName    = "mutation.app.logancloud.com"
 	mutationCfgName = "logan-app-webhook-mutation"
 
-	validationName       = "validation.app.logancloud.com"
-	validationConfigName = "config.validation.app.logancloud.com"
+	validationName = "validation.app.logancloud.com"
 
 	validationCfgName = "logan-app-webhook-validation"
 )
 
-// RegisterWebhook will register webhook for mutation and validation
-func RegisterWebhook(mgr manager.Manager, log logr.Logger, operatorNs string) {
+// RegisterWebhook will register webhook for mutation and validation, the Boots are handled with the provider's config
+func RegisterWebhook(mgr manager.Manager, provider config.ConfigProvider, log logr.Logger, operatorNs string) {
+	resources := make([]string, 0)
+	for _, bootType := range appv1.BootTypes() {
+		resources = append(resources, bootType.Resource)
+	}
+
 	rules := admissionregistrationv1beta1.RuleWithOperations{
 		Operations: []admissionregistrationv1beta1.OperationType{
 			admissionregistrationv1beta1.Create,
@@ -44,13 +50,15 @@ func RegisterWebhook(mgr manager.Manager, log logr.Logger, operatorNs string) {
 		Rule: admissionregistrationv1beta1.Rule{
 			APIGroups:   []string{"app.logancloud.com"},
 			APIVersions: []string{"v1"},
-			Resources:   []string{"javaboots", "phpboots", "pythonboots", "nodejsboots", "webboots"}},
+			Resources:   resources},
 	}
 
 	// 1. Create a webhook(boot mutation)
 	mutationHandler := &bootmutation.BootMutator{
 		Schema:   mgr.GetScheme(),
 		Recorder: mgr.GetRecorder("logan-webhook-mutation"),
+
+		ConfigProvider: provider,
 	}
 	mutationWh, err := builder.NewWebhookBuilder().
 		Name(mutationName).
@@ -67,6 +75,8 @@ func RegisterWebhook(mgr manager.Manager, log logr.Logger, operatorNs string) {
 	validationHandler := &bootvalidation.BootValidator{
 		Schema:   mgr.GetScheme(),
 		Recorder: mgr.GetRecorder("logan-webhook-validation"),
+
+		ConfigProvider: provider,
 	}
 	validationWh, err := builder.NewWebhookBuilder().
 		Name(validationName).
@@ -79,33 +89,6 @@ func RegisterWebhook(mgr manager.Manager, log logr.Logger, operatorNs string) {
 		log.Error(err, "Creating boot validation webhook error")
 	}
 
-	// 3. Create a webhook(config validation)
-
-	configRules := admissionregistrationv1beta1.RuleWithOperations{
-		Operations: []admissionregistrationv1beta1.OperationType{
-			admissionregistrationv1beta1.Create,
-			admissionregistrationv1beta1.Update,
-			admissionregistrationv1beta1.Delete},
-		Rule: admissionregistrationv1beta1.Rule{
-			APIGroups:   []string{""},
-			APIVersions: []string{"v1"},
-			Resources:   []string{"configmaps"}},
-	}
-
-	validationConfigHandler := &bootvalidation.ConfigValidator{
-		OperatorNamespace: operatorNs,
-	}
-	validationConfigWh, err := builder.NewWebhookBuilder().
-		Name(validationConfigName).
-		Validating().
-		Rules(configRules).
-		Handlers(validationConfigHandler).
-		WithManager(mgr).
-		Build()
-	if err != nil {
-		log.Error(err, "Creating config validation webhook error")
-	}
-
 	// Create a server
 	whServer, err := webhook.NewServer(serverName, mgr, webhook.ServerOptions{
 		Port:             port,
@@ -116,7 +99,7 @@ func RegisterWebhook(mgr manager.Manager, log logr.Logger, operatorNs string) {
 		log.Error(err, "Creating webhook server error")
 	}
 
-	err = whServer.Register(mutationWh, validationWh, validationConfigWh)
+	err = whServer.Register(mutationWh, validationWh)
 	if err != nil {
 		log.Error(err, "Registering webhook error")
 	}
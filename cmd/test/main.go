@@ -53,20 +53,15 @@ func main() {
 	logf.SetLogger(logf.ZapLoggerTo(os.Stderr, true)) //Debug Output
 
 	file := "logan-app-operator/configs/config.yaml"
-	f, err := os.Open(file)
+	cfg, err := config.ParseConfigFile(file)
 	if err != nil {
 		panic(err)
 	}
 
-	err = config.Init(f)
-	if err != nil {
-		panic(err)
-	}
-
-	printObj(config.JavaConfig, "java")
-	printObj(config.PhpConfig, "php")
-	printObj(config.PythonConfig, "python")
-	printObj(config.NodeJSConfig, "nodejs")
+	printObj(cfg.GetBootConfig("java"), "java")
+	printObj(cfg.GetBootConfig("php"), "php")
+	printObj(cfg.GetBootConfig("python"), "python")
+	printObj(cfg.GetBootConfig("nodejs"), "nodejs")
 
-	printObj(config.ProfileConfig["hanlp"], "hanlp")
+	printObj(cfg.GetProfileConfig("hanlp"), "hanlp")
 }
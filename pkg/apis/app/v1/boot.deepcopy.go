@@ -217,3 +217,89 @@ func (in *WebBoot) DeepCopyBoot() *Boot {
 	out.BootType = logan.BootWeb
 	return out
 }
+
+// DeepCopyToGo will deepcopy as: Boot -> GoBoot
+func (in *Boot) DeepCopyToGo(out *GoBoot) {
+	*out = GoBoot{}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopyGo will deepcopy as: Boot -> GoBoot
+func (in *Boot) DeepCopyGo() *GoBoot {
+	if in == nil {
+		return nil
+	}
+	out := new(GoBoot)
+	in.DeepCopyToGo(out)
+	return out
+}
+
+// DeepCopyIntoBoot will deepcopy as: GoBoot -> Boot
+func (in *GoBoot) DeepCopyIntoBoot(out *Boot) {
+	*out = Boot{}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopyBoot will deepcopy as: GoBoot -> Boot
+func (in *GoBoot) DeepCopyBoot() *Boot {
+	if in == nil {
+		return nil
+	}
+	out := new(Boot)
+	in.DeepCopyIntoBoot(out)
+
+	out.AppKey = logan.GoAppKey
+	out.BootType = logan.BootGo
+	return out
+}
+
+// DeepCopyToDotnet will deepcopy as: Boot -> DotnetBoot
+func (in *Boot) DeepCopyToDotnet(out *DotnetBoot) {
+	*out = DotnetBoot{}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopyDotnet will deepcopy as: Boot -> DotnetBoot
+func (in *Boot) DeepCopyDotnet() *DotnetBoot {
+	if in == nil {
+		return nil
+	}
+	out := new(DotnetBoot)
+	in.DeepCopyToDotnet(out)
+	return out
+}
+
+// DeepCopyIntoBoot will deepcopy as: DotnetBoot -> Boot
+func (in *DotnetBoot) DeepCopyIntoBoot(out *Boot) {
+	*out = Boot{}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopyBoot will deepcopy as: DotnetBoot -> Boot
+func (in *DotnetBoot) DeepCopyBoot() *Boot {
+	if in == nil {
+		return nil
+	}
+	out := new(Boot)
+	in.DeepCopyIntoBoot(out)
+
+	out.AppKey = logan.DotnetAppKey
+	out.BootType = logan.BootDotnet
+	return out
+}
@@ -1,8 +1,12 @@
 package v1
 
 import (
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -39,19 +43,52 @@ type BootSpec struct {
 	// This is a pointer to distinguish between explicit zero and unspecified.
 	// Defaults to 1.
 	Replicas *int32 `json:"replicas,omitempty"`
+	// Suspend scales the Boot's workload to zero, and pauses the reconciliation and revision recording.
+	// The prior replicas are restored when resumed. Could also be set by the annotation "app.logancloud.com/suspend: true".
+	// +optional
+	Suspend *bool `json:"suspend,omitempty"`
+	// WorkloadType is the type of the Boot's workload, "Deployment", "StatefulSet", "Job" or "CronJob".
+	// Defaults to "Deployment". StatefulSet gives the pods stable names through a headless Service,
+	// and per-pod PVCs from the Boot's own pvc. Job and CronJob run the app container to completion, without Service.
+	// It could not be changed after created.
+	// +optional
+	WorkloadType BootWorkloadType `json:"workloadType,omitempty"`
+	// PodManagementPolicy is how the StatefulSet's pods are created and deleted, "OrderedReady" or "Parallel".
+	// Defaults to "OrderedReady". Only used by the StatefulSet workload, could not be changed after created.
+	// +optional
+	PodManagementPolicy appsv1.PodManagementPolicyType `json:"podManagementPolicy,omitempty"`
+	// Job is the settings of the Job or CronJob workload, the schedule is required by CronJob.
+	// +optional
+	Job *BootJob `json:"job,omitempty"`
 	// Env is list of environment variables to set in the app container.
 	// +optional
 	// +patchMergeKey=name
 	// +patchStrategy=merge
 	Env []corev1.EnvVar `json:"env,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
-	// Port that are exposed by the app container
+	// Port that are exposed by the app container, it is the shorthand of a single "http" port.
+	// If Ports is set, Port is set to the containerPort of the primary port.
 	Port int32 `json:"port,omitempty"`
-	// Reserved, not used. for latter use
+	// Ports is the list of named ports exposed by the app container and the app service.
+	// The primary port is the port named "http", or the first port if not found, which is used by health check and Ingress.
+	// +optional
+	// +patchMergeKey=name
+	// +patchStrategy=merge
+	Ports []BootPort `json:"ports,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
+	// SubDomain is the domain suffix of the Boot's created Ingress, the host is "<name>.<subDomain>" by default.
+	// If empty, the Ingress will not be created.
 	SubDomain string `json:"subDomain,omitempty"`
 	// Health is check path for the app container.
 	Health *string `json:"health,omitempty"`
 	// Readiness is a readiness check path for the app container.
 	Readiness *string `json:"readiness,omitempty"`
+	// LivenessProbe is the liveness probe settings of the app container, merged on the operator config's livenessProbe.
+	// The http probe's path defaults to Health.
+	// +optional
+	LivenessProbe *BootProbe `json:"livenessProbe,omitempty"`
+	// ReadinessProbe is the readiness probe settings of the app container, merged on the operator config's readinessProbe.
+	// The http probe's path defaults to Readiness, or Health if Readiness is empty.
+	// +optional
+	ReadinessProbe *BootProbe `json:"readinessProbe,omitempty"`
 	// Prometheus will scrape metrics from the service, default is `true`
 	Prometheus string `json:"prometheus"`
 	// Resources is the compute resource requirements for the app container
@@ -59,6 +96,24 @@ type BootSpec struct {
 	// NodeSelector is a selector which must be true for the pod to fit on a node.
 	// Selector which must match a node's labels for the pod to be scheduled on that node.
 	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Tolerations is the pod's tolerations, the operator config's mandatory tolerations are always merged.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// NodeAffinity is the pod's node affinity, the operator config's mandatory requirements are always merged.
+	// +optional
+	NodeAffinity *corev1.NodeAffinity `json:"nodeAffinity,omitempty"`
+	// TopologySpread is how the pods spread across the topology domains, such as zone and host.
+	// Defaults to spread across hosts, preferred with weight 100.
+	// +optional
+	// +patchMergeKey=topologyKey
+	// +patchStrategy=merge
+	TopologySpread []BootTopologySpread `json:"topologySpread,omitempty" patchStrategy:"merge" patchMergeKey:"topologyKey"`
+	// PriorityClassName is the pod's priority class name.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+	// RuntimeClassName is the pod's runtime class name.
+	// +optional
+	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
 	// Command is command for boot's container. If empty, will use image's ENTRYPOINT, specified here if needed override.
 	Command []string `json:"command,omitempty"`
 	// SessionAffinity is SessionAffinity for boot's created service. If empty, will not set
@@ -70,6 +125,23 @@ type BootSpec struct {
 	// +patchMergeKey=name
 	// +patchStrategy=merge
 	Pvc []PersistentVolumeClaimMount `json:"pvc,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
+	// Strategy is the rollout strategy of the Boot's workload, merged on the operator config's strategy.
+	// +optional
+	Strategy *BootStrategy `json:"strategy,omitempty"`
+	// Autoscaling is the HorizontalPodAutoscaler settings for the Boot's workload.
+	// When enabled, the replicas of the workload is decided by the created HorizontalPodAutoscaler.
+	// +optional
+	Autoscaling *BootAutoscaling `json:"autoscaling,omitempty"`
+	// ScalingSchedules is the list of cron-style scaling windows in the operator's timezone.
+	// The schedule fired latest is active, its replicas override the Boot's replicas until another schedule fires.
+	// When autoscaling is enabled, the active replicas is the lower limit of the HorizontalPodAutoscaler, and zero scales to zero.
+	// +optional
+	// +patchMergeKey=name
+	// +patchStrategy=merge
+	ScalingSchedules []BootScalingSchedule `json:"scalingSchedules,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
+	// RestartSchedule is the scheduled rolling restart of the Boot's workload, merged on the operator config's restartSchedule.
+	// +optional
+	RestartSchedule *BootRestartSchedule `json:"restartSchedule,omitempty"`
 }
 
 // BootStatus defines the observed state of Boot for specified types, as JavaBoot/PhpBoot/PythonBoot/NodeJSBoot
@@ -79,9 +151,143 @@ type BootStatus struct {
 	// Important: Run "operator-sdk generate k8s" to regenerate code after modifying this file
 	// Add custom validation using kubebuilder tags: https://book.kubebuilder.io/beyond_basics/generating_crd.html
 
-	Type     string `json:"type,omitempty"`
-	Deploy   string `json:"deploy,omitempty"`
+	// Type is the workload type created for the Boot, "deploy", "statefulset", "job" or "cronjob".
+	Type string `json:"type,omitempty"`
+	// Deploy is the name of the Boot's created Deployment, StatefulSet, Job or CronJob.
+	Deploy string `json:"deploy,omitempty"`
+	// Services is the name list of the Boot's created services, split by ,
 	Services string `json:"services,omitempty"`
+
+	// ObservedGeneration is the most recent generation observed for this Boot.
+	// It corresponds to the Boot's generation, which is updated on mutation of the spec.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Replicas is the total number of non-terminated pods targeted by this Boot, used by the scale subresource.
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+	// Selector is the label selector of the Boot's pods in string form, used by the scale subresource.
+	// +optional
+	Selector string `json:"selector,omitempty"`
+	// ReadyReplicas is the number of pods targeted by this Boot with a Ready Condition.
+	// +optional
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+	// AvailableReplicas is the number of available pods (ready for at least minReadySeconds) targeted by this Boot.
+	// +optional
+	AvailableReplicas int32 `json:"availableReplicas,omitempty"`
+	// UpdatedReplicas is the number of pods targeted by this Boot that have the desired template spec.
+	// +optional
+	UpdatedReplicas int32 `json:"updatedReplicas,omitempty"`
+	// Revision is the ID of the Boot's latest BootRevision.
+	// +optional
+	Revision string `json:"revision,omitempty"`
+	// RevisionHash is the boot hash of the Boot's latest BootRevision.
+	// +optional
+	RevisionHash string `json:"revisionHash,omitempty"`
+	// Config is how the Boot's config is resolved, as "type=java;profile=javaext;overlays=team-payments":
+	// the boot type, the profile and the namespace's overlays applied in order, overridden by the Boot's spec.
+	// +optional
+	Config string `json:"config,omitempty"`
+	// Phase is a simple, high-level summary of where the Boot is in its lifecycle.
+	// +optional
+	Phase BootPhase `json:"phase,omitempty"`
+	// ScalingSchedule is the name of the Boot's active scaling schedule.
+	// +optional
+	ScalingSchedule string `json:"scalingSchedule,omitempty"`
+	// NextScheduleTime is the next time when one of the Boot's scaling schedules fires.
+	// +optional
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+	// LastScheduledRestartTime is the latest time when the Boot's restart schedule fired, which is handled.
+	// +optional
+	LastScheduledRestartTime *metav1.Time `json:"lastScheduledRestartTime,omitempty"`
+	// NextScheduledRestartTime is the next time when the Boot is restarted by the restart schedule, including the jitter.
+	// +optional
+	NextScheduledRestartTime *metav1.Time `json:"nextScheduledRestartTime,omitempty"`
+	// Active is the number of the Boot's running Jobs, only for the Job and CronJob workload.
+	// +optional
+	Active int32 `json:"active,omitempty"`
+	// LastRunTime is the latest time when the Boot's Job was started.
+	// +optional
+	LastRunTime *metav1.Time `json:"lastRunTime,omitempty"`
+	// LastSuccessTime is the latest time when the Boot's Job was completed successfully.
+	// +optional
+	LastSuccessTime *metav1.Time `json:"lastSuccessTime,omitempty"`
+	// LastFailureTime is the latest time when the Boot's Job failed.
+	// +optional
+	LastFailureTime *metav1.Time `json:"lastFailureTime,omitempty"`
+	// Conditions represent the latest available observations of the Boot's current state.
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	Conditions []BootCondition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// BootPhase is a label for the condition of a Boot at the current time.
+type BootPhase string
+
+const (
+	// BootPhasePending means the Boot's workload has not been created, or no pod is available yet.
+	BootPhasePending BootPhase = "Pending"
+	// BootPhaseProgressing means the Boot's latest revision is rolling out.
+	BootPhaseProgressing BootPhase = "Progressing"
+	// BootPhaseRunning means all desired pods of the Boot's latest revision are available.
+	BootPhaseRunning BootPhase = "Running"
+	// BootPhaseFailed means the Boot can not be reconciled, or its rollout exceeded the progress deadline.
+	BootPhaseFailed BootPhase = "Failed"
+	// BootPhaseSuspended means the Boot is suspended, its workload is scaled to zero.
+	BootPhaseSuspended BootPhase = "Suspended"
+	// BootPhaseSucceeded means the Boot's Job is completed successfully.
+	BootPhaseSucceeded BootPhase = "Succeeded"
+)
+
+// BootWorkloadType is the type of the Boot's workload.
+type BootWorkloadType string
+
+const (
+	// WorkloadTypeDeployment means the Boot's workload is a Deployment.
+	WorkloadTypeDeployment BootWorkloadType = "Deployment"
+	// WorkloadTypeStatefulSet means the Boot's workload is a StatefulSet with a headless Service.
+	WorkloadTypeStatefulSet BootWorkloadType = "StatefulSet"
+	// WorkloadTypeJob means the Boot's workload is a one-off Job, it is run again when the Boot is changed.
+	WorkloadTypeJob BootWorkloadType = "Job"
+	// WorkloadTypeCronJob means the Boot's workload is a CronJob, which runs the Job on schedule.
+	WorkloadTypeCronJob BootWorkloadType = "CronJob"
+)
+
+// BootConditionType is a valid value for BootCondition.Type
+type BootConditionType string
+
+const (
+	// BootAvailable means the Boot has its desired number of pods available.
+	BootAvailable BootConditionType = "Available"
+	// BootProgressing means the Boot's latest revision is rolling out, it is False when the rollout is
+	// complete(reason RolloutComplete) or exceeded the progress deadline(reason ProgressDeadlineExceeded).
+	BootProgressing BootConditionType = "Progressing"
+	// BootReconcileError means the last reconcile of the Boot failed, message holds the error.
+	BootReconcileError BootConditionType = "ReconcileError"
+	// BootConfigValid means the operator config(type or profile) used by the Boot is valid.
+	BootConfigValid BootConditionType = "ConfigValid"
+)
+
+// BootCondition describes the state of a Boot at a certain point.
+// It follows the shape of the upstream metav1.Condition, which is not available in the pinned kubernetes version.
+// +k8s:openapi-gen=true
+type BootCondition struct {
+	// Type of Boot condition.
+	Type BootConditionType `json:"type"`
+	// Status of the condition, one of True, False, Unknown.
+	Status corev1.ConditionStatus `json:"status"`
+	// ObservedGeneration represents the Boot's generation that the condition was set based upon.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// LastTransitionTime is the last time the condition transitioned from one status to another.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Reason is a unique, one-word, CamelCase reason for the condition's last transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Message is a human readable message indicating details about the transition.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // PersistentVolumeClaimMount defines the Boot match a PersistentVolumeClaim
@@ -97,3 +303,264 @@ type PersistentVolumeClaimMount struct {
 	// not contain ':'.
 	MountPath string `json:"mountPath" protobuf:"bytes,3,opt,name=mountPath"`
 }
+
+// BootPort defines a named port exposed by the Boot's app container and app service
+// +k8s:openapi-gen=true
+type BootPort struct {
+	// Name of the port, must be an IANA_SVC_NAME and unique within the Boot.
+	Name string `json:"name"`
+	// ContainerPort is the port number exposed on the app container.
+	ContainerPort int32 `json:"containerPort"`
+	// ServicePort is the port number exposed by the app service.
+	// Defaults to containerPort.
+	// +optional
+	ServicePort int32 `json:"servicePort,omitempty"`
+	// Protocol for the port, must be UDP, TCP or SCTP.
+	// Defaults to "TCP".
+	// +optional
+	Protocol corev1.Protocol `json:"protocol,omitempty"`
+	// AppProtocol is the application protocol of the port, such as http, grpc.
+	// It is recorded in the app service's annotation "app.logancloud.com/app-protocols".
+	// +optional
+	AppProtocol string `json:"appProtocol,omitempty"`
+}
+
+// BootStrategy defines the rollout strategy of the Boot's workload. Empty fields are defaulted by the operator config.
+// +k8s:openapi-gen=true
+type BootStrategy struct {
+	// Type of the rollout, one of RollingUpdate, Recreate.
+	// Defaults to RollingUpdate.
+	// +optional
+	Type appsv1.DeploymentStrategyType `json:"type,omitempty"`
+	// MaxSurge is the maximum number of pods that can be scheduled above the desired number of pods during the RollingUpdate.
+	// Value can be an absolute number (ex: 5) or a percentage of desired pods (ex: 10%).
+	// +optional
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+	// MaxUnavailable is the maximum number of pods that can be unavailable during the RollingUpdate.
+	// Value can be an absolute number (ex: 5) or a percentage of desired pods (ex: 10%).
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+	// MinReadySeconds is the minimum number of seconds for which a newly created pod should be ready
+	// without any of its container crashing, for it to be considered available.
+	// +optional
+	MinReadySeconds *int32 `json:"minReadySeconds,omitempty"`
+	// ProgressDeadlineSeconds is the maximum time in seconds for the rollout to make progress before it is considered to be failed.
+	// +optional
+	ProgressDeadlineSeconds *int32 `json:"progressDeadlineSeconds,omitempty"`
+	// RevisionHistoryLimit is the number of old ReplicaSets to retain to allow rollback.
+	// +optional
+	RevisionHistoryLimit *int32 `json:"revisionHistoryLimit,omitempty"`
+	// AutoRollback rolls back the Boot to the last Complete or Active revision automatically,
+	// when the latest revision exceeds the progressDeadlineSeconds or its pods crash loop.
+	// Defaults to false.
+	// +optional
+	AutoRollback *bool `json:"autoRollback,omitempty"`
+	// Canary enables the canary rollout: on a spec change, the current Deployment is kept as stable,
+	// and the new revision is deployed to a canary Deployment, until promoted or aborted.
+	// +optional
+	Canary *BootCanary `json:"canary,omitempty"`
+	// BlueGreen enables the blue-green rollout: on a spec change, the new revision is deployed to the other color's Deployment,
+	// and the app Service is switched to it after all its pods are ready. Could not be used with canary or autoscaling.
+	// +optional
+	BlueGreen *BootBlueGreen `json:"blueGreen,omitempty"`
+}
+
+// BootCanary is the canary rollout of the Boot
+type BootCanary struct {
+	// Steps are the canary Deployment's replicas of each step, promoted in order.
+	// Value can be an absolute number (ex: 1) or a percentage of the Boot's replicas (ex: 10%).
+	// After the last step, the stable Deployment is updated to the new revision and the canary Deployment is deleted.
+	// Defaults to [1].
+	// +optional
+	Steps []intstr.IntOrString `json:"steps,omitempty"`
+	// BakeSeconds is the time in seconds which all the canary pods must be ready for, before promoted to the next step automatically.
+	// If not set, the canary is promoted manually by the annotation "app.logancloud.com/canary: promote".
+	// +optional
+	BakeSeconds *int32 `json:"bakeSeconds,omitempty"`
+}
+
+// BootBlueGreen is the blue-green rollout of the Boot
+type BootBlueGreen struct {
+	// AutoSwitch switches the app Service to the new color automatically, after all the new color's pods are ready.
+	// If not set, the switch is triggered manually by the annotation "app.logancloud.com/blue-green: switch".
+	// Defaults to false.
+	// +optional
+	AutoSwitch *bool `json:"autoSwitch,omitempty"`
+	// ScaleDownDelaySeconds is the time in seconds which the old color is kept scaled after the switch,
+	// it could be switched back instantly by the annotation "app.logancloud.com/blue-green: rollback".
+	// Defaults to 600.
+	// +optional
+	ScaleDownDelaySeconds *int32 `json:"scaleDownDelaySeconds,omitempty"`
+}
+
+// BootUnsatisfiableAction is the action when the BootTopologySpread is not satisfied
+type BootUnsatisfiableAction string
+
+// These are valid actions of the BootTopologySpread.
+const (
+	// DoNotSchedule tells the scheduler not to schedule the pod, at most one pod per topology domain.
+	DoNotSchedule BootUnsatisfiableAction = "DoNotSchedule"
+	// ScheduleAnyway tells the scheduler to schedule the pod but prefer the domains without the Boot's pods.
+	ScheduleAnyway BootUnsatisfiableAction = "ScheduleAnyway"
+)
+
+// BootTopologySpread defines how the Boot's pods spread across the topology domains.
+// The pinned kubernetes version has no topologySpreadConstraints, it is implemented by the pod anti-affinity,
+// so there is no maxSkew: DoNotSchedule allows at most one pod per topology domain, and ScheduleAnyway only prefers
+// the domains without the Boot's pods.
+// +k8s:openapi-gen=true
+type BootTopologySpread struct {
+	// TopologyKey is the key of node labels, such as "kubernetes.io/hostname", "failure-domain.beta.kubernetes.io/zone".
+	TopologyKey string `json:"topologyKey"`
+	// WhenUnsatisfiable is the action when the spread is not satisfied, one of DoNotSchedule, ScheduleAnyway.
+	// DoNotSchedule is the required pod anti-affinity, ScheduleAnyway is the preferred pod anti-affinity.
+	// Defaults to ScheduleAnyway.
+	// +optional
+	WhenUnsatisfiable BootUnsatisfiableAction `json:"whenUnsatisfiable,omitempty"`
+	// Weight is the weight of the preferred pod anti-affinity, in the range 1-100.
+	// Defaults to 100.
+	// +optional
+	Weight int32 `json:"weight,omitempty"`
+}
+
+// BootProbeType is the handler type of the BootProbe
+type BootProbeType string
+
+// These are valid handler types of the BootProbe.
+const (
+	// BootProbeHTTP performs a HTTP GET request against the path and port.
+	BootProbeHTTP BootProbeType = "http"
+	// BootProbeTCP performs a TCP check against the port.
+	BootProbeTCP BootProbeType = "tcp"
+	// BootProbeExec executes the command in the app container.
+	BootProbeExec BootProbeType = "exec"
+	// BootProbeGRPC performs the gRPC health check against the port and service, by executing grpc_health_probe
+	// in the app container. The image must provide grpc_health_probe in the PATH, which the operator could not check,
+	// otherwise the probe always fails.
+	BootProbeGRPC BootProbeType = "grpc"
+)
+
+// BootProbe defines a probe of the Boot's app container. Empty fields are defaulted by the operator config.
+// +k8s:openapi-gen=true
+type BootProbe struct {
+	// Type is the handler type of the probe, one of http, tcp, exec, grpc.
+	// Defaults to http.
+	// +optional
+	Type BootProbeType `json:"type,omitempty"`
+	// Path is the path of the http probe.
+	// +optional
+	Path string `json:"path,omitempty"`
+	// Port is the port of the http/tcp/grpc probe.
+	// Defaults to the primary port, or the config's appHealthPort.
+	// +optional
+	Port int32 `json:"port,omitempty"`
+	// Command is the command of the exec probe.
+	// +optional
+	Command []string `json:"command,omitempty"`
+	// Service is the service name of the grpc probe.
+	// +optional
+	Service string `json:"service,omitempty"`
+	// InitialDelaySeconds is the number of seconds after the container has started before the probe is initiated.
+	// +optional
+	InitialDelaySeconds *int32 `json:"initialDelaySeconds,omitempty"`
+	// TimeoutSeconds is the number of seconds after which the probe times out.
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+	// PeriodSeconds is how often (in seconds) to perform the probe.
+	// +optional
+	PeriodSeconds *int32 `json:"periodSeconds,omitempty"`
+	// SuccessThreshold is the minimum consecutive successes for the probe to be considered successful after having failed.
+	// Must be 1 for liveness.
+	// +optional
+	SuccessThreshold *int32 `json:"successThreshold,omitempty"`
+	// FailureThreshold is the minimum consecutive failures for the probe to be considered failed after having succeeded.
+	// +optional
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+}
+
+// BootScalingSchedule defines a cron-style scaling window of the Boot
+// +k8s:openapi-gen=true
+type BootScalingSchedule struct {
+	// Name is the unique name of the schedule.
+	Name string `json:"name"`
+	// Schedule is the cron expression "minute hour dayOfMonth month dayOfWeek" when the window starts.
+	// The window ends when another schedule of the Boot fires.
+	Schedule string `json:"schedule"`
+	// Replicas is the number of replicas in the window.
+	// Defaults to the Boot's replicas, used to scale back to spec.
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+}
+
+// BootRestartSchedule defines the scheduled rolling restart of the Boot
+// +k8s:openapi-gen=true
+type BootRestartSchedule struct {
+	// Enabled is whether to restart the Boot on schedule.
+	// Defaults to true if the schedule is set.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// Schedule is the cron expression "minute hour dayOfMonth month dayOfWeek" in the operator's timezone.
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+	// MaxJitterSeconds is the upper limit of the delay after the schedule fires, to spread the restarts of the Boots.
+	// The delay is stable for the Boot and the schedule's time. Defaults to 0.
+	// +optional
+	MaxJitterSeconds *int32 `json:"maxJitterSeconds,omitempty"`
+}
+
+// BootJob defines the settings of the Boot's Job or CronJob workload
+// +k8s:openapi-gen=true
+type BootJob struct {
+	// Schedule is the CronJob's cron expression "minute hour dayOfMonth month dayOfWeek" in the kube-controller-manager's
+	// timezone. Required by the CronJob workload.
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+	// ConcurrencyPolicy is how the CronJob treats the concurrent runs, "Allow", "Forbid" or "Replace".
+	// Defaults to "Forbid".
+	// +optional
+	ConcurrencyPolicy batchv1beta1.ConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+	// StartingDeadlineSeconds is the deadline for starting the CronJob's run if it misses the scheduled time.
+	// +optional
+	StartingDeadlineSeconds *int64 `json:"startingDeadlineSeconds,omitempty"`
+	// SuccessfulJobsHistoryLimit is the number of the CronJob's successful Jobs to keep. Defaults to 3.
+	// +optional
+	SuccessfulJobsHistoryLimit *int32 `json:"successfulJobsHistoryLimit,omitempty"`
+	// FailedJobsHistoryLimit is the number of the CronJob's failed Jobs to keep. Defaults to 1.
+	// +optional
+	FailedJobsHistoryLimit *int32 `json:"failedJobsHistoryLimit,omitempty"`
+	// BackoffLimit is the number of retries before the Job is marked failed. Defaults to 6.
+	// +optional
+	BackoffLimit *int32 `json:"backoffLimit,omitempty"`
+	// ActiveDeadlineSeconds is the duration the Job may be active before it is terminated.
+	// +optional
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
+	// RestartPolicy is the Job pod's restart policy, "OnFailure" or "Never". Defaults to "OnFailure".
+	// +optional
+	RestartPolicy corev1.RestartPolicy `json:"restartPolicy,omitempty"`
+}
+
+// BootAutoscaling defines the HorizontalPodAutoscaler settings of the Boot
+// +k8s:openapi-gen=true
+type BootAutoscaling struct {
+	// Enabled is whether to create the HorizontalPodAutoscaler for the Boot.
+	// Defaults to true if the autoscaling is specified.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// MinReplicas is the lower limit for the number of replicas.
+	// Defaults to 1.
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	// MaxReplicas is the upper limit for the number of replicas. It cannot be less than minReplicas.
+	MaxReplicas int32 `json:"maxReplicas,omitempty"`
+	// TargetCPUUtilizationPercentage is the target average CPU utilization over all the pods,
+	// represented as a percentage of the requested CPU.
+	// +optional
+	TargetCPUUtilizationPercentage *int32 `json:"targetCPUUtilizationPercentage,omitempty"`
+	// TargetMemoryUtilizationPercentage is the target average memory utilization over all the pods,
+	// represented as a percentage of the requested memory.
+	// +optional
+	TargetMemoryUtilizationPercentage *int32 `json:"targetMemoryUtilizationPercentage,omitempty"`
+	// Metrics contains the custom metric targets, appended to the cpu/memory targets.
+	// +optional
+	Metrics []autoscalingv2beta2.MetricSpec `json:"metrics,omitempty"`
+}
@@ -0,0 +1,50 @@
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetCondition returns the condition with the provided type, nil if not found.
+func (in *BootStatus) GetCondition(condType BootConditionType) *BootCondition {
+	for i := range in.Conditions {
+		if in.Conditions[i].Type == condType {
+			return &in.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// IsConditionTrue returns true if the condition with the provided type is present and its status is True.
+func (in *BootStatus) IsConditionTrue(condType BootConditionType) bool {
+	cond := in.GetCondition(condType)
+	return cond != nil && cond.Status == corev1.ConditionTrue
+}
+
+// SetCondition adds or updates the condition with the same type.
+// LastTransitionTime is only changed when the status of the condition changes.
+// Return true if the condition is changed.
+func (in *BootStatus) SetCondition(newCond BootCondition) bool {
+	current := in.GetCondition(newCond.Type)
+	if current == nil {
+		if newCond.LastTransitionTime.IsZero() {
+			newCond.LastTransitionTime = metav1.Now()
+		}
+		in.Conditions = append(in.Conditions, newCond)
+		return true
+	}
+
+	if current.Status == newCond.Status && current.Reason == newCond.Reason &&
+		current.Message == newCond.Message && current.ObservedGeneration == newCond.ObservedGeneration {
+		return false
+	}
+
+	if current.Status != newCond.Status {
+		current.LastTransitionTime = metav1.Now()
+	}
+	current.Status = newCond.Status
+	current.Reason = newCond.Reason
+	current.Message = newCond.Message
+	current.ObservedGeneration = newCond.ObservedGeneration
+	return true
+}
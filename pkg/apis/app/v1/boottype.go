@@ -0,0 +1,99 @@
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// BootObject is the object of a Boot kind, as JavaBoot/PhpBoot/PythonBoot/NodeJSBoot/WebBoot,
+// which is converted from and to the Boot.
+type BootObject interface {
+	runtime.Object
+	metav1.Object
+
+	// GetBootMeta returns the object's metadata
+	GetBootMeta() *metav1.ObjectMeta
+	// GetBootSpec returns the object's spec
+	GetBootSpec() *BootSpec
+	// GetBootStatus returns the object's status
+	GetBootStatus() *BootStatus
+	// DeepCopyBoot deepcopy the object as Boot
+	DeepCopyBoot() *Boot
+}
+
+// BootType describes a kind of Boot. The controller, webhook and operator config of the kind are driven by it,
+// so adding a runtime is a registration in its types file.
+// +k8s:deepcopy-gen=false
+type BootType struct {
+	// Kind is the kind of the API, as JavaBoot
+	Kind string
+	// Resource is the plural resource name of the API, as javaboots
+	Resource string
+	// ConfigKey is the key of the type in the operator config, also the Boot's type label, as java
+	ConfigKey string
+	// AppKey is the app key of the type, as javaBoot
+	AppKey string
+
+	// New returns an empty object of the kind
+	New func() BootObject
+	// NewList returns an empty list of the kind
+	NewList func() runtime.Object
+
+	// Strategy is the built-in rollout strategy of the type, overridden by the operator config and Boot's spec.
+	Strategy *BootStrategy
+	// Probe is the built-in probe settings of the type, overridden by the operator config and Boot's spec.
+	Probe *BootProbe
+	// Resources is the built-in resources of the type, used when the operator config has no resources.
+	Resources *corev1.ResourceRequirements
+	// CPUEnvs is the envs of the runtime's processor count, derived from the app container's cpu limits.
+	// They are added to the Boot's env if the Boot has cpu limits and does not specify them.
+	CPUEnvs []string
+}
+
+var bootTypes []*BootType
+
+// RegisterBootType registers the Boot's type, should be called in init.
+func RegisterBootType(bootType *BootType) {
+	bootTypes = append(bootTypes, bootType)
+}
+
+// BootTypes returns all registered Boot's types, in the order of registering.
+func BootTypes() []*BootType {
+	return bootTypes
+}
+
+// GetBootType returns the registered Boot's type by the kind, nil if not registered.
+func GetBootType(kind string) *BootType {
+	for _, bootType := range bootTypes {
+		if bootType.Kind == kind {
+			return bootType
+		}
+	}
+	return nil
+}
+
+// GetBootTypeByConfigKey returns the registered Boot's type by the config key, nil if not registered.
+func GetBootTypeByConfigKey(configKey string) *BootType {
+	for _, bootType := range bootTypes {
+		if bootType.ConfigKey == configKey {
+			return bootType
+		}
+	}
+	return nil
+}
+
+// BootObject deepcopy the Boot as a new object of the type.
+func (bootType *BootType) BootObject(in *Boot) BootObject {
+	out := bootType.New()
+	in.DeepCopyToObject(out)
+	return out
+}
+
+// DeepCopyToObject will deepcopy as: Boot -> BootObject
+func (in *Boot) DeepCopyToObject(out BootObject) {
+	out.GetObjectKind().SetGroupVersionKind(in.GroupVersionKind())
+	in.ObjectMeta.DeepCopyInto(out.GetBootMeta())
+	in.Spec.DeepCopyInto(out.GetBootSpec())
+	in.Status.DeepCopyInto(out.GetBootStatus())
+}
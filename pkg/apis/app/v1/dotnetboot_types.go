@@ -0,0 +1,83 @@
+package v1
+
+import (
+	"github.com/logancloud/logan-app-operator/pkg/logan"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DotnetBoot is the Schema for the dotnetboots API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.replicas,selectorpath=.status.selector
+type DotnetBoot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BootSpec   `json:"spec,omitempty"`
+	Status BootStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DotnetBootList contains a list of DotnetBoot
+type DotnetBootList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DotnetBoot `json:"items"`
+}
+
+// GetBootMeta returns the DotnetBoot's metadata
+func (in *DotnetBoot) GetBootMeta() *metav1.ObjectMeta {
+	return &in.ObjectMeta
+}
+
+// GetBootSpec returns the DotnetBoot's spec
+func (in *DotnetBoot) GetBootSpec() *BootSpec {
+	return &in.Spec
+}
+
+// GetBootStatus returns the DotnetBoot's status
+func (in *DotnetBoot) GetBootStatus() *BootStatus {
+	return &in.Status
+}
+
+// .NET app starts in seconds after JIT, probe it faster than Java.
+var (
+	dotnetProbeInitialDelaySeconds = int32(15)
+	dotnetProbePeriodSeconds       = int32(5)
+	dotnetProbeFailureThreshold    = int32(6)
+)
+
+func init() {
+	SchemeBuilder.Register(&DotnetBoot{}, &DotnetBootList{})
+	RegisterBootType(&BootType{
+		Kind:      "DotnetBoot",
+		Resource:  "dotnetboots",
+		ConfigKey: logan.BootDotnet,
+		AppKey:    logan.DotnetAppKey,
+		New:       func() BootObject { return &DotnetBoot{} },
+		NewList:   func() runtime.Object { return &DotnetBootList{} },
+		Probe: &BootProbe{
+			InitialDelaySeconds: &dotnetProbeInitialDelaySeconds,
+			PeriodSeconds:       &dotnetProbePeriodSeconds,
+			FailureThreshold:    &dotnetProbeFailureThreshold,
+		},
+		Resources: &corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1"),
+				corev1.ResourceMemory: resource.MustParse("512Mi"),
+			},
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("50m"),
+				corev1.ResourceMemory: resource.MustParse("128Mi"),
+			},
+		},
+		// .NET runtime sizes the thread pool and GC heaps by the processor count, which is the node's cpus by default.
+		CPUEnvs: []string{"DOTNET_PROCESSOR_COUNT"},
+	})
+}
@@ -0,0 +1,83 @@
+package v1
+
+import (
+	"github.com/logancloud/logan-app-operator/pkg/logan"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// GoBoot is the Schema for the goboots API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.replicas,selectorpath=.status.selector
+type GoBoot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BootSpec   `json:"spec,omitempty"`
+	Status BootStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// GoBootList contains a list of GoBoot
+type GoBootList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GoBoot `json:"items"`
+}
+
+// GetBootMeta returns the GoBoot's metadata
+func (in *GoBoot) GetBootMeta() *metav1.ObjectMeta {
+	return &in.ObjectMeta
+}
+
+// GetBootSpec returns the GoBoot's spec
+func (in *GoBoot) GetBootSpec() *BootSpec {
+	return &in.Spec
+}
+
+// GetBootStatus returns the GoBoot's status
+func (in *GoBoot) GetBootStatus() *BootStatus {
+	return &in.Status
+}
+
+// Go app starts in seconds and is small, probe it fast.
+var (
+	goProbeInitialDelaySeconds = int32(5)
+	goProbePeriodSeconds       = int32(5)
+	goProbeFailureThreshold    = int32(3)
+)
+
+func init() {
+	SchemeBuilder.Register(&GoBoot{}, &GoBootList{})
+	RegisterBootType(&BootType{
+		Kind:      "GoBoot",
+		Resource:  "goboots",
+		ConfigKey: logan.BootGo,
+		AppKey:    logan.GoAppKey,
+		New:       func() BootObject { return &GoBoot{} },
+		NewList:   func() runtime.Object { return &GoBootList{} },
+		Probe: &BootProbe{
+			InitialDelaySeconds: &goProbeInitialDelaySeconds,
+			PeriodSeconds:       &goProbePeriodSeconds,
+			FailureThreshold:    &goProbeFailureThreshold,
+		},
+		Resources: &corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1"),
+				corev1.ResourceMemory: resource.MustParse("256Mi"),
+			},
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("10m"),
+				corev1.ResourceMemory: resource.MustParse("64Mi"),
+			},
+		},
+		// Go runtime uses the node's cpus as GOMAXPROCS by default, which is throttled by the cpu limits.
+		CPUEnvs: []string{"GOMAXPROCS"},
+	})
+}
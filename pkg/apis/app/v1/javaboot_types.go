@@ -1,7 +1,11 @@
 package v1
 
 import (
+	"github.com/logancloud/logan-app-operator/pkg/logan"
+	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -9,6 +13,7 @@ import (
 // JavaBoot is the Schema for the javaboots API
 // +k8s:openapi-gen=true
 // +kubebuilder:subresource:status
+// +kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.replicas,selectorpath=.status.selector
 type JavaBoot struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -26,6 +31,36 @@ type JavaBootList struct {
 	Items           []JavaBoot `json:"items"`
 }
 
+// GetBootMeta returns the JavaBoot's metadata
+func (in *JavaBoot) GetBootMeta() *metav1.ObjectMeta {
+	return &in.ObjectMeta
+}
+
+// GetBootSpec returns the JavaBoot's spec
+func (in *JavaBoot) GetBootSpec() *BootSpec {
+	return &in.Spec
+}
+
+// GetBootStatus returns the JavaBoot's status
+func (in *JavaBoot) GetBootStatus() *BootStatus {
+	return &in.Status
+}
+
+var javaMaxUnavailable = intstr.FromString("1%")
+
 func init() {
 	SchemeBuilder.Register(&JavaBoot{}, &JavaBootList{})
+	RegisterBootType(&BootType{
+		Kind:      "JavaBoot",
+		Resource:  "javaboots",
+		ConfigKey: logan.BootJava,
+		AppKey:    logan.JavaAppKey,
+		New:       func() BootObject { return &JavaBoot{} },
+		NewList:   func() runtime.Object { return &JavaBootList{} },
+		// Avoid when boot has more than 4 pods, more than one pod will be RollingUpdate.
+		Strategy: &BootStrategy{
+			Type:           appsv1.RollingUpdateDeploymentStrategyType,
+			MaxUnavailable: &javaMaxUnavailable,
+		},
+	})
 }
@@ -0,0 +1,61 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// LoganOperatorConfig is the Schema for the loganoperatorconfigs API, the operator's config. It is cluster-scoped,
+// and named as the operator's CONFIGMAP_NAME, which is migrated from the ConfigMap of the same name.
+// +k8s:openapi-gen=true
+// +genclient:nonNamespaced
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+type LoganOperatorConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// spec contains the operator config
+	Spec LoganOperatorConfigSpec `json:"spec,omitempty"`
+	// status contains the config loaded by the running operator
+	Status LoganOperatorConfigStatus `json:"status,omitempty"`
+}
+
+// LoganOperatorConfigSpec defines the operator config
+// +k8s:openapi-gen=true
+type LoganOperatorConfigSpec struct {
+	// Config is the operator config keyed by the boot type(java, php, ...), the other keys are the profiles
+	// selected by the Boot's annotation "logan/profile", or the namespace overlays.
+	Config map[string]*OperatorConfig `json:"config,omitempty"`
+}
+
+// LoganOperatorConfigStatus defines the observed state of LoganOperatorConfig
+// +k8s:openapi-gen=true
+type LoganOperatorConfigStatus struct {
+	// ObservedGeneration is the most recent generation observed by the operator.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// LoadedGeneration is the generation loaded by the running operator, the last valid generation observed.
+	// +optional
+	LoadedGeneration int64 `json:"loadedGeneration,omitempty"`
+	// LoadedTime is the last time the config is loaded by the running operator.
+	// +optional
+	LoadedTime *metav1.Time `json:"loadedTime,omitempty"`
+	// Message is the error of the observed generation if it is invalid, then the loaded generation is kept.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// LoganOperatorConfigList contains a list of LoganOperatorConfig
+type LoganOperatorConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LoganOperatorConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&LoganOperatorConfig{}, &LoganOperatorConfigList{})
+}
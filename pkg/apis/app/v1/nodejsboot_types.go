@@ -1,7 +1,9 @@
 package v1
 
 import (
+	"github.com/logancloud/logan-app-operator/pkg/logan"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -9,6 +11,7 @@ import (
 // NodeJSBoot is the Schema for the nodejsboots API
 // +k8s:openapi-gen=true
 // +kubebuilder:subresource:status
+// +kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.replicas,selectorpath=.status.selector
 type NodeJSBoot struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -26,6 +29,29 @@ type NodeJSBootList struct {
 	Items           []NodeJSBoot `json:"items"`
 }
 
+// GetBootMeta returns the NodeJSBoot's metadata
+func (in *NodeJSBoot) GetBootMeta() *metav1.ObjectMeta {
+	return &in.ObjectMeta
+}
+
+// GetBootSpec returns the NodeJSBoot's spec
+func (in *NodeJSBoot) GetBootSpec() *BootSpec {
+	return &in.Spec
+}
+
+// GetBootStatus returns the NodeJSBoot's status
+func (in *NodeJSBoot) GetBootStatus() *BootStatus {
+	return &in.Status
+}
+
 func init() {
 	SchemeBuilder.Register(&NodeJSBoot{}, &NodeJSBootList{})
+	RegisterBootType(&BootType{
+		Kind:      "NodeJSBoot",
+		Resource:  "nodejsboots",
+		ConfigKey: logan.BootNodeJS,
+		AppKey:    logan.NodeJSAppKey,
+		New:       func() BootObject { return &NodeJSBoot{} },
+		NewList:   func() runtime.Object { return &NodeJSBootList{} },
+	})
 }
@@ -0,0 +1,213 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OperatorConfig is the struct for boot's global config
+//   - Operator's default settings：settings
+//   - operator's env specific config，oEnvs
+//   - Container Info
+//     1. application(app) container config: app
+//     2. sidecar containers：sidecarContainers
+//     3. sidecar services：sidecarServices
+//   - Profile's bases: extends
+//   - Namespace overlay's selector: overlay
+//
+// +k8s:openapi-gen=true
+type OperatorConfig struct {
+	// Extends is the boot types or the profiles inherited by the profile, only the declared config is overridden.
+	Extends Extends `json:"extends,omitempty"`
+
+	// Overlay makes the config a namespace overlay, applied to the Boots in the namespaces selected.
+	Overlay *OverlaySelector `json:"overlay,omitempty"`
+
+	// Operator配置信息
+	Settings *SettingsConfig `json:"settings,omitempty"`
+
+	// Operator的环境信息配置, keyed by the container(app, sidecar) and the env.
+	// +k8s:openapi-gen=false
+	OEnvs map[string]map[string]AppSpec `json:"oEnvs,omitempty"`
+
+	// Operator的默认App配置
+	AppSpec *AppSpec `json:"app,omitempty"`
+
+	//Operator的默认SidecarContainers配置
+	SidecarContainers *[]corev1.Container `json:"sideCarContainers,omitempty"`
+
+	// Sidecar的Service列表
+	SidecarServices *[]SidecarService `json:"sidecarServices,omitempty"`
+}
+
+// Extends is the boot types or the profiles a profile inherits, support a single key as "extends: java",
+// or the list of keys as "extends: [java, tracing]". It is always a list in the LoganOperatorConfig.
+type Extends []string
+
+// UnmarshalJSON unmarshals the single key or the list of keys
+func (e *Extends) UnmarshalJSON(data []byte) error {
+	var key string
+	if err := json.Unmarshal(data, &key); err == nil {
+		*e = Extends{key}
+		return nil
+	}
+
+	var keys []string
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return fmt.Errorf("extends must be a key or a list of keys: %s", err.Error())
+	}
+	*e = keys
+	return nil
+}
+
+// OverlaySelector selects the namespaces, whose Boots the overlay is applied to. The namespace must match both
+// the namespaceSelector and the namespaceAnnotations if specified.
+// +k8s:openapi-gen=true
+type OverlaySelector struct {
+	// NamespaceSelector selects the namespaces by the labels, as "matchLabels: {logan/team: payments}".
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// NamespaceAnnotations selects the namespaces by the annotations, all the annotations must be equal.
+	NamespaceAnnotations map[string]string `json:"namespaceAnnotations,omitempty"`
+	// Types is the boot types' config keys the overlay is applied to, as "java", all the types if empty.
+	Types []string `json:"types,omitempty"`
+	// Priority is the order of the overlays applied, the lower is applied first, and the later overrides.
+	// The overlays of the same priority are applied in the order of their keys.
+	Priority int32 `json:"priority,omitempty"`
+}
+
+// SettingsConfig is the common struct for Settings
+// +k8s:openapi-gen=true
+type SettingsConfig struct {
+	// Registry is the image registry, replacing ${REGISTRY} in the images.
+	Registry string `json:"registry,omitempty"`
+	// AppHealthPort is the port of the app container's health check, default is the app's port.
+	AppHealthPort int32 `json:"appHealthPort,omitempty"`
+	// PrometheusScrape is whether the Boot's service is scraped by prometheus, default is true.
+	PrometheusScrape *bool `json:"prometheusScrape,omitempty"`
+}
+
+// AppSpec define the App spec
+// +k8s:openapi-gen=true
+type AppSpec struct {
+	// Type is the app's type.
+	Type string `json:"type,omitempty"`
+	// Port is the default port of the app container.
+	Port int32 `json:"port,omitempty"`
+	// Replicas is the default replicas of the Boots.
+	Replicas int32 `json:"replicas,omitempty"`
+	// Health is the default health check path of the app container.
+	Health string `json:"health,omitempty"`
+	// Env is the envs merged into the Boots, support ${APP} and ${ENV}.
+	Env []corev1.EnvVar `json:"env,omitempty"`
+	// Resources is the default compute resource requirements of the app container.
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+	// NodeSelector is always merged into the Boots' pods.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// SubDomain is the default domain suffix of the Boots' Ingress.
+	SubDomain string `json:"subDomain,omitempty"`
+
+	// PodSpec is merged into the Boots' pods, as initContainers, volumes.
+	PodSpec *corev1.PodSpec `json:"podSpec,omitempty"`
+	// Container is merged into the app container, as volumeMounts.
+	Container *corev1.Container `json:"container,omitempty"`
+	// Settings is the settings of the app, overridden by the config's settings.
+	Settings *SettingsConfig `json:"settings,omitempty"`
+
+	// Autoscaling is the default HorizontalPodAutoscaler settings for the Boots
+	Autoscaling *BootAutoscaling `json:"autoscaling,omitempty"`
+	// Ingress is the Ingress settings for the Boots, Ingress is created when Boot's subDomain is not empty.
+	Ingress *IngressConfig `json:"ingress,omitempty"`
+
+	// Strategy is the default rollout strategy for the Boots, overridden by Boot's strategy.
+	Strategy *BootStrategy `json:"strategy,omitempty"`
+
+	// RestartSchedule is the default scheduled restart policy for the Boots, overridden by Boot's restartSchedule.
+	RestartSchedule *BootRestartSchedule `json:"restartSchedule,omitempty"`
+
+	// Scheduling is the scheduling settings for the Boots: defaults and mandatory overlays.
+	Scheduling *SchedulingConfig `json:"scheduling,omitempty"`
+
+	// LivenessProbe is the default liveness probe settings for the Boots, overridden by Boot's livenessProbe.
+	LivenessProbe *BootProbe `json:"livenessProbe,omitempty"`
+	// ReadinessProbe is the default readiness probe settings for the Boots, overridden by Boot's readinessProbe.
+	ReadinessProbe *BootProbe `json:"readinessProbe,omitempty"`
+
+	// JVM is the JVM flags policy for the Boots, computed from the app container's resource limits. Used by JavaBoot.
+	JVM *JVMConfig `json:"jvm,omitempty"`
+}
+
+// IngressConfig define the Ingress generated from Boot's subDomain
+// +k8s:openapi-gen=true
+type IngressConfig struct {
+	// Enabled is whether to create the Ingress, default is true.
+	Enabled *bool `json:"enabled,omitempty"`
+	// Host is the host template, support ${APP}, ${ENV} and ${SUBDOMAIN}, default is "${APP}.${SUBDOMAIN}".
+	Host string `json:"host,omitempty"`
+	// Class is the ingress class, set by annotation "kubernetes.io/ingress.class".
+	Class string `json:"class,omitempty"`
+	// Annotations is the additional annotations for the Ingress.
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// TLSSecretName is the TLS secret's name for the host, support ${APP} and ${ENV}. TLS is not set if empty.
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+	// Paths is the path list routing to the Boot's service, default is ["/"].
+	Paths []string `json:"paths,omitempty"`
+}
+
+// JVMConfig define the JVM flags computed from the app container's resource limits, the flags are injected into
+// the env and merged with the Boot's value of the env: the flags specified by the Boot are kept.
+// +k8s:openapi-gen=true
+type JVMConfig struct {
+	// Enabled is whether to inject the JVM flags, default is true.
+	Enabled *bool `json:"enabled,omitempty"`
+	// EnvName is the env's name of the JVM flags, default is "JAVA_OPTS".
+	EnvName string `json:"envName,omitempty"`
+	// HeapPercentage is the max heap(-Xmx) as the percentage of the memory limits, default is 75.
+	HeapPercentage int32 `json:"heapPercentage,omitempty"`
+	// InitialHeapPercentage is the initial heap(-Xms) as the percentage of the memory limits, not set if 0.
+	InitialHeapPercentage int32 `json:"initialHeapPercentage,omitempty"`
+	// GC is the garbage collector, as G1GC, ParallelGC, set as "-XX:+Use<GC>". Not set if empty.
+	GC string `json:"gc,omitempty"`
+	// ActiveProcessorCount is whether to set "-XX:ActiveProcessorCount" as the cpu limits rounded up, default is true.
+	ActiveProcessorCount *bool `json:"activeProcessorCount,omitempty"`
+	// Options is the additional flags, as "-XX:+ExitOnOutOfMemoryError".
+	Options []string `json:"options,omitempty"`
+}
+
+// SchedulingConfig define the scheduling settings for the Boots
+// +k8s:openapi-gen=true
+type SchedulingConfig struct {
+	// Defaults is used when the Boot do not specify the field.
+	Defaults *SchedulingSpec `json:"defaults,omitempty"`
+	// Mandatory is always merged into the Boot, as the config's nodeSelector.
+	//	- tolerations: added or replaced by key and effect
+	//	- nodeAffinity: required match expressions are added to every Boot's node selector term, preferred terms are added
+	//	- topologySpread: added or replaced by topologyKey
+	//	- priorityClassName, runtimeClassName: replaced
+	Mandatory *SchedulingSpec `json:"mandatory,omitempty"`
+}
+
+// SchedulingSpec define the scheduling fields of the Boot
+// +k8s:openapi-gen=true
+type SchedulingSpec struct {
+	// Tolerations is the pod's tolerations.
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// NodeAffinity is the pod's node affinity.
+	NodeAffinity *corev1.NodeAffinity `json:"nodeAffinity,omitempty"`
+	// TopologySpread is how the pods spread across the topology domains.
+	TopologySpread []BootTopologySpread `json:"topologySpread,omitempty"`
+	// PriorityClassName is the pod's priority class name.
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+	// RuntimeClassName is the pod's runtime class name.
+	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
+}
+
+// SidecarService define the service for Sidecar
+// +k8s:openapi-gen=true
+type SidecarService struct {
+	// Name is the service's name, support ${APP}.
+	Name string `json:"name"`
+	// Port is the sidecar's port exposed by the service.
+	Port int32 `json:"port"`
+}
@@ -1,7 +1,9 @@
 package v1
 
 import (
+	"github.com/logancloud/logan-app-operator/pkg/logan"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -9,6 +11,7 @@ import (
 // PhpBoot is the Schema for the phpboots API
 // +k8s:openapi-gen=true
 // +kubebuilder:subresource:status
+// +kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.replicas,selectorpath=.status.selector
 type PhpBoot struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -26,6 +29,29 @@ type PhpBootList struct {
 	Items           []PhpBoot `json:"items"`
 }
 
+// GetBootMeta returns the PhpBoot's metadata
+func (in *PhpBoot) GetBootMeta() *metav1.ObjectMeta {
+	return &in.ObjectMeta
+}
+
+// GetBootSpec returns the PhpBoot's spec
+func (in *PhpBoot) GetBootSpec() *BootSpec {
+	return &in.Spec
+}
+
+// GetBootStatus returns the PhpBoot's status
+func (in *PhpBoot) GetBootStatus() *BootStatus {
+	return &in.Status
+}
+
 func init() {
 	SchemeBuilder.Register(&PhpBoot{}, &PhpBootList{})
+	RegisterBootType(&BootType{
+		Kind:      "PhpBoot",
+		Resource:  "phpboots",
+		ConfigKey: logan.BootPhp,
+		AppKey:    logan.PhpAppKey,
+		New:       func() BootObject { return &PhpBoot{} },
+		NewList:   func() runtime.Object { return &PhpBootList{} },
+	})
 }
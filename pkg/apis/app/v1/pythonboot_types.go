@@ -1,7 +1,9 @@
 package v1
 
 import (
+	"github.com/logancloud/logan-app-operator/pkg/logan"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -9,6 +11,7 @@ import (
 // PythonBoot is the Schema for the pythonboots API
 // +k8s:openapi-gen=true
 // +kubebuilder:subresource:status
+// +kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.replicas,selectorpath=.status.selector
 type PythonBoot struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -26,6 +29,35 @@ type PythonBootList struct {
 	Items           []PythonBoot `json:"items"`
 }
 
+// GetBootMeta returns the PythonBoot's metadata
+func (in *PythonBoot) GetBootMeta() *metav1.ObjectMeta {
+	return &in.ObjectMeta
+}
+
+// GetBootSpec returns the PythonBoot's spec
+func (in *PythonBoot) GetBootSpec() *BootSpec {
+	return &in.Spec
+}
+
+// GetBootStatus returns the PythonBoot's status
+func (in *PythonBoot) GetBootStatus() *BootStatus {
+	return &in.Status
+}
+
+var pythonProbeFailureThreshold = int32(15)
+
 func init() {
 	SchemeBuilder.Register(&PythonBoot{}, &PythonBootList{})
+	RegisterBootType(&BootType{
+		Kind:      "PythonBoot",
+		Resource:  "pythonboots",
+		ConfigKey: logan.BootPython,
+		AppKey:    logan.PythonAppKey,
+		New:       func() BootObject { return &PythonBoot{} },
+		NewList:   func() runtime.Object { return &PythonBootList{} },
+		// havok issue #95
+		Probe: &BootProbe{
+			FailureThreshold: &pythonProbeFailureThreshold,
+		},
+	})
 }
@@ -1,7 +1,9 @@
 package v1
 
 import (
+	"github.com/logancloud/logan-app-operator/pkg/logan"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -9,6 +11,7 @@ import (
 // WebBoot is the Schema for the webboots API
 // +k8s:openapi-gen=true
 // +kubebuilder:subresource:status
+// +kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.replicas,selectorpath=.status.selector
 type WebBoot struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -26,6 +29,29 @@ type WebBootList struct {
 	Items           []WebBoot `json:"items"`
 }
 
+// GetBootMeta returns the WebBoot's metadata
+func (in *WebBoot) GetBootMeta() *metav1.ObjectMeta {
+	return &in.ObjectMeta
+}
+
+// GetBootSpec returns the WebBoot's spec
+func (in *WebBoot) GetBootSpec() *BootSpec {
+	return &in.Spec
+}
+
+// GetBootStatus returns the WebBoot's status
+func (in *WebBoot) GetBootStatus() *BootStatus {
+	return &in.Status
+}
+
 func init() {
 	SchemeBuilder.Register(&WebBoot{}, &WebBootList{})
+	RegisterBootType(&BootType{
+		Kind:      "WebBoot",
+		Resource:  "webboots",
+		ConfigKey: logan.BootWeb,
+		AppKey:    logan.WebAppKey,
+		New:       func() BootObject { return &WebBoot{} },
+		NewList:   func() runtime.Object { return &WebBootList{} },
+	})
 }
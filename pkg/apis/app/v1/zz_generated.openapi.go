@@ -11,19 +11,191 @@ import (
 
 func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
 	return map[string]common.OpenAPIDefinition{
+		"./pkg/apis/app/v1.AppSpec":                    schema_pkg_apis_app_v1_AppSpec(ref),
 		"./pkg/apis/app/v1.Boot":                       schema_pkg_apis_app_v1_Boot(ref),
+		"./pkg/apis/app/v1.BootAutoscaling":            schema_pkg_apis_app_v1_BootAutoscaling(ref),
+		"./pkg/apis/app/v1.BootCondition":              schema_pkg_apis_app_v1_BootCondition(ref),
+		"./pkg/apis/app/v1.BootJob":                    schema_pkg_apis_app_v1_BootJob(ref),
+		"./pkg/apis/app/v1.BootPort":                   schema_pkg_apis_app_v1_BootPort(ref),
+		"./pkg/apis/app/v1.BootProbe":                  schema_pkg_apis_app_v1_BootProbe(ref),
+		"./pkg/apis/app/v1.BootRestartSchedule":        schema_pkg_apis_app_v1_BootRestartSchedule(ref),
 		"./pkg/apis/app/v1.BootRevision":               schema_pkg_apis_app_v1_BootRevision(ref),
+		"./pkg/apis/app/v1.BootScalingSchedule":        schema_pkg_apis_app_v1_BootScalingSchedule(ref),
 		"./pkg/apis/app/v1.BootSpec":                   schema_pkg_apis_app_v1_BootSpec(ref),
 		"./pkg/apis/app/v1.BootStatus":                 schema_pkg_apis_app_v1_BootStatus(ref),
+		"./pkg/apis/app/v1.BootStrategy":               schema_pkg_apis_app_v1_BootStrategy(ref),
+		"./pkg/apis/app/v1.BootTopologySpread":         schema_pkg_apis_app_v1_BootTopologySpread(ref),
+		"./pkg/apis/app/v1.DotnetBoot":                 schema_pkg_apis_app_v1_DotnetBoot(ref),
+		"./pkg/apis/app/v1.GoBoot":                     schema_pkg_apis_app_v1_GoBoot(ref),
+		"./pkg/apis/app/v1.IngressConfig":              schema_pkg_apis_app_v1_IngressConfig(ref),
+		"./pkg/apis/app/v1.JVMConfig":                  schema_pkg_apis_app_v1_JVMConfig(ref),
 		"./pkg/apis/app/v1.JavaBoot":                   schema_pkg_apis_app_v1_JavaBoot(ref),
+		"./pkg/apis/app/v1.LoganOperatorConfig":        schema_pkg_apis_app_v1_LoganOperatorConfig(ref),
+		"./pkg/apis/app/v1.LoganOperatorConfigSpec":    schema_pkg_apis_app_v1_LoganOperatorConfigSpec(ref),
+		"./pkg/apis/app/v1.LoganOperatorConfigStatus":  schema_pkg_apis_app_v1_LoganOperatorConfigStatus(ref),
 		"./pkg/apis/app/v1.NodeJSBoot":                 schema_pkg_apis_app_v1_NodeJSBoot(ref),
+		"./pkg/apis/app/v1.OperatorConfig":             schema_pkg_apis_app_v1_OperatorConfig(ref),
+		"./pkg/apis/app/v1.OverlaySelector":            schema_pkg_apis_app_v1_OverlaySelector(ref),
 		"./pkg/apis/app/v1.PersistentVolumeClaimMount": schema_pkg_apis_app_v1_PersistentVolumeClaimMount(ref),
 		"./pkg/apis/app/v1.PhpBoot":                    schema_pkg_apis_app_v1_PhpBoot(ref),
 		"./pkg/apis/app/v1.PythonBoot":                 schema_pkg_apis_app_v1_PythonBoot(ref),
+		"./pkg/apis/app/v1.SchedulingConfig":           schema_pkg_apis_app_v1_SchedulingConfig(ref),
+		"./pkg/apis/app/v1.SchedulingSpec":             schema_pkg_apis_app_v1_SchedulingSpec(ref),
+		"./pkg/apis/app/v1.SettingsConfig":             schema_pkg_apis_app_v1_SettingsConfig(ref),
+		"./pkg/apis/app/v1.SidecarService":             schema_pkg_apis_app_v1_SidecarService(ref),
 		"./pkg/apis/app/v1.WebBoot":                    schema_pkg_apis_app_v1_WebBoot(ref),
 	}
 }
 
+func schema_pkg_apis_app_v1_AppSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "AppSpec define the App spec",
+				Properties: map[string]spec.Schema{
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type is the app's type.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"port": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Port is the default port of the app container.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"replicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Replicas is the default replicas of the Boots.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"health": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Health is the default health check path of the app container.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"env": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Env is the envs merged into the Boots, support ${APP} and ${ENV}.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/api/core/v1.EnvVar"),
+									},
+								},
+							},
+						},
+					},
+					"resources": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Resources is the default compute resource requirements of the app container.",
+							Ref:         ref("k8s.io/api/core/v1.ResourceRequirements"),
+						},
+					},
+					"nodeSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeSelector is always merged into the Boots' pods.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"subDomain": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SubDomain is the default domain suffix of the Boots' Ingress.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"podSpec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodSpec is merged into the Boots' pods, as initContainers, volumes.",
+							Ref:         ref("k8s.io/api/core/v1.PodSpec"),
+						},
+					},
+					"container": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Container is merged into the app container, as volumeMounts.",
+							Ref:         ref("k8s.io/api/core/v1.Container"),
+						},
+					},
+					"settings": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Settings is the settings of the app, overridden by the config's settings.",
+							Ref:         ref("./pkg/apis/app/v1.SettingsConfig"),
+						},
+					},
+					"autoscaling": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Autoscaling is the default HorizontalPodAutoscaler settings for the Boots",
+							Ref:         ref("./pkg/apis/app/v1.BootAutoscaling"),
+						},
+					},
+					"ingress": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Ingress is the Ingress settings for the Boots, Ingress is created when Boot's subDomain is not empty.",
+							Ref:         ref("./pkg/apis/app/v1.IngressConfig"),
+						},
+					},
+					"strategy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Strategy is the default rollout strategy for the Boots, overridden by Boot's strategy.",
+							Ref:         ref("./pkg/apis/app/v1.BootStrategy"),
+						},
+					},
+					"restartSchedule": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RestartSchedule is the default scheduled restart policy for the Boots, overridden by Boot's restartSchedule.",
+							Ref:         ref("./pkg/apis/app/v1.BootRestartSchedule"),
+						},
+					},
+					"scheduling": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Scheduling is the scheduling settings for the Boots: defaults and mandatory overlays.",
+							Ref:         ref("./pkg/apis/app/v1.SchedulingConfig"),
+						},
+					},
+					"livenessProbe": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LivenessProbe is the default liveness probe settings for the Boots, overridden by Boot's livenessProbe.",
+							Ref:         ref("./pkg/apis/app/v1.BootProbe"),
+						},
+					},
+					"readinessProbe": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ReadinessProbe is the default readiness probe settings for the Boots, overridden by Boot's readinessProbe.",
+							Ref:         ref("./pkg/apis/app/v1.BootProbe"),
+						},
+					},
+					"jvm": {
+						SchemaProps: spec.SchemaProps{
+							Description: "JVM is the JVM flags policy for the Boots, computed from the app container's resource limits. Used by JavaBoot.",
+							Ref:         ref("./pkg/apis/app/v1.JVMConfig"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"./pkg/apis/app/v1.BootAutoscaling", "./pkg/apis/app/v1.BootProbe", "./pkg/apis/app/v1.BootRestartSchedule", "./pkg/apis/app/v1.BootStrategy", "./pkg/apis/app/v1.IngressConfig", "./pkg/apis/app/v1.JVMConfig", "./pkg/apis/app/v1.SchedulingConfig", "./pkg/apis/app/v1.SettingsConfig", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.PodSpec", "k8s.io/api/core/v1.ResourceRequirements"},
+	}
+}
+
 func schema_pkg_apis_app_v1_Boot(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -82,250 +254,1630 @@ func schema_pkg_apis_app_v1_Boot(ref common.ReferenceCallback) common.OpenAPIDef
 	}
 }
 
-func schema_pkg_apis_app_v1_BootRevision(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_app_v1_BootAutoscaling(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "BootRevision is the Schema for the bootrevisions API",
+				Description: "BootAutoscaling defines the HorizontalPodAutoscaler settings of the Boot",
 				Properties: map[string]spec.Schema{
-					"kind": {
+					"enabled": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Description: "Enabled is whether to create the HorizontalPodAutoscaler for the Boot. Defaults to true if the autoscaling is specified.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"minReplicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MinReplicas is the lower limit for the number of replicas. Defaults to 1.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"maxReplicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxReplicas is the upper limit for the number of replicas. It cannot be less than minReplicas.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"targetCPUUtilizationPercentage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TargetCPUUtilizationPercentage is the target average CPU utilization over all the pods, represented as a percentage of the requested CPU.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"targetMemoryUtilizationPercentage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TargetMemoryUtilizationPercentage is the target average memory utilization over all the pods, represented as a percentage of the requested memory.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"metrics": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Metrics contains the custom metric targets, appended to the cpu/memory targets.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/api/autoscaling/v2beta2.MetricSpec"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/api/autoscaling/v2beta2.MetricSpec"},
+	}
+}
+
+func schema_pkg_apis_app_v1_BootCondition(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BootCondition describes the state of a Boot at a certain point. It follows the shape of the upstream metav1.Condition, which is not available in the pinned kubernetes version.",
+				Properties: map[string]spec.Schema{
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type of Boot condition.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"apiVersion": {
+					"status": {
 						SchemaProps: spec.SchemaProps{
-							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Description: "Status of the condition, one of True, False, Unknown.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"metadata": {
+					"observedGeneration": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+							Description: "ObservedGeneration represents the Boot's generation that the condition was set based upon.",
+							Type:        []string{"integer"},
+							Format:      "int64",
 						},
 					},
-					"spec": {
+					"lastTransitionTime": {
 						SchemaProps: spec.SchemaProps{
-							Description: "spec contains the desired behavior of the Boot",
-							Ref:         ref("./pkg/apis/app/v1.BootSpec"),
+							Description: "LastTransitionTime is the last time the condition transitioned from one status to another.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
-					"status": {
+					"reason": {
 						SchemaProps: spec.SchemaProps{
-							Description: "status contains the last observed state of the BootStatus",
-							Ref:         ref("./pkg/apis/app/v1.BootStatus"),
+							Description: "Reason is a unique, one-word, CamelCase reason for the condition's last transition.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"bootType": {
+					"message": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "Message is a human readable message indicating details about the transition.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"appKey": {
+				},
+				Required: []string{"type", "status"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_app_v1_BootJob(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BootJob defines the settings of the Boot's Job or CronJob workload",
+				Properties: map[string]spec.Schema{
+					"schedule": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "Schedule is the CronJob's cron expression \"minute hour dayOfMonth month dayOfWeek\" in the kube-controller-manager's timezone. Required by the CronJob workload.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"concurrencyPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ConcurrencyPolicy is how the CronJob treats the concurrent runs, \"Allow\", \"Forbid\" or \"Replace\". Defaults to \"Forbid\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"startingDeadlineSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StartingDeadlineSeconds is the deadline for starting the CronJob's run if it misses the scheduled time.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"successfulJobsHistoryLimit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SuccessfulJobsHistoryLimit is the number of the CronJob's successful Jobs to keep. Defaults to 3.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"failedJobsHistoryLimit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FailedJobsHistoryLimit is the number of the CronJob's failed Jobs to keep. Defaults to 1.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"backoffLimit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BackoffLimit is the number of retries before the Job is marked failed. Defaults to 6.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"activeDeadlineSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ActiveDeadlineSeconds is the duration the Job may be active before it is terminated.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"restartPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RestartPolicy is the Job pod's restart policy, \"OnFailure\" or \"Never\". Defaults to \"OnFailure\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_app_v1_BootPort(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BootPort defines a named port exposed by the Boot's app container and app service",
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the port, must be an IANA_SVC_NAME and unique within the Boot.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"containerPort": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ContainerPort is the port number exposed on the app container.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"servicePort": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ServicePort is the port number exposed by the app service. Defaults to containerPort.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"protocol": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Protocol for the port, must be UDP, TCP or SCTP. Defaults to \"TCP\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"appProtocol": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AppProtocol is the application protocol of the port, such as http, grpc. It is recorded in the app service's annotation \"app.logancloud.com/app-protocols\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name", "containerPort"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_app_v1_BootProbe(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BootProbe defines a probe of the Boot's app container. Empty fields are defaulted by the operator config.",
+				Properties: map[string]spec.Schema{
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type is the handler type of the probe, one of http, tcp, exec, grpc. Defaults to http.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"path": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Path is the path of the http probe.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"port": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Port is the port of the http/tcp/grpc probe. Defaults to the primary port, or the config's appHealthPort.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"command": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Command is the command of the exec probe.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"service": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Service is the service name of the grpc probe.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"initialDelaySeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "InitialDelaySeconds is the number of seconds after the container has started before the probe is initiated.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"timeoutSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TimeoutSeconds is the number of seconds after which the probe times out.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"periodSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PeriodSeconds is how often (in seconds) to perform the probe.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"successThreshold": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SuccessThreshold is the minimum consecutive successes for the probe to be considered successful after having failed. Must be 1 for liveness.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"failureThreshold": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FailureThreshold is the minimum consecutive failures for the probe to be considered failed after having succeeded.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_app_v1_BootRestartSchedule(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BootRestartSchedule defines the scheduled rolling restart of the Boot",
+				Properties: map[string]spec.Schema{
+					"enabled": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Enabled is whether to restart the Boot on schedule. Defaults to true if the schedule is set.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"schedule": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Schedule is the cron expression \"minute hour dayOfMonth month dayOfWeek\" in the operator's timezone.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"maxJitterSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxJitterSeconds is the upper limit of the delay after the schedule fires, to spread the restarts of the Boots. The delay is stable for the Boot and the schedule's time. Defaults to 0.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_app_v1_BootRevision(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BootRevision is the Schema for the bootrevisions API",
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "spec contains the desired behavior of the Boot",
+							Ref:         ref("./pkg/apis/app/v1.BootSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Description: "status contains the last observed state of the BootStatus",
+							Ref:         ref("./pkg/apis/app/v1.BootStatus"),
+						},
+					},
+					"bootType": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"appKey": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+				},
+				Required: []string{"bootType", "appKey"},
+			},
+		},
+		Dependencies: []string{
+			"./pkg/apis/app/v1.BootSpec", "./pkg/apis/app/v1.BootStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_app_v1_BootScalingSchedule(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BootScalingSchedule defines a cron-style scaling window of the Boot",
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the unique name of the schedule.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"schedule": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Schedule is the cron expression \"minute hour dayOfMonth month dayOfWeek\" when the window starts. The window ends when another schedule of the Boot fires.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"replicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Replicas is the number of replicas in the window. Defaults to the Boot's replicas, used to scale back to spec.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"name", "schedule"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_app_v1_BootSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BootSpec defines the desired state of Boot for specified types, as JavaBoot/PhpBoot/PythonBoot/NodeJSBoot",
+				Properties: map[string]spec.Schema{
+					"image": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Image is the app container' image. Image must not have a tag version.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"version": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Version is the app container's image version.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"replicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Replicas is the number of desired replicas. This is a pointer to distinguish between explicit zero and unspecified. Defaults to 1.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"suspend": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Suspend scales the Boot's workload to zero, and pauses the reconciliation and revision recording. The prior replicas are restored when resumed. Could also be set by the annotation \"app.logancloud.com/suspend: true\".",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"workloadType": {
+						SchemaProps: spec.SchemaProps{
+							Description: "WorkloadType is the type of the Boot's workload, \"Deployment\", \"StatefulSet\", \"Job\" or \"CronJob\". Defaults to \"Deployment\". StatefulSet gives the pods stable names through a headless Service, and per-pod PVCs from the Boot's own pvc. Job and CronJob run the app container to completion, without Service. It could not be changed after created.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"podManagementPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodManagementPolicy is how the StatefulSet's pods are created and deleted, \"OrderedReady\" or \"Parallel\". Defaults to \"OrderedReady\". Only used by the StatefulSet workload, could not be changed after created.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"job": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Job is the settings of the Job or CronJob workload, the schedule is required by CronJob.",
+							Ref:         ref("./pkg/apis/app/v1.BootJob"),
+						},
+					},
+					"env": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-patch-merge-key": "name",
+								"x-kubernetes-patch-strategy":  "merge",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Env is list of environment variables to set in the app container.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/api/core/v1.EnvVar"),
+									},
+								},
+							},
+						},
+					},
+					"port": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Port that are exposed by the app container, it is the shorthand of a single \"http\" port. If Ports is set, Port is set to the containerPort of the primary port.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"ports": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-patch-merge-key": "name",
+								"x-kubernetes-patch-strategy":  "merge",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Ports is the list of named ports exposed by the app container and the app service. The primary port is the port named \"http\", or the first port if not found, which is used by health check and Ingress.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("./pkg/apis/app/v1.BootPort"),
+									},
+								},
+							},
+						},
+					},
+					"subDomain": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SubDomain is the domain suffix of the Boot's created Ingress, the host is \"<name>.<subDomain>\" by default. If empty, the Ingress will not be created.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"health": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Health is check path for the app container.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"readiness": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Readiness is a readiness check path for the app container.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"livenessProbe": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LivenessProbe is the liveness probe settings of the app container, merged on the operator config's livenessProbe. The http probe's path defaults to Health.",
+							Ref:         ref("./pkg/apis/app/v1.BootProbe"),
+						},
+					},
+					"readinessProbe": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ReadinessProbe is the readiness probe settings of the app container, merged on the operator config's readinessProbe. The http probe's path defaults to Readiness, or Health if Readiness is empty.",
+							Ref:         ref("./pkg/apis/app/v1.BootProbe"),
+						},
+					},
+					"prometheus": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Prometheus will scrape metrics from the service, default is `true`",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"resources": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Resources is the compute resource requirements for the app container",
+							Ref:         ref("k8s.io/api/core/v1.ResourceRequirements"),
+						},
+					},
+					"nodeSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeSelector is a selector which must be true for the pod to fit on a node. Selector which must match a node's labels for the pod to be scheduled on that node.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"tolerations": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Tolerations is the pod's tolerations, the operator config's mandatory tolerations are always merged.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/api/core/v1.Toleration"),
+									},
+								},
+							},
+						},
+					},
+					"nodeAffinity": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeAffinity is the pod's node affinity, the operator config's mandatory requirements are always merged.",
+							Ref:         ref("k8s.io/api/core/v1.NodeAffinity"),
+						},
+					},
+					"topologySpread": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-patch-merge-key": "topologyKey",
+								"x-kubernetes-patch-strategy":  "merge",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "TopologySpread is how the pods spread across the topology domains, such as zone and host. Defaults to spread across hosts, preferred with weight 100.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("./pkg/apis/app/v1.BootTopologySpread"),
+									},
+								},
+							},
+						},
+					},
+					"priorityClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PriorityClassName is the pod's priority class name.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"runtimeClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RuntimeClassName is the pod's runtime class name.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"command": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Command is command for boot's container. If empty, will use image's ENTRYPOINT, specified here if needed override.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"sessionAffinity": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SessionAffinity is SessionAffinity for boot's created service. If empty, will not set",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"nodePort": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NodePort will expose the service on each node’s IP at a random port, default is ``",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"pvc": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-patch-merge-key": "name",
+								"x-kubernetes-patch-strategy":  "merge",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "pvc is list of PersistentVolumeClaim to set in the app container.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("./pkg/apis/app/v1.PersistentVolumeClaimMount"),
+									},
+								},
+							},
+						},
+					},
+					"strategy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Strategy is the rollout strategy of the Boot's workload, merged on the operator config's strategy.",
+							Ref:         ref("./pkg/apis/app/v1.BootStrategy"),
+						},
+					},
+					"autoscaling": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Autoscaling is the HorizontalPodAutoscaler settings for the Boot's workload. When enabled, the replicas of the workload is decided by the created HorizontalPodAutoscaler.",
+							Ref:         ref("./pkg/apis/app/v1.BootAutoscaling"),
+						},
+					},
+					"scalingSchedules": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-patch-merge-key": "name",
+								"x-kubernetes-patch-strategy":  "merge",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "ScalingSchedules is the list of cron-style scaling windows in the operator's timezone. The schedule fired latest is active, its replicas override the Boot's replicas until another schedule fires. When autoscaling is enabled, the active replicas is the lower limit of the HorizontalPodAutoscaler, and zero scales to zero.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("./pkg/apis/app/v1.BootScalingSchedule"),
+									},
+								},
+							},
+						},
+					},
+					"restartSchedule": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RestartSchedule is the scheduled rolling restart of the Boot's workload, merged on the operator config's restartSchedule.",
+							Ref:         ref("./pkg/apis/app/v1.BootRestartSchedule"),
+						},
+					},
+				},
+				Required: []string{"image", "version", "prometheus"},
+			},
+		},
+		Dependencies: []string{
+			"./pkg/apis/app/v1.BootAutoscaling", "./pkg/apis/app/v1.BootJob", "./pkg/apis/app/v1.BootPort", "./pkg/apis/app/v1.BootProbe", "./pkg/apis/app/v1.BootRestartSchedule", "./pkg/apis/app/v1.BootScalingSchedule", "./pkg/apis/app/v1.BootStrategy", "./pkg/apis/app/v1.BootTopologySpread", "./pkg/apis/app/v1.PersistentVolumeClaimMount", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.NodeAffinity", "k8s.io/api/core/v1.ResourceRequirements", "k8s.io/api/core/v1.Toleration"},
+	}
+}
+
+func schema_pkg_apis_app_v1_BootStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BootStatus defines the observed state of Boot for specified types, as JavaBoot/PhpBoot/PythonBoot/NodeJSBoot",
+				Properties: map[string]spec.Schema{
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type is the workload type created for the Boot, \"deploy\", \"statefulset\", \"job\" or \"cronjob\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"deploy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Deploy is the name of the Boot's created Deployment, StatefulSet, Job or CronJob.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"services": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Services is the name list of the Boot's created services, split by ,",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"observedGeneration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ObservedGeneration is the most recent generation observed for this Boot. It corresponds to the Boot's generation, which is updated on mutation of the spec.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"replicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Replicas is the total number of non-terminated pods targeted by this Boot, used by the scale subresource.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"selector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Selector is the label selector of the Boot's pods in string form, used by the scale subresource.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"readyReplicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ReadyReplicas is the number of pods targeted by this Boot with a Ready Condition.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"availableReplicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AvailableReplicas is the number of available pods (ready for at least minReadySeconds) targeted by this Boot.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"updatedReplicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "UpdatedReplicas is the number of pods targeted by this Boot that have the desired template spec.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"revision": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Revision is the ID of the Boot's latest BootRevision.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"revisionHash": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RevisionHash is the boot hash of the Boot's latest BootRevision.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"config": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Config is how the Boot's config is resolved, as \"type=java;profile=javaext;overlays=team-payments\": the boot type, the profile and the namespace's overlays applied in order, overridden by the Boot's spec.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"phase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Phase is a simple, high-level summary of where the Boot is in its lifecycle.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"scalingSchedule": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ScalingSchedule is the name of the Boot's active scaling schedule.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"nextScheduleTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NextScheduleTime is the next time when one of the Boot's scaling schedules fires.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"lastScheduledRestartTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastScheduledRestartTime is the latest time when the Boot's restart schedule fired, which is handled.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"nextScheduledRestartTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NextScheduledRestartTime is the next time when the Boot is restarted by the restart schedule, including the jitter.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"active": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Active is the number of the Boot's running Jobs, only for the Job and CronJob workload.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"lastRunTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastRunTime is the latest time when the Boot's Job was started.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"lastSuccessTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastSuccessTime is the latest time when the Boot's Job was completed successfully.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"lastFailureTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastFailureTime is the latest time when the Boot's Job failed.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"conditions": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-patch-merge-key": "type",
+								"x-kubernetes-patch-strategy":  "merge",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Conditions represent the latest available observations of the Boot's current state.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("./pkg/apis/app/v1.BootCondition"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"./pkg/apis/app/v1.BootCondition", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_app_v1_BootStrategy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BootStrategy defines the rollout strategy of the Boot's workload. Empty fields are defaulted by the operator config.",
+				Properties: map[string]spec.Schema{
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type of the rollout, one of RollingUpdate, Recreate. Defaults to RollingUpdate.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"maxSurge": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxSurge is the maximum number of pods that can be scheduled above the desired number of pods during the RollingUpdate. Value can be an absolute number (ex: 5) or a percentage of desired pods (ex: 10%).",
+							Ref:         ref("k8s.io/apimachinery/pkg/util/intstr.IntOrString"),
+						},
+					},
+					"maxUnavailable": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxUnavailable is the maximum number of pods that can be unavailable during the RollingUpdate. Value can be an absolute number (ex: 5) or a percentage of desired pods (ex: 10%).",
+							Ref:         ref("k8s.io/apimachinery/pkg/util/intstr.IntOrString"),
+						},
+					},
+					"minReadySeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MinReadySeconds is the minimum number of seconds for which a newly created pod should be ready without any of its container crashing, for it to be considered available.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"progressDeadlineSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ProgressDeadlineSeconds is the maximum time in seconds for the rollout to make progress before it is considered to be failed.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"revisionHistoryLimit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RevisionHistoryLimit is the number of old ReplicaSets to retain to allow rollback.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"autoRollback": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AutoRollback rolls back the Boot to the last Complete or Active revision automatically, when the latest revision exceeds the progressDeadlineSeconds or its pods crash loop. Defaults to false.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"canary": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Canary enables the canary rollout: on a spec change, the current Deployment is kept as stable, and the new revision is deployed to a canary Deployment, until promoted or aborted.",
+							Ref:         ref("./pkg/apis/app/v1.BootCanary"),
+						},
+					},
+					"blueGreen": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BlueGreen enables the blue-green rollout: on a spec change, the new revision is deployed to the other color's Deployment, and the app Service is switched to it after all its pods are ready. Could not be used with canary or autoscaling.",
+							Ref:         ref("./pkg/apis/app/v1.BootBlueGreen"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"./pkg/apis/app/v1.BootBlueGreen", "./pkg/apis/app/v1.BootCanary", "k8s.io/apimachinery/pkg/util/intstr.IntOrString"},
+	}
+}
+
+func schema_pkg_apis_app_v1_BootTopologySpread(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BootTopologySpread defines how the Boot's pods spread across the topology domains. The pinned kubernetes version has no topologySpreadConstraints, it is implemented by the pod anti-affinity, so there is no maxSkew: DoNotSchedule allows at most one pod per topology domain, and ScheduleAnyway only prefers the domains without the Boot's pods.",
+				Properties: map[string]spec.Schema{
+					"topologyKey": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TopologyKey is the key of node labels, such as \"kubernetes.io/hostname\", \"failure-domain.beta.kubernetes.io/zone\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"whenUnsatisfiable": {
+						SchemaProps: spec.SchemaProps{
+							Description: "WhenUnsatisfiable is the action when the spread is not satisfied, one of DoNotSchedule, ScheduleAnyway. DoNotSchedule is the required pod anti-affinity, ScheduleAnyway is the preferred pod anti-affinity. Defaults to ScheduleAnyway.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"weight": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Weight is the weight of the preferred pod anti-affinity, in the range 1-100. Defaults to 100.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"topologyKey"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_app_v1_DotnetBoot(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DotnetBoot is the Schema for the dotnetboots API",
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("./pkg/apis/app/v1.BootSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("./pkg/apis/app/v1.BootStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"./pkg/apis/app/v1.BootSpec", "./pkg/apis/app/v1.BootStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_app_v1_GoBoot(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "GoBoot is the Schema for the goboots API",
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("./pkg/apis/app/v1.BootSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("./pkg/apis/app/v1.BootStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"./pkg/apis/app/v1.BootSpec", "./pkg/apis/app/v1.BootStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_app_v1_IngressConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "IngressConfig define the Ingress generated from Boot's subDomain",
+				Properties: map[string]spec.Schema{
+					"enabled": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Enabled is whether to create the Ingress, default is true.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"host": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Host is the host template, support ${APP}, ${ENV} and ${SUBDOMAIN}, default is \"${APP}.${SUBDOMAIN}\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"class": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Class is the ingress class, set by annotation \"kubernetes.io/ingress.class\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"annotations": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Annotations is the additional annotations for the Ingress.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"tlsSecretName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TLSSecretName is the TLS secret's name for the host, support ${APP} and ${ENV}. TLS is not set if empty.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"paths": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Paths is the path list routing to the Boot's service, default is [\"/\"].",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_app_v1_JVMConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "JVMConfig define the JVM flags computed from the app container's resource limits, the flags are injected into the env and merged with the Boot's value of the env: the flags specified by the Boot are kept.",
+				Properties: map[string]spec.Schema{
+					"enabled": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Enabled is whether to inject the JVM flags, default is true.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"envName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EnvName is the env's name of the JVM flags, default is \"JAVA_OPTS\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"heapPercentage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HeapPercentage is the max heap(-Xmx) as the percentage of the memory limits, default is 75.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"initialHeapPercentage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "InitialHeapPercentage is the initial heap(-Xms) as the percentage of the memory limits, not set if 0.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"gc": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GC is the garbage collector, as G1GC, ParallelGC, set as \"-XX:+Use<GC>\". Not set if empty.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"activeProcessorCount": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ActiveProcessorCount is whether to set \"-XX:ActiveProcessorCount\" as the cpu limits rounded up, default is true.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"options": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Options is the additional flags, as \"-XX:+ExitOnOutOfMemoryError\".",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_app_v1_JavaBoot(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "JavaBoot is the Schema for the javaboots API",
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("./pkg/apis/app/v1.BootSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("./pkg/apis/app/v1.BootStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"./pkg/apis/app/v1.BootSpec", "./pkg/apis/app/v1.BootStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_app_v1_LoganOperatorConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "LoganOperatorConfig is the Schema for the loganoperatorconfigs API, the operator's config. It is cluster-scoped, and named as the operator's CONFIGMAP_NAME, which is migrated from the ConfigMap of the same name.",
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "spec contains the operator config",
+							Ref:         ref("./pkg/apis/app/v1.LoganOperatorConfigSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Description: "status contains the config loaded by the running operator",
+							Ref:         ref("./pkg/apis/app/v1.LoganOperatorConfigStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"./pkg/apis/app/v1.LoganOperatorConfigSpec", "./pkg/apis/app/v1.LoganOperatorConfigStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_app_v1_LoganOperatorConfigSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "LoganOperatorConfigSpec defines the operator config",
+				Properties: map[string]spec.Schema{
+					"config": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Config is the operator config keyed by the boot type(java, php, ...), the other keys are the profiles selected by the Boot's annotation \"logan/profile\", or the namespace overlays.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("./pkg/apis/app/v1.OperatorConfig"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"./pkg/apis/app/v1.OperatorConfig"},
+	}
+}
+
+func schema_pkg_apis_app_v1_LoganOperatorConfigStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "LoganOperatorConfigStatus defines the observed state of LoganOperatorConfig",
+				Properties: map[string]spec.Schema{
+					"observedGeneration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ObservedGeneration is the most recent generation observed by the operator.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"loadedGeneration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LoadedGeneration is the generation loaded by the running operator, the last valid generation observed.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"loadedTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LoadedTime is the last time the config is loaded by the running operator.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message is the error of the observed generation if it is invalid, then the loaded generation is kept.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
 				},
-				Required: []string{"bootType", "appKey"},
 			},
 		},
 		Dependencies: []string{
-			"./pkg/apis/app/v1.BootSpec", "./pkg/apis/app/v1.BootStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
-func schema_pkg_apis_app_v1_BootSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_app_v1_NodeJSBoot(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "BootSpec defines the desired state of Boot for specified types, as JavaBoot/PhpBoot/PythonBoot/NodeJSBoot",
+				Description: "NodeJSBoot is the Schema for the nodejsboots API",
 				Properties: map[string]spec.Schema{
-					"image": {
+					"kind": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Image is the app container' image. Image must not have a tag version.",
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"version": {
+					"apiVersion": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Version is the app container's image version.",
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"replicas": {
+					"metadata": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Replicas is the number of desired replicas. This is a pointer to distinguish between explicit zero and unspecified. Defaults to 1.",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
 						},
 					},
-					"env": {
-						VendorExtensible: spec.VendorExtensible{
-							Extensions: spec.Extensions{
-								"x-kubernetes-patch-merge-key": "name",
-								"x-kubernetes-patch-strategy":  "merge",
-							},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("./pkg/apis/app/v1.BootSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("./pkg/apis/app/v1.BootStatus"),
 						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"./pkg/apis/app/v1.BootSpec", "./pkg/apis/app/v1.BootStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_app_v1_OperatorConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "OperatorConfig is the struct for boot's global config\n  - Operator's default settings：settings\n  - operator's env specific config，oEnvs\n  - Container Info\n    1. application(app) container config: app\n    2. sidecar containers：sidecarContainers\n    3. sidecar services：sidecarServices\n  - Profile's bases: extends\n  - Namespace overlay's selector: overlay",
+				Properties: map[string]spec.Schema{
+					"extends": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Env is list of environment variables to set in the app container.",
+							Description: "Extends is the boot types or the profiles inherited by the profile, only the declared config is overridden.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Ref: ref("k8s.io/api/core/v1.EnvVar"),
+										Type:   []string{"string"},
+										Format: "",
 									},
 								},
 							},
 						},
 					},
-					"port": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Port that are exposed by the app container",
-							Type:        []string{"integer"},
-							Format:      "int32",
-						},
-					},
-					"subDomain": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Reserved, not used. for latter use",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"health": {
+					"overlay": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Health is check path for the app container.",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Overlay makes the config a namespace overlay, applied to the Boots in the namespaces selected.",
+							Ref:         ref("./pkg/apis/app/v1.OverlaySelector"),
 						},
 					},
-					"prometheus": {
+					"settings": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Prometheus will scrape metrics from the service, default is `true`",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Operator配置信息",
+							Ref:         ref("./pkg/apis/app/v1.SettingsConfig"),
 						},
 					},
-					"resources": {
+					"app": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Resources is the compute resource requirements for the app container",
-							Ref:         ref("k8s.io/api/core/v1.ResourceRequirements"),
+							Description: "Operator的默认App配置",
+							Ref:         ref("./pkg/apis/app/v1.AppSpec"),
 						},
 					},
-					"nodeSelector": {
+					"sideCarContainers": {
 						SchemaProps: spec.SchemaProps{
-							Description: "NodeSelector is a selector which must be true for the pod to fit on a node. Selector which must match a node's labels for the pod to be scheduled on that node.",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
+							Description: "Operator的默认SidecarContainers配置",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Type:   []string{"string"},
-										Format: "",
+										Ref: ref("k8s.io/api/core/v1.Container"),
 									},
 								},
 							},
 						},
 					},
-					"command": {
+					"sidecarServices": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Command is command for boot's container. If empty, will use image's ENTRYPOINT, specified here if needed override.",
+							Description: "Sidecar的Service列表",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Type:   []string{"string"},
-										Format: "",
+										Ref: ref("./pkg/apis/app/v1.SidecarService"),
 									},
 								},
 							},
 						},
 					},
-					"sessionAffinity": {
+				},
+			},
+		},
+		Dependencies: []string{
+			"./pkg/apis/app/v1.AppSpec", "./pkg/apis/app/v1.OverlaySelector", "./pkg/apis/app/v1.SettingsConfig", "./pkg/apis/app/v1.SidecarService", "k8s.io/api/core/v1.Container"},
+	}
+}
+
+func schema_pkg_apis_app_v1_OverlaySelector(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "OverlaySelector selects the namespaces, whose Boots the overlay is applied to. The namespace must match both the namespaceSelector and the namespaceAnnotations if specified.",
+				Properties: map[string]spec.Schema{
+					"namespaceSelector": {
 						SchemaProps: spec.SchemaProps{
-							Description: "SessionAffinity is SessionAffinity for boot's created service. If empty, will not set",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "NamespaceSelector selects the namespaces by the labels, as \"matchLabels: {logan/team: payments}\".",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
 						},
 					},
-					"nodePort": {
+					"namespaceAnnotations": {
 						SchemaProps: spec.SchemaProps{
-							Description: "NodePort will expose the service on each node’s IP at a random port, default is ``",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"pvc": {
-						VendorExtensible: spec.VendorExtensible{
-							Extensions: spec.Extensions{
-								"x-kubernetes-patch-merge-key": "name",
-								"x-kubernetes-patch-strategy":  "merge",
+							Description: "NamespaceAnnotations selects the namespaces by the annotations, all the annotations must be equal.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
 							},
 						},
+					},
+					"types": {
 						SchemaProps: spec.SchemaProps{
-							Description: "pvc is list of PersistentVolumeClaim to set in the app container.",
+							Description: "Types is the boot types' config keys the overlay is applied to, as \"java\", all the types if empty.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Ref: ref("./pkg/apis/app/v1.PersistentVolumeClaimMount"),
+										Type:   []string{"string"},
+										Format: "",
 									},
 								},
 							},
 						},
 					},
+					"priority": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Priority is the order of the overlays applied, the lower is applied first, and the later overrides. The overlays of the same priority are applied in the order of their keys.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
 				},
-				Required: []string{"image", "version", "prometheus"},
 			},
 		},
 		Dependencies: []string{
-			"./pkg/apis/app/v1.PersistentVolumeClaimMount", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.ResourceRequirements"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
 	}
 }
 
-func schema_pkg_apis_app_v1_BootStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_app_v1_PersistentVolumeClaimMount(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "BootStatus defines the observed state of Boot for specified types, as JavaBoot/PhpBoot/PythonBoot/NodeJSBoot",
+				Description: "PersistentVolumeClaimMount defines the Boot match a PersistentVolumeClaim",
 				Properties: map[string]spec.Schema{
-					"type": {
+					"name": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "This must match the Name of a PersistentVolumeClaim.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"deploy": {
+					"readOnly": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "Mounted read-only if true, read-write otherwise (false or unspecified). Defaults to false.",
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
-					"services": {
+					"mountPath": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "Path within the container at which the volume should be mounted.  Must not contain ':'.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
 				},
+				Required: []string{"name", "mountPath"},
 			},
 		},
-		Dependencies: []string{},
 	}
 }
 
-func schema_pkg_apis_app_v1_JavaBoot(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_app_v1_PhpBoot(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "JavaBoot is the Schema for the javaboots API",
+				Description: "PhpBoot is the Schema for the phpboots API",
 				Properties: map[string]spec.Schema{
 					"kind": {
 						SchemaProps: spec.SchemaProps{
@@ -364,11 +1916,11 @@ func schema_pkg_apis_app_v1_JavaBoot(ref common.ReferenceCallback) common.OpenAP
 	}
 }
 
-func schema_pkg_apis_app_v1_NodeJSBoot(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_app_v1_PythonBoot(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "NodeJSBoot is the Schema for the nodejsboots API",
+				Description: "PythonBoot is the Schema for the pythonboots API",
 				Properties: map[string]spec.Schema{
 					"kind": {
 						SchemaProps: spec.SchemaProps{
@@ -407,124 +1959,149 @@ func schema_pkg_apis_app_v1_NodeJSBoot(ref common.ReferenceCallback) common.Open
 	}
 }
 
-func schema_pkg_apis_app_v1_PersistentVolumeClaimMount(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_app_v1_SchedulingConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "PersistentVolumeClaimMount defines the Boot match a PersistentVolumeClaim",
+				Description: "SchedulingConfig define the scheduling settings for the Boots",
 				Properties: map[string]spec.Schema{
-					"name": {
-						SchemaProps: spec.SchemaProps{
-							Description: "This must match the Name of a PersistentVolumeClaim.",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"readOnly": {
+					"defaults": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Mounted read-only if true, read-write otherwise (false or unspecified). Defaults to false.",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Description: "Defaults is used when the Boot do not specify the field.",
+							Ref:         ref("./pkg/apis/app/v1.SchedulingSpec"),
 						},
 					},
-					"mountPath": {
+					"mandatory": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Path within the container at which the volume should be mounted.  Must not contain ':'.",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Mandatory is always merged into the Boot, as the config's nodeSelector.\n\t- tolerations: added or replaced by key and effect\n\t- nodeAffinity: required match expressions are added to every Boot's node selector term, preferred terms are added\n\t- topologySpread: added or replaced by topologyKey\n\t- priorityClassName, runtimeClassName: replaced",
+							Ref:         ref("./pkg/apis/app/v1.SchedulingSpec"),
 						},
 					},
 				},
-				Required: []string{"name", "mountPath"},
 			},
 		},
-		Dependencies: []string{},
+		Dependencies: []string{
+			"./pkg/apis/app/v1.SchedulingSpec"},
 	}
 }
 
-func schema_pkg_apis_app_v1_PhpBoot(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_app_v1_SchedulingSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "PhpBoot is the Schema for the phpboots API",
+				Description: "SchedulingSpec define the scheduling fields of the Boot",
 				Properties: map[string]spec.Schema{
-					"kind": {
+					"tolerations": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Tolerations is the pod's tolerations.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/api/core/v1.Toleration"),
+									},
+								},
+							},
 						},
 					},
-					"apiVersion": {
+					"nodeAffinity": {
 						SchemaProps: spec.SchemaProps{
-							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "NodeAffinity is the pod's node affinity.",
+							Ref:         ref("k8s.io/api/core/v1.NodeAffinity"),
 						},
 					},
-					"metadata": {
+					"topologySpread": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+							Description: "TopologySpread is how the pods spread across the topology domains.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("./pkg/apis/app/v1.BootTopologySpread"),
+									},
+								},
+							},
 						},
 					},
-					"spec": {
+					"priorityClassName": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("./pkg/apis/app/v1.BootSpec"),
+							Description: "PriorityClassName is the pod's priority class name.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"status": {
+					"runtimeClassName": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("./pkg/apis/app/v1.BootStatus"),
+							Description: "RuntimeClassName is the pod's runtime class name.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"./pkg/apis/app/v1.BootSpec", "./pkg/apis/app/v1.BootStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+			"./pkg/apis/app/v1.BootTopologySpread", "k8s.io/api/core/v1.NodeAffinity", "k8s.io/api/core/v1.Toleration"},
 	}
 }
 
-func schema_pkg_apis_app_v1_PythonBoot(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_app_v1_SettingsConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "PythonBoot is the Schema for the pythonboots API",
+				Description: "SettingsConfig is the common struct for Settings",
 				Properties: map[string]spec.Schema{
-					"kind": {
+					"registry": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Description: "Registry is the image registry, replacing ${REGISTRY} in the images.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"apiVersion": {
+					"appHealthPort": {
 						SchemaProps: spec.SchemaProps{
-							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "AppHealthPort is the port of the app container's health check, default is the app's port.",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
-					"metadata": {
+					"prometheusScrape": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+							Description: "PrometheusScrape is whether the Boot's service is scraped by prometheus, default is true.",
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
-					"spec": {
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_app_v1_SidecarService(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SidecarService define the service for Sidecar",
+				Properties: map[string]spec.Schema{
+					"name": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("./pkg/apis/app/v1.BootSpec"),
+							Description: "Name is the service's name, support ${APP}.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"status": {
+					"port": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("./pkg/apis/app/v1.BootStatus"),
+							Description: "Port is the sidecar's port exposed by the service.",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
 				},
+				Required: []string{"name", "port"},
 			},
 		},
-		Dependencies: []string{
-			"./pkg/apis/app/v1.BootSpec", "./pkg/apis/app/v1.BootStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
 	}
 }
 
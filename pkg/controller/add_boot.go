@@ -1,10 +1,10 @@
 package controller
 
 import (
-	"github.com/logancloud/logan-app-operator/pkg/controller/webboot"
+	"github.com/logancloud/logan-app-operator/pkg/controller/boot"
 )
 
 func init() {
 	// AddToManagerFuncs is a list of functions to create controllers and add them to a manager.
-	AddToManagerFuncs = append(AddToManagerFuncs, webboot.Add)
+	AddToManagerFuncs = append(AddToManagerFuncs, boot.Add)
 }
@@ -1,10 +1,10 @@
 package controller
 
 import (
-	"github.com/logancloud/logan-app-operator/pkg/controller/javaboot"
+	"github.com/logancloud/logan-app-operator/pkg/controller/operatorconfig"
 )
 
 func init() {
 	// AddToManagerFuncs is a list of functions to create controllers and add them to a manager.
-	AddToManagerFuncs = append(AddToManagerFuncs, javaboot.Add)
+	AddToManagerFuncs = append(AddToManagerFuncs, operatorconfig.Add)
 }
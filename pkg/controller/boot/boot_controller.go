@@ -0,0 +1,367 @@
+package boot
+
+import (
+	"context"
+	"github.com/go-logr/logr"
+	appv1 "github.com/logancloud/logan-app-operator/pkg/apis/app/v1"
+	"github.com/logancloud/logan-app-operator/pkg/logan"
+	"github.com/logancloud/logan-app-operator/pkg/logan/config"
+	loganMetrics "github.com/logancloud/logan-app-operator/pkg/logan/metrics"
+	"github.com/logancloud/logan-app-operator/pkg/logan/operator"
+	"github.com/logancloud/logan-app-operator/pkg/logan/util"
+	"github.com/logancloud/logan-app-operator/pkg/logan/util/keys"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"reflect"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	"strings"
+	"time"
+)
+
+// bootEvents are the channels to enqueue the Boots of each registered Boot's type without the cluster's events,
+// as the Boots whose config is changed by reloading the operator config. Keyed by the type's kind.
+var bootEvents = make(map[string]chan event.GenericEvent)
+
+// Add creates a Controller for each registered Boot's type and adds them to the Manager. The Manager will set fields
+// on the Controllers and Start them when the Manager is Started. The Boots are handled with the provider's config.
+func Add(mgr manager.Manager, provider *config.Provider) error {
+	for _, bootType := range appv1.BootTypes() {
+		bootEvents[bootType.Kind] = make(chan event.GenericEvent)
+		err := add(mgr, newReconciler(mgr, provider, bootType), provider, bootType)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Enqueue enqueues the Boot of the type to be reconciled, blocks until the Boot's Controller receives it or the stop
+// channel is closed. Return false if the type's Controller is not added, or it is stopped.
+func Enqueue(bootType *appv1.BootType, key types.NamespacedName, stop <-chan struct{}) bool {
+	events, ok := bootEvents[bootType.Kind]
+	if !ok {
+		return false
+	}
+
+	bootObj := bootType.New()
+	bootObj.SetNamespace(key.Namespace)
+	bootObj.SetName(key.Name)
+	select {
+	case events <- event.GenericEvent{Meta: bootObj, Object: bootObj}:
+		return true
+	case <-stop:
+		return false
+	}
+}
+
+// controllerName returns the name of the Controller for the Boot's type, as javaboot-controller
+func controllerName(bootType *appv1.BootType) string {
+	return strings.ToLower(bootType.Kind) + "-controller"
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager, provider config.ConfigProvider, bootType *appv1.BootType) reconcile.Reconciler {
+	return &ReconcileBoot{
+		client:   util.NewClient(mgr.GetClient()),
+		scheme:   mgr.GetScheme(),
+		recorder: mgr.GetRecorder(controllerName(bootType)),
+		provider: provider,
+		bootType: bootType,
+		log:      logf.Log.WithName("logan_controller_" + strings.ToLower(bootType.Kind)),
+	}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler, provider config.ConfigProvider, bootType *appv1.BootType) error {
+	// Create a new controller
+	c, err := controller.New(controllerName(bootType), mgr, controller.Options{Reconciler: r, MaxConcurrentReconciles: logan.MaxConcurrentReconciles})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to primary resource of the Boot's type
+	err = c.Watch(&source.Kind{Type: bootType.New()}, &handler.EnqueueRequestForObject{})
+	if err != nil {
+		return err
+	}
+
+	// Watch for the Boots enqueued by Enqueue
+	err = c.Watch(&source.Channel{Source: bootEvents[bootType.Kind]}, &handler.EnqueueRequestForObject{})
+	if err != nil {
+		return err
+	}
+
+	// Watch for the namespaces' labels and annotations, which select the overlays of the operator config
+	err = c.Watch(&source.Kind{Type: &corev1.Namespace{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: namespaceBoots(mgr.GetClient(), provider, bootType),
+	}, namespaceSelectorChanged)
+	if err != nil {
+		return err
+	}
+
+	// Modify this to be the types you create(Deployment and Service) that are owned by the primary resource
+	err = c.Watch(&source.Kind{Type: &appsv1.Deployment{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    bootType.New(),
+	})
+	if err != nil {
+		return err
+	}
+
+	err = c.Watch(&source.Kind{Type: &appsv1.StatefulSet{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    bootType.New(),
+	})
+	if err != nil {
+		return err
+	}
+
+	err = c.Watch(&source.Kind{Type: &batchv1.Job{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    bootType.New(),
+	})
+	if err != nil {
+		return err
+	}
+
+	err = c.Watch(&source.Kind{Type: &batchv1beta1.CronJob{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    bootType.New(),
+	})
+	if err != nil {
+		return err
+	}
+
+	err = c.Watch(&source.Kind{Type: &corev1.Service{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    bootType.New(),
+	})
+	if err != nil {
+		return err
+	}
+
+	err = c.Watch(&source.Kind{Type: &autoscalingv2beta2.HorizontalPodAutoscaler{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    bootType.New(),
+	})
+	if err != nil {
+		return err
+	}
+
+	err = c.Watch(&source.Kind{Type: &extensionsv1beta1.Ingress{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    bootType.New(),
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// namespaceSelectorChanged filters the namespaces' events, only the changes of the labels or the annotations,
+// which select the overlays, are handled.
+var namespaceSelectorChanged = predicate.Funcs{
+	CreateFunc: func(event.CreateEvent) bool {
+		return false
+	},
+	DeleteFunc: func(event.DeleteEvent) bool {
+		return false
+	},
+	GenericFunc: func(event.GenericEvent) bool {
+		return false
+	},
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return !reflect.DeepEqual(e.MetaOld.GetLabels(), e.MetaNew.GetLabels()) ||
+			!reflect.DeepEqual(e.MetaOld.GetAnnotations(), e.MetaNew.GetAnnotations())
+	},
+}
+
+// namespaceBoots returns the mapper from a namespace to the Boots of the type in it, if the config has overlays.
+func namespaceBoots(c client.Client, provider config.ConfigProvider, bootType *appv1.BootType) handler.ToRequestsFunc {
+	return func(obj handler.MapObject) []reconcile.Request {
+		namespace := obj.Meta.GetName()
+		if operator.Ignore(namespace) || len(provider.Config().OverlayConfig) == 0 {
+			return nil
+		}
+
+		list := bootType.NewList()
+		err := c.List(context.TODO(), &client.ListOptions{Namespace: namespace}, list)
+		if err != nil {
+			logf.Log.Error(err, "Failed to list the Boots of the namespace", "namespace", namespace)
+			return nil
+		}
+
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return nil
+		}
+
+		requests := make([]reconcile.Request, 0, len(items))
+		for _, item := range items {
+			bootObj, ok := item.(appv1.BootObject)
+			if !ok {
+				continue
+			}
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
+				Namespace: bootObj.GetNamespace(),
+				Name:      bootObj.GetName(),
+			}})
+		}
+		return requests
+	}
+}
+
+// blank assignment to verify that ReconcileBoot implements reconcile.Reconciler
+var _ reconcile.Reconciler = &ReconcileBoot{}
+
+// ReconcileBoot reconciles the object of a Boot's type, as JavaBoot
+type ReconcileBoot struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver
+	client   util.K8SClient
+	scheme   *runtime.Scheme
+	recorder record.EventRecorder
+	provider config.ConfigProvider
+	bootType *appv1.BootType
+	log      logr.Logger
+}
+
+// Reconcile reads that state of the cluster for a Boot object and makes changes based on the state read
+// and what is in the Boot.Spec
+// Note:
+// The Controller will requeue the Request to be processed again if the returned error is non-nil or
+// Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
+func (r *ReconcileBoot) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	bootType := r.bootType.Kind
+	logger := r.log.WithValues(strings.ToLower(bootType), request)
+
+	if operator.Ignore(request.Namespace) {
+		return reconcile.Result{}, nil
+	}
+
+	logger.Info("Reconciling " + bootType)
+	// Update metrics after processing each Reconcile
+	reconcileStartTS := time.Now()
+	defer func() {
+		loganMetrics.UpdateReconcileTime(bootType, time.Now().Sub(reconcileStartTS))
+	}()
+
+	var bootHandler *operator.BootHandler
+
+	// Fetch the Boot instance
+	bootObj := r.bootType.New()
+	err := r.client.Get(context.TODO(), request.NamespacedName, bootObj)
+	if err != nil {
+		loganMetrics.UpdateMainStageErrors(bootType, loganMetrics.RECONCILE_GET_BOOT_STAGE, request.Name)
+		if errors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
+			logger.Info("Boot resource not found. Ignoring since object must be deleted")
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		logger.Error(err, "Failed to get Boot")
+		return reconcile.Result{}, err
+	}
+
+	bootHandler = operator.InitHandler(bootObj, r.provider, r.scheme, r.client, logger, r.recorder)
+
+	// The Boot is not reconciled without the overlays of its namespace, requeue until the namespace is got.
+	if bootHandler.NamespaceError != nil {
+		loganMetrics.UpdateMainStageErrors(bootType, loganMetrics.RECONCILE_RESOLVE_CONFIG_STAGE, request.Name)
+		return reconcile.Result{Requeue: true}, bootHandler.NamespaceError
+	}
+
+	// Suspend or resume the Boot, the other reconciliation is paused when suspended.
+	result, requeue, err := bootHandler.ReconcileSuspend()
+	if requeue {
+		return result, err
+	}
+
+	// Roll back the Boot to the revision if requested by annotation.
+	result, requeue, err = bootHandler.ReconcileRollback()
+	if requeue {
+		return result, err
+	}
+
+	//if !logan.MutationDefaulter {
+	changed := bootHandler.DefaultValue()
+
+	//Update the Boot's default Value
+	if changed {
+		logger.Info("Updating Boot with Defaulters")
+		err = r.client.Update(context.TODO(), bootObj)
+		if err != nil {
+			msg := "Failed to update Boot with Defaulters"
+			logger.Info(msg, "boot", bootObj)
+			loganMetrics.UpdateMainStageErrors(bootType, loganMetrics.RECONCILE_UPDATE_BOOT_DEFAULTERS_STAGE, bootObj.GetName())
+			bootHandler.RecordEvent(keys.FailedUpdateBootDefaulters, msg, err)
+			return reconcile.Result{Requeue: true}, nil
+		}
+		bootHandler.RecordEvent(keys.UpdatedBootDefaulters, "Updated Boot with Defaulters", nil)
+		return reconcile.Result{Requeue: true}, nil
+	}
+	//}
+
+	// 1. Check the existence of components, if not exist, create new one.
+	result, requeue, err = bootHandler.ReconcileCreate()
+	if requeue {
+		bootHandler.UpdateReconcileErrorStatus(err)
+		return result, err
+	}
+
+	// 2. Handle the update logic of components
+	result, requeue, err = bootHandler.ReconcileUpdate()
+	if requeue {
+		bootHandler.UpdateReconcileErrorStatus(err)
+		return result, err
+	}
+	// Requeue after the delay requested by the rollout, even if nothing is changed.
+	delayedResult := result
+
+	result, requeue, updated, err := bootHandler.ReconcileUpdateBootMeta()
+
+	if updated {
+		logger.Info("Updating Boot Meta", "new", bootObj.GetAnnotations())
+		err := r.client.Update(context.TODO(), bootObj)
+		if err != nil {
+			// Other place will modify the status? So it will sometimes occur.
+			msg := "Failed to update Boot Meta"
+			logger.Info(msg, "err", err.Error())
+			loganMetrics.UpdateReconcileErrors(bootType, loganMetrics.RECONCILE_UPDATE_BOOT_META_STAGE, loganMetrics.RECONCILE_UPDATE_BOOT_META_SUBSTAGE, bootObj.GetName())
+
+			bootHandler.RecordEvent(keys.FailedUpdateBootMeta, msg, err)
+			return reconcile.Result{Requeue: true}, nil
+		}
+		bootHandler.RecordEvent(keys.UpdatedBootMeta, "Updated Boot Meta", nil)
+	}
+	if requeue {
+		return result, err
+	}
+
+	// 3. Write the observed state through the status subresource, after meta is updated.
+	result, requeue, err = bootHandler.ReconcileUpdateBootStatus()
+	if requeue {
+		return result, err
+	}
+
+	return delayedResult, nil
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/go-logr/logr"
 	appv1 "github.com/logancloud/logan-app-operator/pkg/apis/app/v1"
 	"github.com/logancloud/logan-app-operator/pkg/logan"
+	"github.com/logancloud/logan-app-operator/pkg/logan/config"
 	loganMetrics "github.com/logancloud/logan-app-operator/pkg/logan/metrics"
 	"github.com/logancloud/logan-app-operator/pkg/logan/operator"
 	"github.com/logancloud/logan-app-operator/pkg/logan/util"
@@ -30,8 +31,8 @@ var log = logf.Log.WithName("logan_controller_bootrevision")
 var kindType = "BootRevision"
 
 // Add creates a new BootRevision Controller and adds it to the Manager. The Manager will set fields on the Controller
-// and Start it when the Manager is Started.
-func Add(mgr manager.Manager) error {
+// and Start it when the Manager is Started. The BootRevision does not use the operator config.
+func Add(mgr manager.Manager, _ *config.Provider) error {
 	return add(mgr, newReconciler(mgr))
 }
 
@@ -172,68 +173,20 @@ func getBoot(revision *appv1.BootRevision, client client.Client, logger logr.Log
 		Name:      revision.Labels[keys.BootNameKey],
 	}
 
-	bootType := revision.Labels[keys.BootTypeKey]
-	if bootType == logan.BootJava {
-		javaBoot := &appv1.JavaBoot{}
-		err := client.Get(context.TODO(), nn, javaBoot)
-		if err != nil {
-			if errors.IsNotFound(err) {
-				logger.Error(err, "Boot resource not found.Maybe it hasn't been created yet.")
-			} else {
-				logger.Error(err, "Failed to get Boot")
-			}
-			return false, nil
-		}
-		return true, javaBoot
-	} else if bootType == logan.BootPhp {
-		phpBoot := &appv1.PhpBoot{}
-		err := client.Get(context.TODO(), nn, phpBoot)
-		if err != nil {
-			if errors.IsNotFound(err) {
-				logger.Error(err, "Boot resource not found.Maybe it hasn't been created yet.")
-			} else {
-				logger.Error(err, "Failed to get Boot")
-			}
-			return false, nil
-		}
-		return true, phpBoot
-	} else if bootType == logan.BootPython {
-		pythonBoot := &appv1.PythonBoot{}
-		err := client.Get(context.TODO(), nn, pythonBoot)
-		if err != nil {
-			if errors.IsNotFound(err) {
-				logger.Error(err, "Boot resource not found.Maybe it hasn't been created yet.")
-			} else {
-				logger.Error(err, "Failed to get Boot")
-			}
-			return false, nil
-		}
-		return true, pythonBoot
-	} else if bootType == logan.BootNodeJS {
-		nodejsBoot := &appv1.NodeJSBoot{}
-		err := client.Get(context.TODO(), nn, nodejsBoot)
-		if err != nil {
-			if errors.IsNotFound(err) {
-				logger.Error(err, "Boot resource not found.Maybe it hasn't been created yet.")
-			} else {
-				logger.Error(err, "Failed to get Boot")
-			}
-			return false, nil
-		}
-		return true, nodejsBoot
-	} else if bootType == logan.BootWeb {
-		webBoot := &appv1.WebBoot{}
-		err := client.Get(context.TODO(), nn, webBoot)
-		if err != nil {
-			if errors.IsNotFound(err) {
-				logger.Error(err, "Boot resource not found.Maybe it hasn't been created yet.")
-			} else {
-				logger.Error(err, "Failed to get Boot")
-			}
-			return false, nil
-		}
-		return true, webBoot
+	bootType := appv1.GetBootTypeByConfigKey(revision.Labels[keys.BootTypeKey])
+	if bootType == nil {
+		return false, nil
 	}
 
-	return false, nil
+	boot := bootType.New()
+	err := client.Get(context.TODO(), nn, boot)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			logger.Error(err, "Boot resource not found.Maybe it hasn't been created yet.")
+		} else {
+			logger.Error(err, "Failed to get Boot")
+		}
+		return false, nil
+	}
+	return true, boot
 }
@@ -1,16 +1,17 @@
 package controller
 
 import (
+	"github.com/logancloud/logan-app-operator/pkg/logan/config"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 )
 
 // AddToManagerFuncs is a list of functions to add all Controllers to the Manager
-var AddToManagerFuncs []func(manager.Manager) error
+var AddToManagerFuncs []func(manager.Manager, *config.Provider) error
 
-// AddToManager adds all Controllers to the Manager
-func AddToManager(m manager.Manager) error {
+// AddToManager adds all Controllers to the Manager, with the provider of the operator config
+func AddToManager(m manager.Manager, provider *config.Provider) error {
 	for _, f := range AddToManagerFuncs {
-		if err := f(m); err != nil {
+		if err := f(m, provider); err != nil {
 			return err
 		}
 	}
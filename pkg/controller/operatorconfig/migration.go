@@ -0,0 +1,154 @@
+package operatorconfig
+
+import (
+	"context"
+	"fmt"
+	appv1 "github.com/logancloud/logan-app-operator/pkg/apis/app/v1"
+	"github.com/logancloud/logan-app-operator/pkg/logan"
+	"github.com/logancloud/logan-app-operator/pkg/logan/config"
+	"github.com/logancloud/logan-app-operator/pkg/logan/util/keys"
+	"github.com/operator-framework/operator-sdk/pkg/k8sutil"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/kubernetes"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	"strings"
+)
+
+// addMigration creates a Controller migrating the operator's ConfigMap to the LoganOperatorConfig and adds it to
+// the Manager. It is skipped when the operator is not running in a cluster.
+func addMigration(mgr manager.Manager) error {
+	namespace, err := k8sutil.GetOperatorNamespace()
+	if err != nil {
+		if err == k8sutil.ErrNoNamespace {
+			log.Info("Skipping migrating the operator config; not running in a cluster.")
+			return nil
+		}
+		return err
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		return err
+	}
+
+	// Only the operator's ConfigMap is watched, the other ConfigMaps are not cached.
+	informer := coreinformers.NewFilteredConfigMapInformer(kubeClient, namespace, 0, toolscache.Indexers{},
+		func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector("metadata.name", logan.OperConfigmap).String()
+		})
+	err = mgr.Add(manager.RunnableFunc(func(stop <-chan struct{}) error {
+		informer.Run(stop)
+		return nil
+	}))
+	if err != nil {
+		return err
+	}
+
+	r := &ReconcileMigration{
+		client:   mgr.GetClient(),
+		store:    informer.GetStore(),
+		recorder: mgr.GetRecorder("operatorconfig-migration"),
+	}
+	c, err := controller.New("operatorconfig-migration", mgr, controller.Options{Reconciler: r, MaxConcurrentReconciles: 1})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to the operator's ConfigMap
+	return c.Watch(&source.Informer{Informer: informer}, &handler.EnqueueRequestForObject{})
+}
+
+// blank assignment to verify that ReconcileMigration implements reconcile.Reconciler
+var _ reconcile.Reconciler = &ReconcileMigration{}
+
+// ReconcileMigration migrates the operator's ConfigMap to the LoganOperatorConfig
+type ReconcileMigration struct {
+	client   client.Client
+	store    toolscache.Store
+	recorder record.EventRecorder
+}
+
+// Reconcile creates the operator's LoganOperatorConfig from the ConfigMap's config.yaml if it does not exist.
+// Once migrated, the LoganOperatorConfig is the operator config, and the ConfigMap is not used anymore but as the
+// config file mounted, which is only used when the LoganOperatorConfig is not found at startup.
+func (r *ReconcileMigration) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	logger := log.WithValues("configmap", request)
+
+	obj, exists, err := r.store.GetByKey(request.NamespacedName.String())
+	if err != nil {
+		logger.Error(err, "Failed to get operator configmap")
+		return reconcile.Result{}, err
+	}
+	if !exists {
+		return reconcile.Result{}, nil
+	}
+	configMap := obj.(*corev1.ConfigMap)
+
+	existing := &appv1.LoganOperatorConfig{}
+	err = r.client.Get(context.TODO(), types.NamespacedName{Name: logan.OperConfigmap}, existing)
+	if err == nil {
+		logger.Info("LoganOperatorConfig exists, the configmap is not migrated")
+		return reconcile.Result{}, nil
+	}
+	if !errors.IsNotFound(err) {
+		logger.Error(err, "Failed to get LoganOperatorConfig")
+		return reconcile.Result{}, err
+	}
+
+	text := configMap.Data[logan.ConfigFilename]
+	_, err = config.ValidateConfig(text)
+	if err != nil {
+		r.migrateFailed(configMap, err)
+		return reconcile.Result{}, nil
+	}
+
+	declared, err := config.DecodeGlobalConfig(strings.NewReader(text))
+	if err != nil {
+		r.migrateFailed(configMap, err)
+		return reconcile.Result{}, nil
+	}
+
+	operatorConfig := &appv1.LoganOperatorConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: logan.OperConfigmap,
+			Annotations: map[string]string{
+				keys.MigratedFromAnnotationKey: request.NamespacedName.String(),
+			},
+		},
+		Spec: appv1.LoganOperatorConfigSpec{
+			Config: declared,
+		},
+	}
+	err = r.client.Create(context.TODO(), operatorConfig)
+	if err != nil {
+		if errors.IsAlreadyExists(err) {
+			return reconcile.Result{}, nil
+		}
+		r.migrateFailed(configMap, err)
+		return reconcile.Result{}, err
+	}
+
+	msg := fmt.Sprintf("Migrated operator config to LoganOperatorConfig %s", operatorConfig.Name)
+	logger.Info(msg)
+	r.recorder.Event(configMap, corev1.EventTypeNormal, keys.MigratedConfig, msg)
+	return reconcile.Result{}, nil
+}
+
+// migrateFailed records the failure by the event, the ConfigMap is migrated again when it is changed.
+func (r *ReconcileMigration) migrateFailed(configMap *corev1.ConfigMap, err error) {
+	msg := fmt.Sprintf("Failed to migrate operator config to LoganOperatorConfig: %s", err.Error())
+	log.Info(msg, "configmap", configMap.Name)
+	r.recorder.Event(configMap, corev1.EventTypeWarning, keys.FailedMigrateConfig, msg)
+}
@@ -0,0 +1,303 @@
+package operatorconfig
+
+import (
+	"context"
+	"fmt"
+	appv1 "github.com/logancloud/logan-app-operator/pkg/apis/app/v1"
+	"github.com/logancloud/logan-app-operator/pkg/logan"
+	"github.com/logancloud/logan-app-operator/pkg/logan/config"
+	loganMetrics "github.com/logancloud/logan-app-operator/pkg/logan/metrics"
+	"github.com/logancloud/logan-app-operator/pkg/logan/operator"
+	"github.com/logancloud/logan-app-operator/pkg/logan/util/keys"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	"sort"
+	"strings"
+	"time"
+)
+
+var log = logf.Log.WithName("logan_controller_operatorconfig")
+var kindType = "OperatorConfig"
+
+// Add creates a new operator config Controller and adds it to the Manager. The Controller watches the operator's
+// LoganOperatorConfig, reloads the operator config and enqueues the Boots whose config changed. The operator's
+// ConfigMap is migrated to the LoganOperatorConfig if it does not exist.
+func Add(mgr manager.Manager, provider *config.Provider) error {
+	reloader := newBootReloader(logan.ConfigReloadQPS, logan.ConfigReloadBurst)
+	err := mgr.Add(reloader)
+	if err != nil {
+		return err
+	}
+
+	err = add(mgr, newReconciler(mgr, provider, reloader))
+	if err != nil {
+		return err
+	}
+
+	return addMigration(mgr)
+}
+
+// LoadConfig sets the provider's config from the operator's LoganOperatorConfig before the Manager is started,
+// so that the Boots are not reconciled with the config file(the ConfigMap) which is outdated. The provider's config
+// is kept only if the LoganOperatorConfig is not found. If it could not be got or is invalid, the error is returned,
+// and the operator must not start, because the config file is not the last good config.
+func LoadConfig(cfg *rest.Config, scheme *runtime.Scheme, provider *config.Provider) error {
+	c, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		return err
+	}
+
+	operatorConfig := &appv1.LoganOperatorConfig{}
+	err = c.Get(context.TODO(), types.NamespacedName{Name: logan.OperConfigmap}, operatorConfig)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			log.Info("LoganOperatorConfig not found, use the config file", "name", logan.OperConfigmap)
+			return nil
+		}
+		return err
+	}
+
+	loaded, err := config.ValidateGlobalConfig(operatorConfig.Spec.Config)
+	if err != nil {
+		return fmt.Errorf("LoganOperatorConfig %s is invalid: %s", operatorConfig.Name, err.Error())
+	}
+	provider.Set(loaded)
+	log.Info("Loaded LoganOperatorConfig", "name", operatorConfig.Name, "generation", operatorConfig.Generation)
+	return nil
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager, provider *config.Provider, reloader *bootReloader) reconcile.Reconciler {
+	return &ReconcileOperatorConfig{
+		client:   mgr.GetClient(),
+		provider: provider,
+		recorder: mgr.GetRecorder("operatorconfig-controller"),
+		reloader: reloader,
+	}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	// Create a new controller, the config is reloaded one by one.
+	c, err := controller.New("operatorconfig-controller", mgr, controller.Options{Reconciler: r, MaxConcurrentReconciles: 1})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to the LoganOperatorConfig
+	err = c.Watch(&source.Kind{Type: &appv1.LoganOperatorConfig{}}, &handler.EnqueueRequestForObject{})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// blank assignment to verify that ReconcileOperatorConfig implements reconcile.Reconciler
+var _ reconcile.Reconciler = &ReconcileOperatorConfig{}
+
+// ReconcileOperatorConfig reconciles the operator's LoganOperatorConfig
+type ReconcileOperatorConfig struct {
+	client   client.Client
+	provider *config.Provider
+	recorder record.EventRecorder
+	reloader *bootReloader
+
+	// applied is the uid and generation of the LoganOperatorConfig last observed
+	applied string
+}
+
+// Reconcile reloads the operator config from the LoganOperatorConfig. The new config is validated and swapped in as
+// a whole, and the Boots using the changed boot type's config, profile or overlays are enqueued to be reconciled at
+// a limited rate, the Boots reconciled by their own events use the new config at once. If the config is invalid,
+// the last good config is kept, and a Warning event is emitted on the LoganOperatorConfig. The generation loaded is
+// reported in the status.
+func (r *ReconcileOperatorConfig) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	logger := log.WithValues("loganoperatorconfig", request.Name)
+
+	// Only the operator's LoganOperatorConfig is loaded, the others are of the other operators.
+	if request.Name != logan.OperConfigmap {
+		return reconcile.Result{}, nil
+	}
+
+	logger.Info("Reconciling operator config")
+	// Update metrics after processing each Reconcile
+	reconcileStartTS := time.Now()
+	defer func() {
+		loganMetrics.UpdateReconcileTime(kindType, time.Now().Sub(reconcileStartTS))
+	}()
+
+	operatorConfig := &appv1.LoganOperatorConfig{}
+	err := r.client.Get(context.TODO(), request.NamespacedName, operatorConfig)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			logger.Info("LoganOperatorConfig not found, keep the last config")
+			return reconcile.Result{}, nil
+		}
+		logger.Error(err, "Failed to get operator config")
+		return reconcile.Result{}, err
+	}
+
+	applied := fmt.Sprintf("%s/%d", operatorConfig.UID, operatorConfig.Generation)
+	if applied == r.applied {
+		return reconcile.Result{}, nil
+	}
+
+	cfg, err := config.ValidateGlobalConfig(operatorConfig.Spec.Config)
+	if err != nil {
+		r.reloadFailed(operatorConfig, err)
+		operatorConfig.Status.ObservedGeneration = operatorConfig.Generation
+		operatorConfig.Status.Message = err.Error()
+		return reconcile.Result{}, r.updateStatus(operatorConfig, applied)
+	}
+
+	changed := config.ChangedKeys(r.provider.Config(), cfg)
+	boots, err := r.changedBoots(changed, r.provider.Config(), cfg)
+	if err != nil {
+		logger.Error(err, "Failed to list the Boots to reload")
+		return reconcile.Result{}, err
+	}
+
+	r.provider.Set(cfg)
+	loganMetrics.UpdateConfigReloads(loganMetrics.CONFIG_RELOAD_SUCCESS)
+
+	now := metav1.Now()
+	operatorConfig.Status.ObservedGeneration = operatorConfig.Generation
+	operatorConfig.Status.LoadedGeneration = operatorConfig.Generation
+	operatorConfig.Status.LoadedTime = &now
+	operatorConfig.Status.Message = ""
+	err = r.updateStatus(operatorConfig, applied)
+	if len(changed) == 0 {
+		logger.Info("Operator config reloaded, not changed", "generation", operatorConfig.Generation)
+		return reconcile.Result{}, err
+	}
+
+	for _, key := range boots {
+		r.reloader.Add(key)
+	}
+
+	changedKeys := make([]string, 0, len(changed))
+	for key := range changed {
+		changedKeys = append(changedKeys, key)
+	}
+	sort.Strings(changedKeys)
+
+	msg := fmt.Sprintf("Reloaded operator config generation %d, changed: %s, enqueued %d Boots",
+		operatorConfig.Generation, strings.Join(changedKeys, ","), len(boots))
+	logger.Info(msg)
+	r.recorder.Event(operatorConfig, corev1.EventTypeNormal, keys.ReloadedConfig, msg)
+
+	return reconcile.Result{}, err
+}
+
+// reloadFailed keeps the last good config, and records the failure by the event and the metrics.
+func (r *ReconcileOperatorConfig) reloadFailed(operatorConfig *appv1.LoganOperatorConfig, err error) {
+	msg := fmt.Sprintf("Failed to reload operator config generation %d, keep the last config: %s",
+		operatorConfig.Generation, err.Error())
+	log.Info(msg, "loganoperatorconfig", operatorConfig.Name)
+	loganMetrics.UpdateConfigReloads(loganMetrics.CONFIG_RELOAD_FAILURE)
+	r.recorder.Event(operatorConfig, corev1.EventTypeWarning, keys.FailedReloadConfig, msg)
+}
+
+// updateStatus updates the LoganOperatorConfig's status, the generation is applied once the status is updated.
+func (r *ReconcileOperatorConfig) updateStatus(operatorConfig *appv1.LoganOperatorConfig, applied string) error {
+	err := r.client.Status().Update(context.TODO(), operatorConfig)
+	if err != nil {
+		log.Error(err, "Failed to update operator config status", "loganoperatorconfig", operatorConfig.Name)
+		return err
+	}
+	r.applied = applied
+	return nil
+}
+
+// changedBoots returns the Boots whose config changed: the Boot's profile if it uses one, otherwise its boot type,
+// or the overlays selected by the Boot's namespace in the old or the updated config.
+func (r *ReconcileOperatorConfig) changedBoots(changed map[string]bool, old *config.Config,
+	updated *config.Config) ([]bootKey, error) {
+	boots := make([]bootKey, 0)
+	if len(changed) == 0 {
+		return boots, nil
+	}
+
+	namespaces := make(map[string]*corev1.Namespace)
+
+	for _, bootType := range appv1.BootTypes() {
+		list := bootType.NewList()
+		err := r.client.List(context.TODO(), &client.ListOptions{}, list)
+		if err != nil {
+			return nil, err
+		}
+
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range items {
+			bootObj, ok := item.(appv1.BootObject)
+			if !ok || operator.Ignore(bootObj.GetNamespace()) {
+				continue
+			}
+
+			configKey := bootType.ConfigKey
+			if profile, ok := bootObj.GetAnnotations()[config.BootProfileAnnotationKey]; ok {
+				configKey = profile
+			}
+			overlayChanged := false
+			if hasOverlays(old) || hasOverlays(updated) {
+				namespace, err := r.getNamespace(namespaces, bootObj.GetNamespace())
+				if err != nil {
+					return nil, err
+				}
+				for _, cfg := range []*config.Config{old, updated} {
+					for _, overlay := range cfg.MatchOverlays(bootType.ConfigKey, namespace) {
+						overlayChanged = overlayChanged || changed[overlay]
+					}
+				}
+			}
+
+			if changed[configKey] || overlayChanged {
+				boots = append(boots, bootKey{
+					kind:           bootType.Kind,
+					NamespacedName: types.NamespacedName{Namespace: bootObj.GetNamespace(), Name: bootObj.GetName()},
+				})
+			}
+		}
+	}
+
+	return boots, nil
+}
+
+// hasOverlays returns true if the config has any overlay
+func hasOverlays(cfg *config.Config) bool {
+	return cfg != nil && len(cfg.OverlayConfig) > 0
+}
+
+// getNamespace returns the namespace, fetched once for each reload. A deleted namespace selects no overlay.
+func (r *ReconcileOperatorConfig) getNamespace(namespaces map[string]*corev1.Namespace,
+	name string) (*corev1.Namespace, error) {
+	if namespace, ok := namespaces[name]; ok {
+		return namespace, nil
+	}
+
+	namespace := &corev1.Namespace{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: name}, namespace)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	namespaces[name] = namespace
+	return namespace, nil
+}
@@ -0,0 +1,76 @@
+package operatorconfig
+
+import (
+	appv1 "github.com/logancloud/logan-app-operator/pkg/apis/app/v1"
+	"github.com/logancloud/logan-app-operator/pkg/controller/boot"
+	loganMetrics "github.com/logancloud/logan-app-operator/pkg/logan/metrics"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// bootKey is the key of a Boot to be reloaded
+type bootKey struct {
+	kind string
+	types.NamespacedName
+}
+
+// blank assignment to verify that bootReloader implements manager.Runnable
+var _ manager.Runnable = &bootReloader{}
+
+// bootReloader enqueues the Boots to their Controllers at a limited rate, so that a config change, as a sidecar's
+// image, does not roll out all the Boots at once. The Boots waiting are deduplicated between the reloads.
+// The limit applies to the enqueues only: the config is swapped in before, so a Boot waiting is handled with the new
+// config if it is reconciled by its own events, such as a change of the Boot or its Deployment.
+type bootReloader struct {
+	queue   workqueue.Interface
+	limiter flowcontrol.RateLimiter
+}
+
+// newBootReloader returns a new bootReloader with the rate limit
+func newBootReloader(qps float32, burst int) *bootReloader {
+	return &bootReloader{
+		queue:   workqueue.NewNamed("operatorconfig-reloader"),
+		limiter: flowcontrol.NewTokenBucketRateLimiter(qps, burst),
+	}
+}
+
+// Add adds the Boot to be reloaded
+func (b *bootReloader) Add(key bootKey) {
+	b.queue.Add(key)
+}
+
+// Start enqueues the Boots until the stop channel is closed, implements manager.Runnable
+func (b *bootReloader) Start(stop <-chan struct{}) error {
+	go func() {
+		<-stop
+		b.queue.ShutDown()
+	}()
+
+	for b.processNext(stop) {
+	}
+	return nil
+}
+
+// processNext enqueues the next Boot after the rate limit, return false if the queue is shut down.
+func (b *bootReloader) processNext(stop <-chan struct{}) bool {
+	item, shutdown := b.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer b.queue.Done(item)
+
+	key := item.(bootKey)
+	bootType := appv1.GetBootType(key.kind)
+	if bootType == nil {
+		return true
+	}
+
+	b.limiter.Accept()
+	if boot.Enqueue(bootType, key.NamespacedName, stop) {
+		log.Info("Enqueued Boot by reloading operator config", "kind", key.kind, "boot", key.NamespacedName)
+		loganMetrics.UpdateConfigReloadBoots(key.kind)
+	}
+	return true
+}
@@ -2,15 +2,18 @@ package config
 
 import (
 	"bytes"
+	"fmt"
+	appv1 "github.com/logancloud/logan-app-operator/pkg/apis/app/v1"
 	"github.com/logancloud/logan-app-operator/pkg/logan"
 	"github.com/logancloud/logan-app-operator/pkg/logan/util"
 	"io"
-	"k8s.io/api/core/v1"
 	corev1 "k8s.io/api/core/v1"
 	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
 	"os"
+	"reflect"
 	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
 	"strings"
+	"sync"
 )
 
 const (
@@ -33,48 +36,47 @@ type BootConfig struct {
 	SidecarServices   *[]SidecarService
 }
 
-// AppSpec define the App spec
-type AppSpec struct {
-	Type         string                  `json:"type"`
-	Port         int32                   `json:"port"`
-	Replicas     int32                   `json:"replicas"`
-	Health       string                  `json:"health"`
-	Env          []corev1.EnvVar         `json:"env"`
-	Resources    v1.ResourceRequirements `json:"resources"`
-	NodeSelector map[string]string       `json:"nodeSelector"`
-	SubDomain    string                  `json:"subDomain"`
-
-	PodSpec   *corev1.PodSpec   `json:"podSpec"`
-	Container *corev1.Container `json:"container"`
-	Settings  *SettingsConfig   `json:"settings"`
-}
-
-// SidecarService define the service for Sidecar
-type SidecarService struct {
-	Name string `json:"name"`
-	Port int32  `json:"port"`
-}
-
-var (
-	// JavaConfig is the config for JavaBoot
-	JavaConfig *BootConfig
-	// PhpConfig is the config for PhpBoot
-	PhpConfig *BootConfig
-	// PythonConfig is the config for PythonBoot
-	PythonConfig *BootConfig
-	// NodeJSConfig is the config for NodeJSBoot
-	NodeJSConfig *BootConfig
-	// WebConfig is the config for WebBoot
-	WebConfig *BootConfig
-	// ProfileConfig is the profile support config for All Boots, support to override the default profile.
-	ProfileConfig map[string]*BootConfig
+// The operator config's model is defined by the LoganOperatorConfig API.
+type (
+	// AppSpec define the App spec
+	AppSpec = appv1.AppSpec
+	// IngressConfig define the Ingress generated from Boot's subDomain
+	IngressConfig = appv1.IngressConfig
+	// JVMConfig define the JVM flags computed from the app container's resource limits
+	JVMConfig = appv1.JVMConfig
+	// SchedulingConfig define the scheduling settings for the Boots
+	SchedulingConfig = appv1.SchedulingConfig
+	// SchedulingSpec define the scheduling fields of the Boot
+	SchedulingSpec = appv1.SchedulingSpec
+	// SidecarService define the service for Sidecar
+	SidecarService = appv1.SidecarService
+	// SettingsConfig is the common struct for Settings
+	SettingsConfig = appv1.SettingsConfig
+	// OperatorConfig is the struct for boot's global config
+	OperatorConfig = appv1.OperatorConfig
+	// Extends is the boot types or the profiles a profile inherits
+	Extends = appv1.Extends
+	// OverlaySelector selects the namespaces, whose Boots the overlay is applied to
+	OverlaySelector = appv1.OverlaySelector
 )
 
-// SettingsConfig is the common struct for Settings
-type SettingsConfig struct {
-	Registry         string `json:"registry"`
-	AppHealthPort    int32  `json:"appHealthPort"`
-	PrometheusScrape *bool  `json:"prometheusScrape"`
+// Config is a parsed operator config snapshot. It is immutable once parsed, and is swapped as a whole by the
+// ConfigProvider when the config is reloaded.
+type Config struct {
+	// BootTypeConfig is the config for all registered Boot's types, keyed by the type's config key.
+	BootTypeConfig map[string]*BootConfig
+	// ProfileConfig is the config for the profiles, keyed by the profile.
+	ProfileConfig map[string]*BootConfig
+	// OverlayConfig is the config for the namespace overlays, keyed by the overlay. An overlay is not defaulted,
+	// only its declared config is applied onto the Boot's config.
+	OverlayConfig map[string]*OperatorConfig
+
+	// raw is the config of the Boot's types and the profiles before the defaults, to apply the overlays onto.
+	raw GlobalConfig
+	// baseTypes is the base boot type of the keys
+	baseTypes map[string]string
+	// overlaid caches the configs with the overlays applied, keyed by the config key and the overlays.
+	overlaid *sync.Map
 }
 
 // GlobalConfig is the entry for all boot's config
@@ -85,173 +87,246 @@ type SettingsConfig struct {
 // 	- "web": default Web operator config
 type GlobalConfig map[string]*OperatorConfig
 
-// OperatorConfig is the struct for boot's global config
-// 	- Operator's default settings：settings
-// 	- operator's env specific config，oEnvs
-//	- Container Info
-//		1. application(app) container config: app
-//		2. sidecar containers：sidecarContainers
-//		3. sidecar services：sidecarServices
-type OperatorConfig struct {
-	// Operator配置信息
-	Settings *SettingsConfig `json:"settings"`
-
-	// Operator的环境信息配置
-	OEnvs map[string]map[string]AppSpec `json:"oEnvs"`
-
-	// Operator的默认App配置
-	AppSpec *AppSpec `json:"app"`
-
-	//Operator的默认SidecarContainers配置
-	SidecarContainers *[]corev1.Container `json:"sideCarContainers"`
-
-	// Sidecar的Service列表
-	SidecarServices *[]SidecarService `json:"sidecarServices"`
-}
-
-// InitByFile will initialize the config from the file
-func InitByFile(configFile string) error {
+// ParseConfigFile will parse the config from the file
+func ParseConfigFile(configFile string) (*Config, error) {
 	f, err := os.Open(configFile)
 	if err != nil {
-		log.Error(err, "Can not open config file")
-		os.Exit(1)
+		return nil, err
 	}
+	defer f.Close()
 
-	return Init(f)
+	return ParseConfig(f)
 }
 
-// Init will initialize the config from the io.Reader
-func Init(content io.Reader) error {
-	err := NewConfig(content)
+// ParseConfig will parse the config from the io.Reader with defaults.
+func ParseConfig(content io.Reader) (*Config, error) {
+	c, err := DecodeGlobalConfig(content)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return parseGlobalConfig(c)
 }
 
-// NewConfig will initialize the config from the io.Reader
-func NewConfig(content io.Reader) error {
+// DecodeGlobalConfig will decode the declared config from the io.Reader, without defaults.
+func DecodeGlobalConfig(content io.Reader) (GlobalConfig, error) {
 	c := GlobalConfig{}
 
+	// An empty content is valid, all the Boot's types use the defaults.
 	err := k8syaml.NewYAMLOrJSONDecoder(content, 100).Decode(&c)
-	if err != nil {
-		return err
+	if err != nil && err != io.EOF {
+		return nil, err
 	}
+	return c, nil
+}
 
-	gConfig := c
-	gConfig.applyDefaults()
+// ParseGlobalConfig will parse the declared config with defaults, as the LoganOperatorConfig's spec.
+// The declared config is not changed.
+func ParseGlobalConfig(declared GlobalConfig) (*Config, error) {
+	c := make(GlobalConfig, len(declared))
+	for key, operator := range declared {
+		c[key] = operator.DeepCopy()
+	}
 
-	operator := gConfig[logan.BootJava]
-	JavaConfig = &BootConfig{
-		AppSpec: operator.AppSpec,
+	return parseGlobalConfig(c)
+}
 
-		SidecarContainers: operator.SidecarContainers,
-		SidecarServices:   operator.SidecarServices,
+// parseGlobalConfig parses the declared config, which is changed by applying the defaults.
+func parseGlobalConfig(gConfig GlobalConfig) (*Config, error) {
+	baseTypes, err := gConfig.resolveExtends()
+	if err != nil {
+		return nil, err
 	}
 
-	operator = gConfig[logan.BootPhp]
-	PhpConfig = &BootConfig{
-		AppSpec: operator.AppSpec,
+	// Keep the overlays and the config before the defaults, the overlays are applied onto it.
+	overlays := make(map[string]*OperatorConfig)
+	raw := make(GlobalConfig)
+	for key, operator := range gConfig {
+		if operator != nil && operator.Overlay != nil {
+			err = validateOverlay(key, operator.Overlay)
+			if err != nil {
+				return nil, err
+			}
+			overlays[key] = operator
+			delete(gConfig, key)
+			continue
+		}
 
-		SidecarContainers: operator.SidecarContainers,
-		SidecarServices:   operator.SidecarServices,
+		raw[key], err = copyOperatorConfig(operator)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	operator = gConfig[logan.BootPython]
-	PythonConfig = &BootConfig{
-		AppSpec: operator.AppSpec,
+	gConfig.applyDefaults(baseTypes)
 
-		SidecarContainers: operator.SidecarContainers,
-		SidecarServices:   operator.SidecarServices,
+	var tmpBootTypeConfig = make(map[string]*BootConfig, 0)
+	var tmpProfileConfig = make(map[string]*BootConfig, 0)
+	for key, operator := range gConfig {
+		bootConfig := &BootConfig{
+			AppSpec: operator.AppSpec,
+
+			SidecarContainers: operator.SidecarContainers,
+			SidecarServices:   operator.SidecarServices,
+		}
+		if appv1.GetBootTypeByConfigKey(key) != nil {
+			tmpBootTypeConfig[key] = bootConfig
+		} else {
+			tmpProfileConfig[key] = bootConfig
+		}
 	}
 
-	operator = gConfig[logan.BootNodeJS]
-	NodeJSConfig = &BootConfig{
-		AppSpec: operator.AppSpec,
+	return &Config{
+		BootTypeConfig: tmpBootTypeConfig,
+		ProfileConfig:  tmpProfileConfig,
+		OverlayConfig:  overlays,
+		raw:            raw,
+		baseTypes:      baseTypes,
+		overlaid:       &sync.Map{},
+	}, nil
+}
+
+// ParseConfigFromString will parse the config from string.
+func ParseConfigFromString(content string) (*Config, error) {
+	return ParseConfig(bytes.NewBuffer([]byte(content)))
+}
 
-		SidecarContainers: operator.SidecarContainers,
-		SidecarServices:   operator.SidecarServices,
+// ValidateConfig will parse and validate the config from string, which must not be blank.
+// It has no side effect, the live config is not changed.
+func ValidateConfig(content string) (*Config, error) {
+	if strings.TrimSpace(content) == "" {
+		return nil, fmt.Errorf("%s can not be blank", logan.ConfigFilename)
 	}
 
-	operator = gConfig[logan.BootWeb]
-	WebConfig = &BootConfig{
-		AppSpec: operator.AppSpec,
+	cfg, err := ParseConfigFromString(content)
+	if err != nil {
+		return nil, err
+	}
 
-		SidecarContainers: operator.SidecarContainers,
-		SidecarServices:   operator.SidecarServices,
+	err = cfg.validate()
+	if err != nil {
+		return nil, err
 	}
+	return cfg, nil
+}
 
-	var tmpProfileConfig = make(map[string]*BootConfig, 0)
-	for key, operator := range gConfig {
-		if key != logan.BootJava && key != logan.BootPhp && key != logan.BootPython && key != logan.BootNodeJS && key != logan.BootWeb {
-			tmpProfileConfig[key] = &BootConfig{
-				AppSpec: operator.AppSpec,
+// ValidateGlobalConfig will parse and validate the declared config, as the LoganOperatorConfig's spec.
+// It has no side effect, the live config is not changed.
+func ValidateGlobalConfig(declared GlobalConfig) (*Config, error) {
+	cfg, err := ParseGlobalConfig(declared)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cfg.validate()
+	if err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
 
-				SidecarContainers: operator.SidecarContainers,
-				SidecarServices:   operator.SidecarServices,
+// validate returns an error if the parsed config's values are invalid
+func (cfg *Config) validate() error {
+	for _, configs := range []map[string]*BootConfig{cfg.BootTypeConfig, cfg.ProfileConfig} {
+		for key, bootCfg := range configs {
+			if bootCfg.AppSpec == nil || bootCfg.AppSpec.JVM == nil {
+				continue
+			}
+			jvm := bootCfg.AppSpec.JVM
+			if jvm.HeapPercentage < 0 || jvm.HeapPercentage > 100 {
+				return fmt.Errorf("%s: jvm heapPercentage %d is not in [0, 100]", key, jvm.HeapPercentage)
+			}
+			if jvm.InitialHeapPercentage < 0 || jvm.InitialHeapPercentage > 100 {
+				return fmt.Errorf("%s: jvm initialHeapPercentage %d is not in [0, 100]",
+					key, jvm.InitialHeapPercentage)
 			}
 		}
 	}
 
-	ProfileConfig = tmpProfileConfig
-
 	return nil
 }
 
-// NewConfigFromString will initialize the config from string, for testing,
-func NewConfigFromString(content string) error {
-	if content == "" {
-		globalCfg := &GlobalConfig{}
-		globalCfg.applyDefaults()
+// GetBootConfig returns the config of the registered Boot's type by the type's config key, nil if not found.
+func (cfg *Config) GetBootConfig(configKey string) *BootConfig {
+	if cfg == nil {
+		return nil
+	}
+
+	return cfg.BootTypeConfig[configKey]
+}
+
+// GetProfileConfig returns the config of the profile, nil if not found.
+func (cfg *Config) GetProfileConfig(profile string) *BootConfig {
+	if cfg == nil {
 		return nil
 	}
 
-	return NewConfig(bytes.NewBuffer([]byte(content)))
+	return cfg.ProfileConfig[profile]
 }
 
-func (globalCfg GlobalConfig) applyDefaults() {
-	applyDefaultWithSidecar(globalCfg, globalCfg[logan.BootJava], logan.BootJava)
+// ChangedKeys returns the keys of the Boot's types, the profiles and the overlays, whose config is changed, added
+// or removed.
+func ChangedKeys(old *Config, updated *Config) map[string]bool {
+	changed := make(map[string]bool)
+	if old == nil {
+		old = &Config{}
+	}
 
-	applyDefaultWithSidecar(globalCfg, globalCfg[logan.BootPhp], logan.BootPhp)
+	for _, pair := range [][2]map[string]*BootConfig{
+		{old.BootTypeConfig, updated.BootTypeConfig},
+		{old.ProfileConfig, updated.ProfileConfig},
+	} {
+		for key, oldCfg := range pair[0] {
+			if !reflect.DeepEqual(oldCfg, pair[1][key]) {
+				changed[key] = true
+			}
+		}
+		for key := range pair[1] {
+			if _, ok := pair[0][key]; !ok {
+				changed[key] = true
+			}
+		}
+	}
 
-	applyDefaultWithSidecar(globalCfg, globalCfg[logan.BootPython], logan.BootPython)
+	for key, oldCfg := range old.OverlayConfig {
+		if !reflect.DeepEqual(oldCfg, updated.OverlayConfig[key]) {
+			changed[key] = true
+		}
+	}
+	for key := range updated.OverlayConfig {
+		if _, ok := old.OverlayConfig[key]; !ok {
+			changed[key] = true
+		}
+	}
 
-	applyDefaultWithSidecar(globalCfg, globalCfg[logan.BootNodeJS], logan.BootNodeJS)
+	return changed
+}
 
-	applyDefaultWithSidecar(globalCfg, globalCfg[logan.BootWeb], logan.BootWeb)
+// applyDefaults applies the defaults to the boot types and the profiles, the profile extending a boot type uses
+// the type's built-in defaults, the baseTypes are the base boot type of the keys.
+func (globalCfg GlobalConfig) applyDefaults(baseTypes map[string]string) {
+	for _, bootType := range appv1.BootTypes() {
+		applyDefaultWithSidecar(globalCfg, globalCfg[bootType.ConfigKey], bootType.ConfigKey, bootType.ConfigKey)
+	}
 
 	for key, value := range globalCfg {
-		if key != logan.BootJava && key != logan.BootPhp && key != logan.BootPython && key != logan.BootNodeJS && key != logan.BootWeb {
-			applyDefaultWithSidecar(globalCfg, value, key)
+		if appv1.GetBootTypeByConfigKey(key) == nil {
+			applyDefaultWithSidecar(globalCfg, value, key, baseTypes[key])
 		}
 	}
 }
 
-func applyDefaultWithSidecar(globalCfg GlobalConfig, operatorCfg *OperatorConfig, bootType string) {
+func applyDefaultWithSidecar(globalCfg GlobalConfig, operatorCfg *OperatorConfig, bootType string, baseType string) {
 	if operatorCfg == nil {
 		operatorCfg = &OperatorConfig{}
-		if bootType == logan.BootJava {
-			globalCfg[logan.BootJava] = operatorCfg
-		} else if bootType == logan.BootPhp {
-			globalCfg[logan.BootPhp] = operatorCfg
-		} else if bootType == logan.BootPython {
-			globalCfg[logan.BootPython] = operatorCfg
-		} else if bootType == logan.BootNodeJS {
-			globalCfg[logan.BootNodeJS] = operatorCfg
-		} else if bootType == logan.BootWeb {
-			globalCfg[logan.BootWeb] = operatorCfg
-		} else {
-			// Other profiles: use key
-			globalCfg[bootType] = operatorCfg
-		}
+		globalCfg[bootType] = operatorCfg
 	}
 
 	if operatorCfg.AppSpec == nil {
 		operatorCfg.AppSpec = &AppSpec{}
 	}
 	appSpec := operatorCfg.AppSpec
-	applyDefault(operatorCfg, appSpec, bootType)
+	applyDefault(operatorCfg, appSpec, bootType, baseType)
 
 	// Replace Registry's name, Merge env
 	// 1. InitContainers
@@ -313,7 +388,7 @@ func applyDefaultWithSidecar(globalCfg GlobalConfig, operatorCfg *OperatorConfig
 	}
 }
 
-func applyDefault(operatorCfg *OperatorConfig, appSpec *AppSpec, bootType string) {
+func applyDefault(operatorCfg *OperatorConfig, appSpec *AppSpec, bootType string, baseType string) {
 	if appSpec.Port <= 0 {
 		appSpec.Port = defaultPort
 	}
@@ -326,6 +401,12 @@ func applyDefault(operatorCfg *OperatorConfig, appSpec *AppSpec, bootType string
 		appSpec.Health = defaultHealth
 	}
 
+	// Built-in resources of the Boot's type, when the config has no resources.
+	if registered := appv1.GetBootTypeByConfigKey(baseType); registered != nil && registered.Resources != nil &&
+		len(appSpec.Resources.Limits) == 0 && len(appSpec.Resources.Requests) == 0 {
+		appSpec.Resources = *registered.Resources.DeepCopy()
+	}
+
 	if appSpec.Settings == nil {
 		appSpec.Settings = &SettingsConfig{}
 	}
@@ -4,16 +4,21 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	coreV1 "k8s.io/api/core/v1"
+	"strings"
 )
 
 var _ = Describe("Config", func() {
 
 	Context("With empty config content", func() {
-		var err error
-
 		It("Test empty config is valid", func() {
-			err = NewConfigFromString("")
+			cfg, err := ParseConfigFromString("")
 			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.GetBootConfig("java").AppSpec.Port).To(BeEquivalentTo(8080))
+		})
+
+		It("Test blank config is invalid for validating", func() {
+			_, err := ValidateConfig(" \n")
+			Expect(err).To(HaveOccurred())
 		})
 	})
 
@@ -37,14 +42,158 @@ java:
       - name: SERVER_PORT
         value: "8080"
 `
-			err := NewConfigFromString(text)
+			cfg, err := ParseConfigFromString(text)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(cfg.GetBootConfig("java").AppSpec.Port).To(BeEquivalentTo(8080))
+			Expect(cfg.GetBootConfig("java").AppSpec.Replicas).To(BeEquivalentTo(1))
+			Expect(cfg.GetBootConfig("java").AppSpec.Health).To(Equal("/health"))
+
+			Expect(cfg.GetBootConfig("java").AppSpec).NotTo(BeNil())
+		})
+	})
+
+	Context("With registered boot types", func() {
+		It("Test registered types and profiles config", func() {
+			text := `
+java:
+  app:
+    port: 8081
+myprofile:
+  app:
+    port: 8082
+`
+			cfg, err := ParseConfigFromString(text)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(cfg.GetBootConfig("java")).To(Equal(cfg.BootTypeConfig["java"]))
+			Expect(cfg.GetBootConfig("java").AppSpec.Port).To(BeEquivalentTo(8081))
+			for _, key := range []string{"php", "python", "nodejs", "web", "go", "dotnet"} {
+				Expect(cfg.GetBootConfig(key)).NotTo(BeNil())
+				Expect(cfg.GetBootConfig(key).AppSpec.Port).To(BeEquivalentTo(8080))
+			}
+			Expect(cfg.GetBootConfig("myprofile")).To(BeNil())
+
+			Expect(cfg.ProfileConfig).To(HaveKey("myprofile"))
+			Expect(cfg.ProfileConfig).NotTo(HaveKey("java"))
+			Expect(cfg.ProfileConfig["myprofile"].AppSpec.Port).To(BeEquivalentTo(8082))
+		})
+	})
+
+	Context("With config snapshots", func() {
+		It("Test swapping config snapshot by provider", func() {
+			old, err := ParseConfigFromString(`
+java:
+  app:
+    port: 8081
+`)
+			Expect(err).NotTo(HaveOccurred())
+			provider := NewProvider(old)
+			Expect(provider.Config()).To(Equal(old))
+
+			cfg, err := ParseConfigFromString(`
+java:
+  app:
+    port: 8082
+`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.GetBootConfig("java").AppSpec.Port).To(BeEquivalentTo(8082))
+			Expect(provider.Config().GetBootConfig("java").AppSpec.Port).To(BeEquivalentTo(8081))
+
+			_, err = ParseConfigFromString("java: [")
+			Expect(err).To(HaveOccurred())
+
+			provider.Set(cfg)
+			Expect(provider.Config()).To(Equal(cfg))
+			Expect(provider.Config().GetBootConfig("java").AppSpec.Port).To(BeEquivalentTo(8082))
+			Expect(old.GetBootConfig("java").AppSpec.Port).To(BeEquivalentTo(8081))
+		})
+
+		It("Test several providers in one process", func() {
+			javaCfg, err := ParseConfigFromString(`
+java:
+  app:
+    port: 8081
+`)
+			Expect(err).NotTo(HaveOccurred())
+			phpCfg, err := ParseConfigFromString(`
+php:
+  app:
+    port: 8082
+`)
 			Expect(err).NotTo(HaveOccurred())
 
-			Expect(JavaConfig.AppSpec.Port).To(BeEquivalentTo(8080))
-			Expect(JavaConfig.AppSpec.Replicas).To(BeEquivalentTo(1))
-			Expect(JavaConfig.AppSpec.Health).To(Equal("/health"))
+			var javaProvider, phpProvider ConfigProvider = NewProvider(javaCfg), NewProvider(phpCfg)
+			Expect(javaProvider.Config().GetBootConfig("java").AppSpec.Port).To(BeEquivalentTo(8081))
+			Expect(javaProvider.Config().GetBootConfig("php").AppSpec.Port).To(BeEquivalentTo(8080))
+			Expect(phpProvider.Config().GetBootConfig("java").AppSpec.Port).To(BeEquivalentTo(8080))
+			Expect(phpProvider.Config().GetBootConfig("php").AppSpec.Port).To(BeEquivalentTo(8082))
 
-			Expect(JavaConfig.AppSpec).NotTo(BeNil())
+			var empty *Config
+			Expect(empty.GetBootConfig("java")).To(BeNil())
+			Expect(empty.GetProfileConfig("myprofile")).To(BeNil())
+		})
+
+		It("Test validating config", func() {
+			cfg, err := ValidateConfig(`
+java:
+  app:
+    port: 8081
+    jvm:
+      heapPercentage: 75
+`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.GetBootConfig("java").AppSpec.Port).To(BeEquivalentTo(8081))
+
+			_, err = ValidateConfig("java: [")
+			Expect(err).To(HaveOccurred())
+
+			_, err = ValidateConfig(`
+java:
+  app:
+    jvm:
+      heapPercentage: 120
+`)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Test changed keys of the config", func() {
+			old, err := ParseConfigFromString(`
+java:
+  app:
+    port: 8081
+php:
+  app:
+    port: 7777
+profile1:
+  app:
+    port: 8083
+profile2:
+  app:
+    port: 8084
+`)
+			Expect(err).NotTo(HaveOccurred())
+
+			updated, err := ParseConfigFromString(`
+java:
+  app:
+    port: 8081
+php:
+  app:
+    port: 7778
+profile2:
+  app:
+    port: 8084
+profile3:
+  app:
+    port: 8085
+`)
+			Expect(err).NotTo(HaveOccurred())
+
+			changed := ChangedKeys(old, updated)
+			Expect(changed).To(Equal(map[string]bool{"php": true, "profile1": true, "profile3": true}))
+			Expect(ChangedKeys(updated, updated)).To(BeEmpty())
+			Expect(ChangedKeys(nil, updated)).To(HaveKey("java"))
 		})
 	})
 
@@ -58,13 +207,13 @@ java:
   oEnvs:
     app:
 `
-			err := NewConfigFromString(text)
+			cfg, err := ParseConfigFromString(text)
 			Expect(err).NotTo(HaveOccurred())
 
-			Expect(JavaConfig.AppSpec.Port).To(BeEquivalentTo(8080))
-			Expect(JavaConfig.AppSpec.Replicas).To(BeEquivalentTo(1))
-			Expect(JavaConfig.AppSpec.Health).To(Equal("/health"))
-			Expect(JavaConfig.SidecarContainers).Should(BeNil())
+			Expect(cfg.GetBootConfig("java").AppSpec.Port).To(BeEquivalentTo(8080))
+			Expect(cfg.GetBootConfig("java").AppSpec.Replicas).To(BeEquivalentTo(1))
+			Expect(cfg.GetBootConfig("java").AppSpec.Health).To(Equal("/health"))
+			Expect(cfg.GetBootConfig("java").SidecarContainers).Should(BeNil())
 		})
 
 		It("Test app config with oenv config", func() {
@@ -93,24 +242,24 @@ java:
             memory: "1Gi"
         subDomain: "2exp.logan.local"
 `
-			err := NewConfigFromString(text)
+			cfg, err := ParseConfigFromString(text)
 			Expect(err).NotTo(HaveOccurred())
 
-			Expect(JavaConfig.AppSpec.Port).To(BeEquivalentTo(8082))
-			Expect(JavaConfig.AppSpec.Replicas).To(BeEquivalentTo(2))
-			Expect(JavaConfig.AppSpec.Health).To(Equal("/health2"))
-			Expect(JavaConfig.AppSpec.SubDomain).To(Equal("2exp.logan.local"))
+			Expect(cfg.GetBootConfig("java").AppSpec.Port).To(BeEquivalentTo(8082))
+			Expect(cfg.GetBootConfig("java").AppSpec.Replicas).To(BeEquivalentTo(2))
+			Expect(cfg.GetBootConfig("java").AppSpec.Health).To(Equal("/health2"))
+			Expect(cfg.GetBootConfig("java").AppSpec.SubDomain).To(Equal("2exp.logan.local"))
 
-			Expect(JavaConfig.AppSpec.Env[0].Name).To(Equal("SPRING_ZIPKIN_ENABLED2"))
-			Expect(JavaConfig.AppSpec.Env[0].Value).To(Equal("true"))
+			Expect(cfg.GetBootConfig("java").AppSpec.Env[0].Name).To(Equal("SPRING_ZIPKIN_ENABLED2"))
+			Expect(cfg.GetBootConfig("java").AppSpec.Env[0].Value).To(Equal("true"))
 
 			myNodeSelector := map[string]string{"logan/env": "test"}
-			Expect(JavaConfig.AppSpec.NodeSelector).Should(Equal(myNodeSelector))
+			Expect(cfg.GetBootConfig("java").AppSpec.NodeSelector).Should(Equal(myNodeSelector))
 
-			Expect(JavaConfig.AppSpec.Resources.Limits.Cpu().Value()).To(Equal(int64(2)))
-			Expect(JavaConfig.AppSpec.Resources.Limits.Memory().Value()).To(Equal(int64(2048 * 1024 * 1024)))
-			Expect(JavaConfig.AppSpec.Resources.Requests.Cpu().Value()).To(Equal(int64(1)))
-			Expect(JavaConfig.AppSpec.Resources.Requests.Memory().Value()).To(Equal(int64(1024 * 1024 * 1024)))
+			Expect(cfg.GetBootConfig("java").AppSpec.Resources.Limits.Cpu().Value()).To(Equal(int64(2)))
+			Expect(cfg.GetBootConfig("java").AppSpec.Resources.Limits.Memory().Value()).To(Equal(int64(2048 * 1024 * 1024)))
+			Expect(cfg.GetBootConfig("java").AppSpec.Resources.Requests.Cpu().Value()).To(Equal(int64(1)))
+			Expect(cfg.GetBootConfig("java").AppSpec.Resources.Requests.Memory().Value()).To(Equal(int64(1024 * 1024 * 1024)))
 		})
 
 		It("Test app config with app config", func() {
@@ -140,23 +289,23 @@ java:
         memory: "1Gi"
     subDomain: "3exp.logan.local"
 `
-			err := NewConfigFromString(text)
+			cfg, err := ParseConfigFromString(text)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(JavaConfig.AppSpec.Port).To(BeEquivalentTo(8083))
-			Expect(JavaConfig.AppSpec.Replicas).To(BeEquivalentTo(3))
-			Expect(JavaConfig.AppSpec.Health).To(Equal("/health3"))
-			Expect(JavaConfig.AppSpec.SubDomain).To(Equal("3exp.logan.local"))
+			Expect(cfg.GetBootConfig("java").AppSpec.Port).To(BeEquivalentTo(8083))
+			Expect(cfg.GetBootConfig("java").AppSpec.Replicas).To(BeEquivalentTo(3))
+			Expect(cfg.GetBootConfig("java").AppSpec.Health).To(Equal("/health3"))
+			Expect(cfg.GetBootConfig("java").AppSpec.SubDomain).To(Equal("3exp.logan.local"))
 
-			Expect(JavaConfig.AppSpec.Env[0].Name).To(Equal("SPRING_ZIPKIN_ENABLED"))
-			Expect(JavaConfig.AppSpec.Env[0].Value).To(Equal("true"))
+			Expect(cfg.GetBootConfig("java").AppSpec.Env[0].Name).To(Equal("SPRING_ZIPKIN_ENABLED"))
+			Expect(cfg.GetBootConfig("java").AppSpec.Env[0].Value).To(Equal("true"))
 
 			myNodeSelector := map[string]string{"logan/env": "test"}
-			Expect(JavaConfig.AppSpec.NodeSelector).Should(Equal(myNodeSelector))
+			Expect(cfg.GetBootConfig("java").AppSpec.NodeSelector).Should(Equal(myNodeSelector))
 
-			Expect(JavaConfig.AppSpec.Resources.Limits.Cpu().Value()).To(Equal(int64(2)))
-			Expect(JavaConfig.AppSpec.Resources.Limits.Memory().Value()).To(Equal(int64(2048 * 1024 * 1024)))
-			Expect(JavaConfig.AppSpec.Resources.Requests.Cpu().Value()).To(Equal(int64(1)))
-			Expect(JavaConfig.AppSpec.Resources.Requests.Memory().Value()).To(Equal(int64(1024 * 1024 * 1024)))
+			Expect(cfg.GetBootConfig("java").AppSpec.Resources.Limits.Cpu().Value()).To(Equal(int64(2)))
+			Expect(cfg.GetBootConfig("java").AppSpec.Resources.Limits.Memory().Value()).To(Equal(int64(2048 * 1024 * 1024)))
+			Expect(cfg.GetBootConfig("java").AppSpec.Resources.Requests.Cpu().Value()).To(Equal(int64(1)))
+			Expect(cfg.GetBootConfig("java").AppSpec.Resources.Requests.Memory().Value()).To(Equal(int64(1024 * 1024 * 1024)))
 		})
 
 		It("Test app config order", func() {
@@ -205,21 +354,21 @@ java:
         memory: "1Gi"
     subDomain: "3exp.logan.local"
 `
-			err := NewConfigFromString(text)
+			cfg, err := ParseConfigFromString(text)
 			Expect(err).NotTo(HaveOccurred())
 
-			Expect(JavaConfig.AppSpec.Port).To(BeEquivalentTo(8082))
-			Expect(JavaConfig.AppSpec.Replicas).To(BeEquivalentTo(2))
-			Expect(JavaConfig.AppSpec.Health).To(Equal("/health2"))
-			Expect(JavaConfig.AppSpec.SubDomain).To(Equal("2exp.logan.local"))
+			Expect(cfg.GetBootConfig("java").AppSpec.Port).To(BeEquivalentTo(8082))
+			Expect(cfg.GetBootConfig("java").AppSpec.Replicas).To(BeEquivalentTo(2))
+			Expect(cfg.GetBootConfig("java").AppSpec.Health).To(Equal("/health2"))
+			Expect(cfg.GetBootConfig("java").AppSpec.SubDomain).To(Equal("2exp.logan.local"))
 
 			myNodeSelector := map[string]string{"logan/envA": "A", "logan/envB": "B", "logan/envC": "C"}
-			Expect(JavaConfig.AppSpec.NodeSelector).Should(Equal(myNodeSelector))
+			Expect(cfg.GetBootConfig("java").AppSpec.NodeSelector).Should(Equal(myNodeSelector))
 
-			Expect(JavaConfig.AppSpec.Resources.Limits.Cpu().Value()).To(Equal(int64(4)))
-			Expect(JavaConfig.AppSpec.Resources.Limits.Memory().Value()).To(Equal(int64(4 * 1024 * 1024 * 1024)))
-			Expect(JavaConfig.AppSpec.Resources.Requests.Cpu().Value()).To(Equal(int64(3)))
-			Expect(JavaConfig.AppSpec.Resources.Requests.Memory().Value()).To(Equal(int64(3 * 1024 * 1024 * 1024)))
+			Expect(cfg.GetBootConfig("java").AppSpec.Resources.Limits.Cpu().Value()).To(Equal(int64(4)))
+			Expect(cfg.GetBootConfig("java").AppSpec.Resources.Limits.Memory().Value()).To(Equal(int64(4 * 1024 * 1024 * 1024)))
+			Expect(cfg.GetBootConfig("java").AppSpec.Resources.Requests.Cpu().Value()).To(Equal(int64(3)))
+			Expect(cfg.GetBootConfig("java").AppSpec.Resources.Requests.Memory().Value()).To(Equal(int64(3 * 1024 * 1024 * 1024)))
 		})
 
 		It("Test app config env order", func() {
@@ -244,15 +393,15 @@ java:
       - name: MY_ENV_APP
         value: "A"
 `
-			err := NewConfigFromString(text)
+			cfg, err := ParseConfigFromString(text)
 			Expect(err).NotTo(HaveOccurred())
 
-			Expect(JavaConfig.AppSpec.Env[0].Name).Should(Equal("SPRING_ZIPKIN_ENABLED"))
-			Expect(JavaConfig.AppSpec.Env[0].Value).Should(Equal("false"))
-			Expect(JavaConfig.AppSpec.Env[1].Name).Should(Equal("MY_ENV_APP"))
-			Expect(JavaConfig.AppSpec.Env[1].Value).Should(Equal("A"))
-			Expect(JavaConfig.AppSpec.Env[2].Name).Should(Equal("MY_OENV_APP"))
-			Expect(JavaConfig.AppSpec.Env[2].Value).Should(Equal("B"))
+			Expect(cfg.GetBootConfig("java").AppSpec.Env[0].Name).Should(Equal("SPRING_ZIPKIN_ENABLED"))
+			Expect(cfg.GetBootConfig("java").AppSpec.Env[0].Value).Should(Equal("false"))
+			Expect(cfg.GetBootConfig("java").AppSpec.Env[1].Name).Should(Equal("MY_ENV_APP"))
+			Expect(cfg.GetBootConfig("java").AppSpec.Env[1].Value).Should(Equal("A"))
+			Expect(cfg.GetBootConfig("java").AppSpec.Env[2].Name).Should(Equal("MY_OENV_APP"))
+			Expect(cfg.GetBootConfig("java").AppSpec.Env[2].Value).Should(Equal("B"))
 
 		})
 
@@ -291,10 +440,10 @@ php:
         - name: C
           value: "C"
 `
-			err := NewConfigFromString(text)
+			cfg, err := ParseConfigFromString(text)
 			Expect(err).NotTo(HaveOccurred())
 
-			for _, c := range *PhpConfig.SidecarContainers {
+			for _, c := range *cfg.GetBootConfig("php").SidecarContainers {
 				Expect(c.Env[0].Name).Should(Equal("A"))
 				Expect(c.Env[0].Value).Should(Equal("A"))
 				Expect(c.Env[1].Name).Should(Equal("C"))
@@ -336,10 +485,10 @@ php:
         - mountPath: /opt/data
           name: shared-data
 `
-			err := NewConfigFromString(text)
+			cfg, err := ParseConfigFromString(text)
 			Expect(err).NotTo(HaveOccurred())
 
-			for _, c := range *PhpConfig.SidecarContainers {
+			for _, c := range *cfg.GetBootConfig("php").SidecarContainers {
 				Expect(c.Name).Should(Equal("sidecar"))
 				Expect(c.Image).Should(Equal("${REGISTRY}/logancloud/logan-pulse-sidecar:0.1.2"))
 				Expect(c.ImagePullPolicy).Should(Equal(coreV1.PullAlways))
@@ -362,15 +511,676 @@ php:
     - name: ${APP}-sidecar
       port: 5678
 `
-			err := NewConfigFromString(text)
+			cfg, err := ParseConfigFromString(text)
 			Expect(err).NotTo(HaveOccurred())
 
-			for _, s := range *PhpConfig.SidecarServices {
+			for _, s := range *cfg.GetBootConfig("php").SidecarServices {
 				Expect(s.Name).Should(Equal("${APP}-sidecar"))
 				Expect(s.Port).Should(Equal(int32(5678)))
 			}
 		})
 
+		It("Test app config autoscaling", func() {
+			text := `
+java:
+  oEnvs:
+    app:
+      test:
+        autoscaling:
+          minReplicas: 2
+          maxReplicas: 10
+          targetCPUUtilizationPercentage: 70
+  app:
+    autoscaling:
+      enabled: false
+      maxReplicas: 5
+      targetMemoryUtilizationPercentage: 60
+php:
+  app:
+    port: 8080
+`
+			cfg, err := ParseConfigFromString(text)
+			Expect(err).NotTo(HaveOccurred())
+
+			// oEnv's autoscaling will override the app's autoscaling as a whole.
+			autoscaling := cfg.GetBootConfig("java").AppSpec.Autoscaling
+			Expect(autoscaling).ShouldNot(BeNil())
+			Expect(autoscaling.Enabled).Should(BeNil())
+			Expect(*autoscaling.MinReplicas).Should(Equal(int32(2)))
+			Expect(autoscaling.MaxReplicas).Should(Equal(int32(10)))
+			Expect(*autoscaling.TargetCPUUtilizationPercentage).Should(Equal(int32(70)))
+			Expect(autoscaling.TargetMemoryUtilizationPercentage).Should(BeNil())
+
+			Expect(cfg.GetBootConfig("php").AppSpec.Autoscaling).Should(BeNil())
+		})
+
+		It("Test app config ingress", func() {
+			text := `
+java:
+  oEnvs:
+    app:
+      test:
+        ingress:
+          host: "${APP}-${ENV}.${SUBDOMAIN}"
+          class: nginx
+          tlsSecretName: logan-tls
+          annotations:
+            nginx.ingress.kubernetes.io/proxy-body-size: "8m"
+  app:
+    subDomain: logan.local
+    ingress:
+      class: traefik
+`
+			cfg, err := ParseConfigFromString(text)
+			Expect(err).NotTo(HaveOccurred())
+
+			ingress := cfg.GetBootConfig("java").AppSpec.Ingress
+			Expect(cfg.GetBootConfig("java").AppSpec.SubDomain).Should(Equal("logan.local"))
+			Expect(ingress).ShouldNot(BeNil())
+			Expect(ingress.Enabled).Should(BeNil())
+			Expect(ingress.Host).Should(Equal("${APP}-${ENV}.${SUBDOMAIN}"))
+			Expect(ingress.Class).Should(Equal("nginx"))
+			Expect(ingress.TLSSecretName).Should(Equal("logan-tls"))
+			Expect(ingress.Annotations).Should(HaveKeyWithValue("nginx.ingress.kubernetes.io/proxy-body-size", "8m"))
+		})
+
+		It("Test app config probes", func() {
+			text := `
+java:
+  app:
+    livenessProbe:
+      initialDelaySeconds: 0
+      failureThreshold: 5
+python:
+  app:
+    readinessProbe:
+      type: tcp
+      port: 5000
+`
+			cfg, err := ParseConfigFromString(text)
+			Expect(err).NotTo(HaveOccurred())
+
+			liveness := cfg.GetBootConfig("java").AppSpec.LivenessProbe
+			Expect(liveness).ShouldNot(BeNil())
+			Expect(liveness.Type).Should(BeEmpty())
+			Expect(*liveness.InitialDelaySeconds).Should(Equal(int32(0)))
+			Expect(*liveness.FailureThreshold).Should(Equal(int32(5)))
+			Expect(liveness.PeriodSeconds).Should(BeNil())
+			Expect(cfg.GetBootConfig("java").AppSpec.ReadinessProbe).Should(BeNil())
+
+			readiness := cfg.GetBootConfig("python").AppSpec.ReadinessProbe
+			Expect(readiness).ShouldNot(BeNil())
+			Expect(string(readiness.Type)).Should(Equal("tcp"))
+			Expect(readiness.Port).Should(Equal(int32(5000)))
+			Expect(cfg.GetBootConfig("python").AppSpec.LivenessProbe).Should(BeNil())
+		})
+
+		It("Test app config scheduling", func() {
+			text := `
+java:
+  app:
+    nodeSelector:
+      logan/node: app
+    scheduling:
+      defaults:
+        priorityClassName: logan-normal
+        topologySpread:
+        - topologyKey: failure-domain.beta.kubernetes.io/zone
+          whenUnsatisfiable: ScheduleAnyway
+          weight: 50
+      mandatory:
+        runtimeClassName: gvisor
+        tolerations:
+        - key: logan/dedicated
+          operator: Equal
+          value: app
+          effect: NoSchedule
+`
+			cfg, err := ParseConfigFromString(text)
+			Expect(err).NotTo(HaveOccurred())
+
+			scheduling := cfg.GetBootConfig("java").AppSpec.Scheduling
+			Expect(scheduling).ShouldNot(BeNil())
+			Expect(scheduling.Defaults.PriorityClassName).Should(Equal("logan-normal"))
+			Expect(scheduling.Defaults.TopologySpread).Should(HaveLen(1))
+			Expect(scheduling.Defaults.TopologySpread[0].Weight).Should(Equal(int32(50)))
+			Expect(string(scheduling.Defaults.TopologySpread[0].WhenUnsatisfiable)).Should(Equal("ScheduleAnyway"))
+			Expect(scheduling.Defaults.Tolerations).Should(BeEmpty())
+
+			Expect(*scheduling.Mandatory.RuntimeClassName).Should(Equal("gvisor"))
+			Expect(scheduling.Mandatory.Tolerations).Should(HaveLen(1))
+			Expect(scheduling.Mandatory.Tolerations[0].Key).Should(Equal("logan/dedicated"))
+			Expect(string(scheduling.Mandatory.Tolerations[0].Effect)).Should(Equal("NoSchedule"))
+
+			Expect(cfg.GetBootConfig("php").AppSpec.Scheduling).Should(BeNil())
+		})
+
+		It("Test app config strategy", func() {
+			text := `
+java:
+  app:
+    strategy:
+      maxSurge: 50%
+      maxUnavailable: 0
+      revisionHistoryLimit: 10
+      canary:
+        steps: [1, 50%]
+        bakeSeconds: 300
+php:
+  app:
+    strategy:
+      type: Recreate
+      progressDeadlineSeconds: 300
+      blueGreen:
+        autoSwitch: true
+        scaleDownDelaySeconds: 60
+`
+			cfg, err := ParseConfigFromString(text)
+			Expect(err).NotTo(HaveOccurred())
+
+			javaStrategy := cfg.GetBootConfig("java").AppSpec.Strategy
+			Expect(javaStrategy).ShouldNot(BeNil())
+			Expect(javaStrategy.Type).Should(BeEmpty())
+			Expect(javaStrategy.MaxSurge.String()).Should(Equal("50%"))
+			Expect(javaStrategy.MaxUnavailable.IntValue()).Should(Equal(0))
+			Expect(*javaStrategy.RevisionHistoryLimit).Should(Equal(int32(10)))
+			Expect(javaStrategy.ProgressDeadlineSeconds).Should(BeNil())
+			Expect(javaStrategy.Canary.Steps).Should(HaveLen(2))
+			Expect(javaStrategy.Canary.Steps[0].IntValue()).Should(Equal(1))
+			Expect(javaStrategy.Canary.Steps[1].String()).Should(Equal("50%"))
+			Expect(*javaStrategy.Canary.BakeSeconds).Should(Equal(int32(300)))
+			Expect(javaStrategy.BlueGreen).Should(BeNil())
+
+			phpStrategy := cfg.GetBootConfig("php").AppSpec.Strategy
+			Expect(string(phpStrategy.Type)).Should(Equal("Recreate"))
+			Expect(*phpStrategy.ProgressDeadlineSeconds).Should(Equal(int32(300)))
+			Expect(phpStrategy.MaxSurge).Should(BeNil())
+			Expect(phpStrategy.Canary).Should(BeNil())
+			Expect(*phpStrategy.BlueGreen.AutoSwitch).Should(BeTrue())
+			Expect(*phpStrategy.BlueGreen.ScaleDownDelaySeconds).Should(Equal(int32(60)))
+		})
+
+		It("Test app config restart schedule", func() {
+			text := `
+php:
+  app:
+    restartSchedule:
+      schedule: "0 3 * * *"
+      maxJitterSeconds: 1800
+python:
+  app:
+    restartSchedule:
+      enabled: false
+      schedule: "30 4 * * 0"
+`
+			cfg, err := ParseConfigFromString(text)
+			Expect(err).NotTo(HaveOccurred())
+
+			phpRestart := cfg.GetBootConfig("php").AppSpec.RestartSchedule
+			Expect(phpRestart).ShouldNot(BeNil())
+			Expect(phpRestart.Enabled).Should(BeNil())
+			Expect(phpRestart.Schedule).Should(Equal("0 3 * * *"))
+			Expect(*phpRestart.MaxJitterSeconds).Should(Equal(int32(1800)))
+
+			pythonRestart := cfg.GetBootConfig("python").AppSpec.RestartSchedule
+			Expect(*pythonRestart.Enabled).Should(BeFalse())
+			Expect(pythonRestart.Schedule).Should(Equal("30 4 * * 0"))
+			Expect(pythonRestart.MaxJitterSeconds).Should(BeNil())
+
+			Expect(cfg.GetBootConfig("java").AppSpec.RestartSchedule).Should(BeNil())
+		})
+
+		It("Test app config runtime resources", func() {
+			text := `
+go:
+  app:
+    port: 8090
+dotnet:
+  app:
+    resources:
+      limits:
+        cpu: "2"
+        memory: 1Gi
+`
+			cfg, err := ParseConfigFromString(text)
+			Expect(err).NotTo(HaveOccurred())
+
+			goResources := cfg.GetBootConfig("go").AppSpec.Resources
+			Expect(goResources.Limits.Cpu().String()).Should(Equal("1"))
+			Expect(goResources.Limits.Memory().String()).Should(Equal("256Mi"))
+			Expect(goResources.Requests.Cpu().String()).Should(Equal("10m"))
+			Expect(goResources.Requests.Memory().String()).Should(Equal("64Mi"))
+
+			dotnetResources := cfg.GetBootConfig("dotnet").AppSpec.Resources
+			Expect(dotnetResources.Limits.Cpu().String()).Should(Equal("2"))
+			Expect(dotnetResources.Limits.Memory().String()).Should(Equal("1Gi"))
+			Expect(dotnetResources.Requests).Should(BeEmpty())
+
+			Expect(cfg.GetBootConfig("java").AppSpec.Resources.Limits).Should(BeEmpty())
+		})
+
+		It("Test app config jvm", func() {
+			text := `
+java:
+  app:
+    jvm:
+      heapPercentage: 70
+      gc: G1GC
+      activeProcessorCount: false
+      options:
+        - "-XX:+ExitOnOutOfMemoryError"
+`
+			cfg, err := ParseConfigFromString(text)
+			Expect(err).NotTo(HaveOccurred())
+
+			jvm := cfg.GetBootConfig("java").AppSpec.JVM
+			Expect(jvm).ShouldNot(BeNil())
+			Expect(jvm.Enabled).Should(BeNil())
+			Expect(jvm.EnvName).Should(BeEmpty())
+			Expect(jvm.HeapPercentage).Should(Equal(int32(70)))
+			Expect(jvm.GC).Should(Equal("G1GC"))
+			Expect(*jvm.ActiveProcessorCount).Should(BeFalse())
+			Expect(jvm.Options).Should(Equal([]string{"-XX:+ExitOnOutOfMemoryError"}))
+
+			Expect(cfg.GetBootConfig("php").AppSpec.JVM).Should(BeNil())
+		})
+
+	})
+
+	Context("With profiles extending", func() {
+		It("Test profile extends boot type and mixins", func() {
+			text := `
+java:
+  settings:
+    registry: "registry.logan.local"
+  oEnvs:
+    app:
+      test:
+        env:
+          - name: OENV_APP
+            value: "test"
+  app:
+    port: 8081
+    env:
+      - name: ENV_A
+        value: "A"
+      - name: ENV_B
+        value: "B"
+    nodeSelector:
+      logan/envA: A
+    resources:
+      limits:
+        cpu: "2"
+        memory: "2Gi"
+      requests:
+        cpu: "30m"
+        memory: "512Mi"
+    podSpec:
+      initContainers:
+        - name: init
+          image: "${REGISTRY}/init:1.0"
+      volumes:
+        - name: data
+          emptyDir: {}
+    jvm:
+      gc: G1GC
+      options:
+        - "-XX:+ExitOnOutOfMemoryError"
+  sideCarContainers:
+    - name: agent
+      image: "${REGISTRY}/agent:1.0"
+      env:
+        - name: AGENT_A
+          value: "A"
+tracing:
+  sideCarContainers:
+    - name: agent
+      image: "${REGISTRY}/agent:2.0"
+      env:
+        - name: AGENT_B
+          value: "B"
+  sidecarServices:
+    - name: ${APP}-agent
+      port: 9411
+javaext:
+  extends:
+    - java
+    - tracing
+  app:
+    replicas: 3
+    env:
+      - name: ENV_B
+        value: "B2"
+      - name: ENV_C
+        value: "C"
+    nodeSelector:
+      logan/envB: B
+    resources:
+      limits:
+        memory: "1Gi"
+    podSpec:
+      volumes:
+        - name: cache
+          emptyDir: {}
+    jvm:
+      options:
+        - "-Dprofile=javaext"
+`
+			cfg, err := ParseConfigFromString(text)
+			Expect(err).NotTo(HaveOccurred())
+
+			profile := cfg.GetProfileConfig("javaext")
+			Expect(profile).NotTo(BeNil())
+			appSpec := profile.AppSpec
+			Expect(appSpec.Port).To(BeEquivalentTo(8081))
+			Expect(appSpec.Replicas).To(BeEquivalentTo(3))
+			Expect(appSpec.Settings.Registry).To(Equal("registry.logan.local"))
+			Expect(appSpec.Env).To(Equal([]coreV1.EnvVar{
+				{Name: "ENV_A", Value: "A"},
+				{Name: "ENV_B", Value: "B2"},
+				{Name: "ENV_C", Value: "C"},
+				{Name: "OENV_APP", Value: "test"},
+			}))
+			Expect(appSpec.NodeSelector).To(Equal(map[string]string{"logan/envA": "A", "logan/envB": "B"}))
+			Expect(appSpec.Resources.Limits.Cpu().Value()).To(Equal(int64(2)))
+			Expect(appSpec.Resources.Limits.Memory().Value()).To(Equal(int64(1024 * 1024 * 1024)))
+			Expect(appSpec.Resources.Requests.Memory().Value()).To(Equal(int64(512 * 1024 * 1024)))
+			Expect(appSpec.PodSpec.InitContainers).To(HaveLen(1))
+			Expect(appSpec.PodSpec.InitContainers[0].Image).To(Equal("registry.logan.local/init:1.0"))
+			Expect(appSpec.PodSpec.Volumes).To(HaveLen(2))
+			Expect(appSpec.PodSpec.Volumes[0].Name).To(Equal("data"))
+			Expect(appSpec.PodSpec.Volumes[1].Name).To(Equal("cache"))
+			Expect(appSpec.JVM.GC).To(Equal("G1GC"))
+			Expect(appSpec.JVM.Options).To(Equal([]string{"-Dprofile=javaext"}))
+
+			Expect(*profile.SidecarContainers).To(HaveLen(1))
+			agent := (*profile.SidecarContainers)[0]
+			Expect(agent.Image).To(Equal("registry.logan.local/agent:2.0"))
+			Expect(agent.Env).To(Equal([]coreV1.EnvVar{{Name: "AGENT_A", Value: "A"}, {Name: "AGENT_B", Value: "B"}}))
+			Expect(*profile.SidecarServices).To(Equal([]SidecarService{{Name: "${APP}-agent", Port: 9411}}))
+
+			// The bases are not changed by the profile
+			java := cfg.GetBootConfig("java").AppSpec
+			Expect(java.Replicas).To(BeEquivalentTo(1))
+			Expect(java.Env[1]).To(Equal(coreV1.EnvVar{Name: "ENV_B", Value: "B"}))
+			Expect(java.PodSpec.Volumes).To(HaveLen(1))
+			Expect(java.Resources.Limits.Memory().Value()).To(Equal(int64(2 * 1024 * 1024 * 1024)))
+			Expect((*cfg.GetBootConfig("java").SidecarContainers)[0].Image).To(Equal("registry.logan.local/agent:1.0"))
+			Expect((*cfg.GetProfileConfig("tracing").SidecarContainers)[0].Env).To(HaveLen(1))
+		})
+
+		It("Test profile extends a single key and the profile", func() {
+			text := `
+myprofile:
+  extends: go
+  app:
+    port: 9090
+myprofile2:
+  extends: myprofile
+  app:
+    health: /ready
+`
+			cfg, err := ParseConfigFromString(text)
+			Expect(err).NotTo(HaveOccurred())
+
+			profile := cfg.GetProfileConfig("myprofile2").AppSpec
+			Expect(profile.Port).To(BeEquivalentTo(9090))
+			Expect(profile.Health).To(Equal("/ready"))
+			// The built-in resources of the base type
+			Expect(profile.Resources.Limits.Memory().String()).To(Equal("256Mi"))
+			Expect(cfg.GetProfileConfig("myprofile").AppSpec.Health).To(Equal("/health"))
+		})
+
+		It("Test invalid profile extends", func() {
+			for _, text := range []string{
+				"myprofile:\n  extends: unknown\n",
+				"p1:\n  extends: p2\np2:\n  extends: [java, p1]\n",
+				"java:\n  extends: php\n",
+				"myprofile:\n  extends:\n    key: java\n",
+			} {
+				_, err := ParseConfigFromString(text)
+				Expect(err).To(HaveOccurred(), text)
+			}
+		})
+
+		It("Test changed keys of the profile extending", func() {
+			old, err := ParseConfigFromString(`
+java:
+  app:
+    port: 8081
+javaext:
+  extends: java
+phpext:
+  extends: php
+`)
+			Expect(err).NotTo(HaveOccurred())
+
+			updated, err := ParseConfigFromString(`
+java:
+  app:
+    port: 8082
+javaext:
+  extends: java
+phpext:
+  extends: php
+`)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ChangedKeys(old, updated)).To(Equal(map[string]bool{"java": true, "javaext": true}))
+		})
+	})
+
+	Context("With namespace overlays", func() {
+		text := `
+java:
+  settings:
+    registry: "registry.logan.local"
+  app:
+    env:
+      - name: ENV_A
+        value: "A"
+    resources:
+      limits:
+        cpu: "2"
+        memory: "2Gi"
+  sideCarContainers:
+    - name: agent
+      image: "${REGISTRY}/agent:1.0"
+javaext:
+  extends: java
+  app:
+    replicas: 3
+team-payments:
+  overlay:
+    namespaceSelector:
+      matchLabels:
+        logan/team: payments
+    types: [java]
+  settings:
+    registry: "registry.payments.local"
+  app:
+    env:
+      - name: TEAM
+        value: "payments"
+    nodeSelector:
+      logan/pool: payments
+    resources:
+      limits:
+        memory: "1Gi"
+team-payments-large:
+  overlay:
+    namespaceSelector:
+      matchLabels:
+        logan/team: payments
+    namespaceAnnotations:
+      logan/size: large
+    priority: 10
+  app:
+    resources:
+      limits:
+        memory: "4Gi"
+all-gpu:
+  overlay:
+    namespaceAnnotations:
+      logan/gpu: "true"
+    priority: -1
+  app:
+    nodeSelector:
+      logan/gpu: "true"
+`
+		namespace := func(labels map[string]string, annotations map[string]string) *coreV1.Namespace {
+			ns := &coreV1.Namespace{}
+			ns.Name = "payments"
+			ns.Labels = labels
+			ns.Annotations = annotations
+			return ns
+		}
+
+		It("Test matching overlays by namespace", func() {
+			cfg, err := ParseConfigFromString(text)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(cfg.OverlayConfig).To(HaveLen(3))
+			// The overlays are not the profiles
+			Expect(cfg.GetProfileConfig("team-payments")).To(BeNil())
+
+			payments := map[string]string{"logan/team": "payments"}
+			Expect(cfg.MatchOverlays("java", namespace(nil, nil))).To(BeEmpty())
+			Expect(cfg.MatchOverlays("java", namespace(payments, nil))).To(Equal([]string{"team-payments"}))
+			Expect(cfg.MatchOverlays("php", namespace(payments, nil))).To(BeEmpty())
+			Expect(cfg.MatchOverlays("java", namespace(payments,
+				map[string]string{"logan/size": "large", "logan/gpu": "true"}))).
+				To(Equal([]string{"all-gpu", "team-payments", "team-payments-large"}))
+			Expect(cfg.MatchOverlays("php", namespace(nil, map[string]string{"logan/gpu": "true"}))).
+				To(Equal([]string{"all-gpu"}))
+		})
+
+		It("Test resolving config with overlays", func() {
+			cfg, err := ParseConfigFromString(text)
+			Expect(err).NotTo(HaveOccurred())
+
+			bootCfg, err := cfg.ResolveBootConfig("java", []string{"team-payments"})
+			Expect(err).NotTo(HaveOccurred())
+			appSpec := bootCfg.AppSpec
+			Expect(appSpec.Env).To(Equal([]coreV1.EnvVar{
+				{Name: "ENV_A", Value: "A"},
+				{Name: "TEAM", Value: "payments"},
+			}))
+			Expect(appSpec.NodeSelector).To(Equal(map[string]string{"logan/pool": "payments"}))
+			Expect(appSpec.Resources.Limits.Cpu().Value()).To(Equal(int64(2)))
+			Expect(appSpec.Resources.Limits.Memory().Value()).To(Equal(int64(1024 * 1024 * 1024)))
+			Expect((*bootCfg.SidecarContainers)[0].Image).To(Equal("registry.payments.local/agent:1.0"))
+
+			// The later overlay overrides
+			bootCfg, err = cfg.ResolveBootConfig("java", []string{"team-payments", "team-payments-large"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bootCfg.AppSpec.Resources.Limits.Memory().Value()).To(Equal(int64(4 * 1024 * 1024 * 1024)))
+
+			// The profile with overlays
+			bootCfg, err = cfg.ResolveBootConfig("javaext", []string{"team-payments"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bootCfg.AppSpec.Replicas).To(BeEquivalentTo(3))
+			Expect(bootCfg.AppSpec.Env[1]).To(Equal(coreV1.EnvVar{Name: "TEAM", Value: "payments"}))
+
+			// The resolved config is cached, and the type's config is not changed
+			cached, err := cfg.ResolveBootConfig("java", []string{"team-payments"})
+			Expect(err).NotTo(HaveOccurred())
+			again, _ := cfg.ResolveBootConfig("java", []string{"team-payments"})
+			Expect(again).To(BeIdenticalTo(cached))
+			java := cfg.GetBootConfig("java")
+			Expect(java.AppSpec.Env).To(HaveLen(1))
+			Expect(java.AppSpec.NodeSelector).To(BeEmpty())
+			Expect((*java.SidecarContainers)[0].Image).To(Equal("registry.logan.local/agent:1.0"))
+
+			noOverlay, err := cfg.ResolveBootConfig("java", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(noOverlay).To(BeIdenticalTo(java))
+
+			_, err = cfg.ResolveBootConfig("java", []string{"unknown"})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Test invalid overlays", func() {
+			for _, text := range []string{
+				"team:\n  overlay:\n    types: [java]\n",
+				"team:\n  overlay:\n    namespaceSelector:\n      matchExpressions:\n        - key: logan/team\n          operator: Bad\n",
+				"team:\n  overlay:\n    namespaceAnnotations:\n      logan/team: payments\n    types: [unknown]\n",
+				"java:\n  overlay:\n    namespaceAnnotations:\n      logan/team: payments\n",
+			} {
+				_, err := ParseConfigFromString(text)
+				Expect(err).To(HaveOccurred(), text)
+			}
+		})
+
+		It("Test changed keys of the overlays", func() {
+			old, err := ParseConfigFromString(text)
+			Expect(err).NotTo(HaveOccurred())
+
+			updated, err := ParseConfigFromString(strings.Replace(text, `value: "payments"`, `value: "payments2"`, 1) + `
+team-orders:
+  overlay:
+    namespaceAnnotations:
+      logan/team: orders
+`)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ChangedKeys(old, updated)).To(Equal(map[string]bool{"team-payments": true, "team-orders": true}))
+		})
 	})
 
+	Context("With LoganOperatorConfig spec", func() {
+		text := `
+java:
+  app:
+    port: 8081
+    env:
+      - name: SPRING_PROFILES_ACTIVE
+        value: "${ENV}"
+javaext:
+  extends: java
+  app:
+    replicas: 2
+    jvm:
+      heapPercentage: 60
+`
+
+		It("Test decoding and parsing the declared config", func() {
+			declared, err := DecodeGlobalConfig(strings.NewReader(text))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(declared["javaext"].Extends).To(Equal(Extends{"java"}))
+			Expect(declared["php"]).To(BeNil())
+
+			expected := make(GlobalConfig, len(declared))
+			for key, operator := range declared {
+				expected[key] = operator.DeepCopy()
+			}
+
+			cfg, err := ParseGlobalConfig(declared)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(declared).To(Equal(expected))
+
+			parsed, err := ParseConfigFromString(text)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.GetBootConfig("java")).To(Equal(parsed.GetBootConfig("java")))
+			Expect(cfg.GetBootConfig("php")).To(Equal(parsed.GetBootConfig("php")))
+			Expect(cfg.GetProfileConfig("javaext")).To(Equal(parsed.GetProfileConfig("javaext")))
+			Expect(cfg.GetProfileConfig("javaext").AppSpec.Port).To(BeEquivalentTo(8081))
+		})
+
+		It("Test validating the declared config", func() {
+			declared, err := DecodeGlobalConfig(strings.NewReader(text))
+			Expect(err).NotTo(HaveOccurred())
+			_, err = ValidateGlobalConfig(declared)
+			Expect(err).NotTo(HaveOccurred())
+
+			declared["javaext"].AppSpec.JVM.HeapPercentage = 120
+			_, err = ValidateGlobalConfig(declared)
+			Expect(err).To(HaveOccurred())
+
+			declared["javaext"].AppSpec.JVM.HeapPercentage = 60
+			declared["javaext"].Extends = Extends{"unknown"}
+			_, err = ValidateGlobalConfig(declared)
+			Expect(err).To(HaveOccurred())
+		})
+	})
 })
@@ -0,0 +1,142 @@
+package config
+
+import (
+	"fmt"
+	appv1 "github.com/logancloud/logan-app-operator/pkg/apis/app/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// validateOverlay returns an error if the overlay selects nothing or is invalid
+func validateOverlay(key string, selector *OverlaySelector) error {
+	if selector.NamespaceSelector == nil && len(selector.NamespaceAnnotations) == 0 {
+		return fmt.Errorf("overlay %s must select the namespaces by namespaceSelector or namespaceAnnotations", key)
+	}
+
+	if selector.NamespaceSelector != nil {
+		_, err := metav1.LabelSelectorAsSelector(selector.NamespaceSelector)
+		if err != nil {
+			return fmt.Errorf("overlay %s has invalid namespaceSelector: %s", key, err.Error())
+		}
+	}
+
+	for _, bootType := range selector.Types {
+		if appv1.GetBootTypeByConfigKey(bootType) == nil {
+			return fmt.Errorf("overlay %s has unknown boot type %s", key, bootType)
+		}
+	}
+	return nil
+}
+
+// overlayMatches returns true if the overlay is applied to the Boots of the type in the namespace
+func overlayMatches(selector *OverlaySelector, bootType string, namespace *corev1.Namespace) bool {
+	if len(selector.Types) > 0 {
+		found := false
+		for _, t := range selector.Types {
+			if t == bootType {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	for key, value := range selector.NamespaceAnnotations {
+		if actual, ok := namespace.Annotations[key]; !ok || actual != value {
+			return false
+		}
+	}
+
+	if selector.NamespaceSelector != nil {
+		nsSelector, err := metav1.LabelSelectorAsSelector(selector.NamespaceSelector)
+		if err != nil || !nsSelector.Matches(labels.Set(namespace.Labels)) {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchOverlays returns the keys of the overlays applied to the Boots of the type in the namespace, in the order
+// applied. The bootType is the type's config key.
+func (cfg *Config) MatchOverlays(bootType string, namespace *corev1.Namespace) []string {
+	if cfg == nil || namespace == nil {
+		return nil
+	}
+
+	matched := make([]string, 0)
+	for key, overlay := range cfg.OverlayConfig {
+		if overlayMatches(overlay.Overlay, bootType, namespace) {
+			matched = append(matched, key)
+		}
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		pi := cfg.OverlayConfig[matched[i]].Overlay.Priority
+		pj := cfg.OverlayConfig[matched[j]].Overlay.Priority
+		if pi != pj {
+			return pi < pj
+		}
+		return matched[i] < matched[j]
+	})
+	return matched
+}
+
+// ResolveBootConfig returns the config of the key, the boot type or the profile, with the overlays applied in order.
+// The overlays' declared config is merged onto the key's config as the profile's extends, then the defaults are
+// applied. The config returned is shared and must not be modified.
+func (cfg *Config) ResolveBootConfig(configKey string, overlays []string) (*BootConfig, error) {
+	bootCfg := cfg.GetBootConfig(configKey)
+	if bootCfg == nil {
+		bootCfg = cfg.GetProfileConfig(configKey)
+	}
+	if len(overlays) == 0 || bootCfg == nil {
+		return bootCfg, nil
+	}
+
+	cacheKey := configKey + "+" + strings.Join(overlays, "+")
+	if cfg.overlaid != nil {
+		if cached, ok := cfg.overlaid.Load(cacheKey); ok {
+			return cached.(*BootConfig), nil
+		}
+	}
+
+	merged, err := copyOperatorConfig(cfg.raw[configKey])
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range overlays {
+		overlay, ok := cfg.OverlayConfig[key]
+		if !ok {
+			return nil, fmt.Errorf("overlay %s is not found", key)
+		}
+		overlayCopy, err := copyOperatorConfig(overlay)
+		if err != nil {
+			return nil, err
+		}
+		overlayCopy.Extends = nil
+		overlayCopy.Overlay = nil
+		mergeProfileValue(reflect.ValueOf(merged).Elem(), reflect.ValueOf(overlayCopy).Elem())
+	}
+
+	baseType := cfg.baseTypes[configKey]
+	if appv1.GetBootTypeByConfigKey(configKey) != nil {
+		baseType = configKey
+	}
+	applyDefaultWithSidecar(GlobalConfig{configKey: merged}, merged, configKey, baseType)
+
+	bootCfg = &BootConfig{
+		AppSpec:           merged.AppSpec,
+		SidecarContainers: merged.SidecarContainers,
+		SidecarServices:   merged.SidecarServices,
+	}
+	if cfg.overlaid != nil {
+		cfg.overlaid.Store(cacheKey, bootCfg)
+	}
+	return bootCfg, nil
+}
@@ -0,0 +1,243 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	appv1 "github.com/logancloud/logan-app-operator/pkg/apis/app/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"reflect"
+	"strings"
+)
+
+// listKey is the key of the list's items merged when a profile extends its bases
+type listKey struct {
+	// field is the item's field as the key
+	field string
+	// merge is whether the item with the same key is merged, otherwise replaced.
+	merge bool
+}
+
+// profileListKeys are the lists merged by the key, the other lists are replaced as a whole.
+var profileListKeys = map[reflect.Type]listKey{
+	reflect.TypeOf([]corev1.Container{}):   {field: "Name", merge: true},
+	reflect.TypeOf([]corev1.EnvVar{}):      {field: "Name"},
+	reflect.TypeOf([]corev1.Volume{}):      {field: "Name"},
+	reflect.TypeOf([]corev1.VolumeMount{}): {field: "MountPath"},
+	reflect.TypeOf([]SidecarService{}):     {field: "Name"},
+}
+
+// profileAtomicTypes are the structs replaced as a whole, the structs with unexported fields are also replaced.
+var profileAtomicTypes = map[reflect.Type]bool{
+	reflect.TypeOf(intstr.IntOrString{}): true,
+}
+
+// resolveExtends resolves the profiles extending the boot types or the other profiles(mixins), before the defaults
+// are applied. The bases are merged in order, then the profile's own config:
+//   - values, pointers and structs: the declared(non-empty) values override, recursively
+//   - maps, as nodeSelector, resources and oEnvs: merged by the key
+//   - lists of env, volumes, sidecarServices: merged by the name, the item with the same name is replaced
+//   - lists of containers, as sideCarContainers and initContainers: merged by the name, the same container is merged
+//   - lists of volumeMounts: merged by the mountPath
+//   - other lists, as jvm options: replaced
+//
+// An inherited item could be overridden, but not removed. Returns the base boot type's config key for each key,
+// empty if the profile does not extend a boot type.
+func (globalCfg GlobalConfig) resolveExtends() (map[string]string, error) {
+	r := &extendsResolver{
+		globalCfg: globalCfg,
+		resolved:  make(map[string]*OperatorConfig),
+		baseTypes: make(map[string]string),
+		visiting:  make(map[string]bool),
+	}
+
+	for key := range globalCfg {
+		if _, err := r.resolve(key, nil); err != nil {
+			return nil, err
+		}
+	}
+	for key, operatorCfg := range r.resolved {
+		if _, ok := globalCfg[key]; ok {
+			globalCfg[key] = operatorCfg
+		}
+	}
+
+	return r.baseTypes, nil
+}
+
+// extendsResolver resolves the extends of the profiles, each key is resolved once.
+type extendsResolver struct {
+	globalCfg GlobalConfig
+	resolved  map[string]*OperatorConfig
+	baseTypes map[string]string
+	visiting  map[string]bool
+}
+
+// resolve returns the config of the key with its bases merged, the path is the keys extending it.
+func (r *extendsResolver) resolve(key string, path []string) (*OperatorConfig, error) {
+	if operatorCfg, ok := r.resolved[key]; ok {
+		return operatorCfg, nil
+	}
+	if r.visiting[key] {
+		return nil, fmt.Errorf("profile extends cycle: %s", strings.Join(append(path, key), " -> "))
+	}
+
+	isBootType := appv1.GetBootTypeByConfigKey(key) != nil
+	operatorCfg, ok := r.globalCfg[key]
+	if !ok && !isBootType {
+		return nil, fmt.Errorf("profile %s extends unknown key %s", path[len(path)-1], key)
+	}
+	if operatorCfg == nil {
+		// The key is not configured, use the defaults.
+		operatorCfg = &OperatorConfig{}
+	}
+
+	if isBootType {
+		if len(operatorCfg.Extends) > 0 {
+			return nil, fmt.Errorf("boot type %s can not extend, only the profiles can", key)
+		}
+		if operatorCfg.Overlay != nil {
+			return nil, fmt.Errorf("boot type %s can not be an overlay", key)
+		}
+		r.baseTypes[key] = key
+		r.resolved[key] = operatorCfg
+		return operatorCfg, nil
+	}
+
+	if len(operatorCfg.Extends) == 0 {
+		r.resolved[key] = operatorCfg
+		return operatorCfg, nil
+	}
+
+	r.visiting[key] = true
+	defer delete(r.visiting, key)
+
+	merged := &OperatorConfig{}
+	for _, base := range operatorCfg.Extends {
+		baseCfg, err := r.resolve(base, append(path, key))
+		if err != nil {
+			return nil, err
+		}
+		// Copy the base, which is also a config itself, and is defaulted separately.
+		baseCopy, err := copyOperatorConfig(baseCfg)
+		if err != nil {
+			return nil, err
+		}
+		mergeProfileValue(reflect.ValueOf(merged).Elem(), reflect.ValueOf(baseCopy).Elem())
+		if _, ok := r.baseTypes[key]; !ok && r.baseTypes[base] != "" {
+			r.baseTypes[key] = r.baseTypes[base]
+		}
+	}
+	mergeProfileValue(reflect.ValueOf(merged).Elem(), reflect.ValueOf(operatorCfg).Elem())
+	merged.Extends = operatorCfg.Extends
+	merged.Overlay = operatorCfg.Overlay
+
+	r.resolved[key] = merged
+	return merged, nil
+}
+
+// copyOperatorConfig returns a deep copy of the config
+func copyOperatorConfig(operatorCfg *OperatorConfig) (*OperatorConfig, error) {
+	data, err := json.Marshal(operatorCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	copied := &OperatorConfig{}
+	err = json.Unmarshal(data, copied)
+	if err != nil {
+		return nil, err
+	}
+	return copied, nil
+}
+
+// mergeProfileValue merges the src into the dst, the dst must be settable.
+func mergeProfileValue(dst, src reflect.Value) {
+	if isEmptyProfileValue(src) {
+		return
+	}
+
+	switch dst.Kind() {
+	case reflect.Ptr:
+		if dst.IsNil() {
+			dst.Set(src)
+			return
+		}
+		mergeProfileValue(dst.Elem(), src.Elem())
+	case reflect.Struct:
+		if profileAtomicTypes[dst.Type()] || !allFieldsExported(dst.Type()) {
+			dst.Set(src)
+			return
+		}
+		for i := 0; i < dst.NumField(); i++ {
+			mergeProfileValue(dst.Field(i), src.Field(i))
+		}
+	case reflect.Map:
+		if dst.IsNil() {
+			dst.Set(src)
+			return
+		}
+		for _, mapKey := range src.MapKeys() {
+			srcElem := src.MapIndex(mapKey)
+			dstElem := dst.MapIndex(mapKey)
+			if !dstElem.IsValid() {
+				dst.SetMapIndex(mapKey, srcElem)
+				continue
+			}
+			elem := reflect.New(dstElem.Type()).Elem()
+			elem.Set(dstElem)
+			mergeProfileValue(elem, srcElem)
+			dst.SetMapIndex(mapKey, elem)
+		}
+	case reflect.Slice:
+		key, ok := profileListKeys[dst.Type()]
+		if !ok || dst.Len() == 0 {
+			dst.Set(src)
+			return
+		}
+		merged := reflect.MakeSlice(dst.Type(), dst.Len(), dst.Len()+src.Len())
+		reflect.Copy(merged, dst)
+		for i := 0; i < src.Len(); i++ {
+			item := src.Index(i)
+			index := -1
+			for j := 0; j < merged.Len(); j++ {
+				if merged.Index(j).FieldByName(key.field).Interface() == item.FieldByName(key.field).Interface() {
+					index = j
+					break
+				}
+			}
+			switch {
+			case index < 0:
+				merged = reflect.Append(merged, item)
+			case key.merge:
+				mergeProfileValue(merged.Index(index), item)
+			default:
+				merged.Index(index).Set(item)
+			}
+		}
+		dst.Set(merged)
+	default:
+		dst.Set(src)
+	}
+}
+
+// isEmptyProfileValue returns true if the value is not declared: nil, empty or zero.
+func isEmptyProfileValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	case reflect.Map, reflect.Slice:
+		return v.Len() == 0
+	}
+	return reflect.DeepEqual(v.Interface(), reflect.Zero(v.Type()).Interface())
+}
+
+// allFieldsExported returns true if all the struct's fields are exported
+func allFieldsExported(typ reflect.Type) bool {
+	for i := 0; i < typ.NumField(); i++ {
+		if typ.Field(i).PkgPath != "" {
+			return false
+		}
+	}
+	return true
+}
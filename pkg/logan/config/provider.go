@@ -0,0 +1,41 @@
+package config
+
+import (
+	"sync/atomic"
+)
+
+// ConfigProvider provides the operator config snapshot. The snapshot returned must not be modified, a Boot is
+// handled with one snapshot, even if the config is reloaded in the meantime.
+type ConfigProvider interface {
+	// Config returns the current config snapshot.
+	Config() *Config
+}
+
+// blank assignment to verify that Provider implements ConfigProvider
+var _ ConfigProvider = &Provider{}
+
+// Provider is a ConfigProvider, whose snapshot is swapped as a whole and safe for concurrent use.
+type Provider struct {
+	value atomic.Value
+}
+
+// NewProvider returns a new Provider with the config snapshot
+func NewProvider(cfg *Config) *Provider {
+	provider := &Provider{}
+	provider.Set(cfg)
+	return provider
+}
+
+// Config returns the current config snapshot, implements ConfigProvider
+func (p *Provider) Config() *Config {
+	cfg, _ := p.value.Load().(*Config)
+	return cfg
+}
+
+// Set swaps in the config snapshot, the Boots handled after use it.
+func (p *Provider) Set(cfg *Config) {
+	if cfg == nil {
+		cfg = &Config{}
+	}
+	p.value.Store(cfg)
+}
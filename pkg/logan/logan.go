@@ -6,6 +6,7 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const (
@@ -21,6 +22,12 @@ const (
 	oMutationDefaulterKey  = "MUTATION_DEFAULTER"
 	oRevisionMaxHistoryKey = "MAX_HISTORY"
 	oBizENVKey             = "BIZ_ENVS"
+	oTimezoneKey           = "TIMEZONE"
+	oConfigReloadQPSKey    = "CONFIG_RELOAD_QPS"
+	oConfigReloadBurstKey  = "CONFIG_RELOAD_BURST"
+
+	defaultConfigReloadQPS   = float32(1)
+	defaultConfigReloadBurst = 10
 
 	// BootJava is for JavaBoot type
 	BootJava = "java"
@@ -32,6 +39,10 @@ const (
 	BootNodeJS = "nodejs"
 	// BootWeb is for WebBoot type
 	BootWeb = "web"
+	// BootGo is for GoBoot type
+	BootGo = "go"
+	// BootDotnet is for DotnetBoot type
+	BootDotnet = "dotnet"
 
 	// JavaAppKey is for JavaBoot type
 	JavaAppKey = "javaBoot"
@@ -43,6 +54,10 @@ const (
 	NodeJSAppKey = "nodejsBoot"
 	// WebAppKey is for WebBoot type
 	WebAppKey = "webBoot"
+	// GoAppKey is for GoBoot type
+	GoAppKey = "goBoot"
+	// DotnetAppKey is for DotnetBoot type
+	DotnetAppKey = "dotnetBoot"
 )
 
 // OperDev is operator's running dev
@@ -63,6 +78,15 @@ var MaxHistory int
 // BizEnvs is what ENV needs to be filtered
 var BizEnvs map[string]bool
 
+// Location is the operator's timezone, which the Boot's scaling schedules are in
+var Location *time.Location
+
+// ConfigReloadQPS is the rate of the Boots enqueued to reconcile when their config changed by reloading
+var ConfigReloadQPS float32
+
+// ConfigReloadBurst is the burst of the Boots enqueued to reconcile when their config changed by reloading
+var ConfigReloadBurst int
+
 var log = logf.Log.WithName("logan_util")
 
 func init() {
@@ -131,5 +155,47 @@ func init() {
 		}
 	}
 
+	timezone, found := os.LookupEnv(oTimezoneKey)
+	if !found || timezone == "" {
+		log.Info("TIMEZONE not set, use default", "TIMEZONE", time.Local.String())
+		Location = time.Local
+	} else {
+		loc, err := time.LoadLocation(timezone)
+		if err != nil {
+			log.Error(err, "TIMEZONE parse error, use default", "TIMEZONE", time.Local.String())
+			Location = time.Local
+		} else {
+			Location = loc
+		}
+	}
+
+	reloadQPS, found := os.LookupEnv(oConfigReloadQPSKey)
+	if !found {
+		log.Info("CONFIG_RELOAD_QPS not set, use default", "CONFIG_RELOAD_QPS", defaultConfigReloadQPS)
+		ConfigReloadQPS = defaultConfigReloadQPS
+	} else {
+		f, err := strconv.ParseFloat(reloadQPS, 32)
+		if err != nil || f <= 0 {
+			log.Error(err, "CONFIG_RELOAD_QPS parse error, use default", "CONFIG_RELOAD_QPS", defaultConfigReloadQPS)
+			ConfigReloadQPS = defaultConfigReloadQPS
+		} else {
+			ConfigReloadQPS = float32(f)
+		}
+	}
+
+	reloadBurst, found := os.LookupEnv(oConfigReloadBurstKey)
+	if !found {
+		log.Info("CONFIG_RELOAD_BURST not set, use default", "CONFIG_RELOAD_BURST", defaultConfigReloadBurst)
+		ConfigReloadBurst = defaultConfigReloadBurst
+	} else {
+		i, err := strconv.Atoi(reloadBurst)
+		if err != nil || i <= 0 {
+			log.Error(err, "CONFIG_RELOAD_BURST parse error, use default", "CONFIG_RELOAD_BURST", defaultConfigReloadBurst)
+			ConfigReloadBurst = defaultConfigReloadBurst
+		} else {
+			ConfigReloadBurst = i
+		}
+	}
+
 	MaxConcurrentReconciles = runtime.NumCPU() * 2
 }
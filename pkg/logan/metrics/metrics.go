@@ -12,9 +12,18 @@ const (
 	// RECONCILE_GET_BOOT_STAGE is main stage to get boot from store
 	RECONCILE_GET_BOOT_STAGE = "reconcile_get_boot"
 
+	// RECONCILE_RESOLVE_CONFIG_STAGE is main stage to resolve boot's config, with the namespace's overlays
+	RECONCILE_RESOLVE_CONFIG_STAGE = "reconcile_resolve_config"
+
 	// RECONCILE_UPDATE_BOOT_DEFAULTERS_STAGE is main stage to update boot with defaulters
 	RECONCILE_UPDATE_BOOT_DEFAULTERS_STAGE = "reconcile_update_boot_defaulters"
 
+	// RECONCILE_ROLLBACK_BOOT_STAGE is main stage to roll back boot to a revision
+	RECONCILE_ROLLBACK_BOOT_STAGE = "reconcile_rollback_boot"
+
+	// RECONCILE_SUSPEND_BOOT_STAGE is main stage to suspend or resume boot
+	RECONCILE_SUSPEND_BOOT_STAGE = "reconcile_suspend_boot"
+
 	// RECONCILE_CREATE_STAGE is main stage to create deployment, service, etc.
 	RECONCILE_CREATE_STAGE = "reconcile_create"
 
@@ -24,6 +33,9 @@ const (
 	// RECONCILE_UPDATE_BOOT_META_STAGE is main stage to update boot's metadata.
 	RECONCILE_UPDATE_BOOT_META_STAGE = "reconcile_update_boot_meta"
 
+	// RECONCILE_UPDATE_BOOT_STATUS_STAGE is main stage to update boot's status.
+	RECONCILE_UPDATE_BOOT_STATUS_STAGE = "reconcile_update_boot_status"
+
 	// Following stages are sub stages
 
 	// RECONCILE_CREATE_DEPLOYMENT_SUBSTAGE is sub stage to create deployment.
@@ -35,6 +47,60 @@ const (
 	// RECONCILE_UPDATE_DEPLOYMENT_SUBSTAGE is sub stage to update deployment.
 	RECONCILE_UPDATE_DEPLOYMENT_SUBSTAGE = "update_deployment"
 
+	// RECONCILE_CREATE_STATEFULSET_SUBSTAGE is sub stage to create statefulset.
+	RECONCILE_CREATE_STATEFULSET_SUBSTAGE = "create_statefulset"
+
+	// RECONCILE_GET_STATEFULSET_SUBSTAGE is sub stage to get statefulset.
+	RECONCILE_GET_STATEFULSET_SUBSTAGE = "get_statefulset"
+
+	// RECONCILE_UPDATE_STATEFULSET_SUBSTAGE is sub stage to update statefulset.
+	RECONCILE_UPDATE_STATEFULSET_SUBSTAGE = "update_statefulset"
+
+	// RECONCILE_LIST_STATEFULSETS_SUBSTAGE is sub stage to list statefulsets.
+	RECONCILE_LIST_STATEFULSETS_SUBSTAGE = "list_statefulsets"
+
+	// RECONCILE_CREATE_JOB_SUBSTAGE is sub stage to create job or cronjob.
+	RECONCILE_CREATE_JOB_SUBSTAGE = "create_job"
+
+	// RECONCILE_GET_JOB_SUBSTAGE is sub stage to get job or cronjob.
+	RECONCILE_GET_JOB_SUBSTAGE = "get_job"
+
+	// RECONCILE_UPDATE_JOB_SUBSTAGE is sub stage to update job or cronjob.
+	RECONCILE_UPDATE_JOB_SUBSTAGE = "update_job"
+
+	// RECONCILE_DELETE_JOB_SUBSTAGE is sub stage to delete job.
+	RECONCILE_DELETE_JOB_SUBSTAGE = "delete_job"
+
+	// RECONCILE_LIST_JOBS_SUBSTAGE is sub stage to list jobs.
+	RECONCILE_LIST_JOBS_SUBSTAGE = "list_jobs"
+
+	// RECONCILE_CREATE_CANARY_SUBSTAGE is sub stage to create canary deployment.
+	RECONCILE_CREATE_CANARY_SUBSTAGE = "create_canary"
+
+	// RECONCILE_GET_CANARY_SUBSTAGE is sub stage to get canary deployment.
+	RECONCILE_GET_CANARY_SUBSTAGE = "get_canary"
+
+	// RECONCILE_UPDATE_CANARY_SUBSTAGE is sub stage to update canary deployment.
+	RECONCILE_UPDATE_CANARY_SUBSTAGE = "update_canary"
+
+	// RECONCILE_DELETE_CANARY_SUBSTAGE is sub stage to delete canary deployment.
+	RECONCILE_DELETE_CANARY_SUBSTAGE = "delete_canary"
+
+	// RECONCILE_LIST_DEPLOYMENTS_SUBSTAGE is sub stage to list deployments.
+	RECONCILE_LIST_DEPLOYMENTS_SUBSTAGE = "list_deployments"
+
+	// RECONCILE_CREATE_COLOR_SUBSTAGE is sub stage to create blue-green color deployment.
+	RECONCILE_CREATE_COLOR_SUBSTAGE = "create_color"
+
+	// RECONCILE_GET_COLOR_SUBSTAGE is sub stage to get blue-green color deployment.
+	RECONCILE_GET_COLOR_SUBSTAGE = "get_color"
+
+	// RECONCILE_UPDATE_COLOR_SUBSTAGE is sub stage to update blue-green color deployment.
+	RECONCILE_UPDATE_COLOR_SUBSTAGE = "update_color"
+
+	// RECONCILE_SWITCH_COLOR_SUBSTAGE is sub stage to switch the services to the other color.
+	RECONCILE_SWITCH_COLOR_SUBSTAGE = "switch_color"
+
 	// RECONCILE_CREATE_SERVICE_SUBSTAGE is sub stage to create service.
 	RECONCILE_CREATE_SERVICE_SUBSTAGE = "create_service"
 
@@ -56,11 +122,41 @@ const (
 	// RECONCILE_DELETE_OTHER_SERVICE_SUBSTAGE is sub stage to delete other service.
 	RECONCILE_DELETE_OTHER_SERVICE_SUBSTAGE = "delete_other_service"
 
+	// RECONCILE_CREATE_HPA_SUBSTAGE is sub stage to create hpa.
+	RECONCILE_CREATE_HPA_SUBSTAGE = "create_hpa"
+
+	// RECONCILE_GET_HPA_SUBSTAGE is sub stage to get hpa.
+	RECONCILE_GET_HPA_SUBSTAGE = "get_hpa"
+
+	// RECONCILE_UPDATE_HPA_SUBSTAGE is sub stage to update hpa.
+	RECONCILE_UPDATE_HPA_SUBSTAGE = "update_hpa"
+
+	// RECONCILE_DELETE_HPA_SUBSTAGE is sub stage to delete hpa.
+	RECONCILE_DELETE_HPA_SUBSTAGE = "delete_hpa"
+
+	// RECONCILE_CREATE_INGRESS_SUBSTAGE is sub stage to create ingress.
+	RECONCILE_CREATE_INGRESS_SUBSTAGE = "create_ingress"
+
+	// RECONCILE_GET_INGRESS_SUBSTAGE is sub stage to get ingress.
+	RECONCILE_GET_INGRESS_SUBSTAGE = "get_ingress"
+
+	// RECONCILE_UPDATE_INGRESS_SUBSTAGE is sub stage to update ingress.
+	RECONCILE_UPDATE_INGRESS_SUBSTAGE = "update_ingress"
+
+	// RECONCILE_DELETE_INGRESS_SUBSTAGE is sub stage to delete ingress.
+	RECONCILE_DELETE_INGRESS_SUBSTAGE = "delete_ingress"
+
 	// RECONCILE_LIST_PODS_SUBSTAGE is sub stage to list pods.
 	RECONCILE_LIST_PODS_SUBSTAGE = "list_pods"
 
 	// RECONCILE_UPDATE_BOOT_META_SUBSTAGE is sub stage to update boot metadata.
 	RECONCILE_UPDATE_BOOT_META_SUBSTAGE = "update_boot_meta"
+
+	// RECONCILE_UPDATE_BOOT_STATUS_SUBSTAGE is sub stage to update boot status.
+	RECONCILE_UPDATE_BOOT_STATUS_SUBSTAGE = "update_boot_status"
+
+	// RECONCILE_RESTART_BOOT_SUBSTAGE is sub stage to restart boot by the restart schedule.
+	RECONCILE_RESTART_BOOT_SUBSTAGE = "restart_boot"
 )
 
 var (
@@ -77,12 +173,51 @@ var (
 		Name: "logan_controller_runtime_reconcile_time_seconds",
 		Help: "Length of time per logan reconciliation per controller",
 	}, []string{"kind"})
+
+	// AutoRollbacks is a prometheus counter metrics which holds the total
+	// number of automatic rollbacks when the Boot's rollout failed
+	AutoRollbacks = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "logan_boot_auto_rollbacks_total",
+		Help: "Total number of automatic rollbacks per boot",
+	}, []string{"kind", "boot", "reason"})
+
+	// ConfigReloads is a prometheus counter metrics which holds the total
+	// number of the operator config's reloads, by result: success or failure
+	ConfigReloads = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "logan_operator_config_reloads_total",
+		Help: "Total number of operator config reloads per result",
+	}, []string{"result"})
+
+	// ConfigReloadSuccessful is a prometheus gauge metrics which holds whether
+	// the last operator config's reload is successful, 1 is successful and 0 is failed
+	ConfigReloadSuccessful = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "logan_operator_config_last_reload_successful",
+		Help: "Whether the last operator config reload is successful",
+	})
+
+	// ConfigReloadBoots is a prometheus counter metrics which holds the total
+	// number of the Boots enqueued to reconcile because their config changed by reloading
+	ConfigReloadBoots = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "logan_operator_config_reload_boots_total",
+		Help: "Total number of boots enqueued by operator config reloads per kind",
+	}, []string{"kind"})
+)
+
+const (
+	// CONFIG_RELOAD_SUCCESS is the result of the successful config reload
+	CONFIG_RELOAD_SUCCESS = "success"
+	// CONFIG_RELOAD_FAILURE is the result of the failed config reload, the last good config is kept
+	CONFIG_RELOAD_FAILURE = "failure"
 )
 
 func init() {
 	metrics.Registry.MustRegister(
 		ReconcileErrors,
 		ReconcileTime,
+		AutoRollbacks,
+		ConfigReloads,
+		ConfigReloadSuccessful,
+		ConfigReloadBoots,
 	)
 }
 
@@ -100,3 +235,23 @@ func UpdateReconcileErrors(kind string, stage string, subStage string, boot stri
 func UpdateMainStageErrors(kind string, stage string, boot string) {
 	ReconcileErrors.WithLabelValues(kind, stage, "", boot).Inc()
 }
+
+// UpdateAutoRollbacks will update automatic rollback metrics when the Boot's rollout failed
+func UpdateAutoRollbacks(kind string, boot string, reason string) {
+	AutoRollbacks.WithLabelValues(kind, boot, reason).Inc()
+}
+
+// UpdateConfigReloads will update the config reload metrics with the result
+func UpdateConfigReloads(result string) {
+	ConfigReloads.WithLabelValues(result).Inc()
+	if result == CONFIG_RELOAD_SUCCESS {
+		ConfigReloadSuccessful.Set(1)
+	} else {
+		ConfigReloadSuccessful.Set(0)
+	}
+}
+
+// UpdateConfigReloadBoots will update the number of Boots enqueued by the config reload
+func UpdateConfigReloadBoots(kind string) {
+	ConfigReloadBoots.WithLabelValues(kind).Inc()
+}
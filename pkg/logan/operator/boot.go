@@ -21,6 +21,9 @@ import (
 const (
 	// HttpPortName is the Boot's created service port name
 	HttpPortName = "http"
+
+	metricsPortName    = "metrics"
+	managementPortName = "management"
 )
 
 // DeployLabels return labels for the created Deploy
@@ -36,13 +39,100 @@ func DeployName(boot *appv1.Boot) string {
 
 // AppContainerHealthPort return the health port for the created Pod's app container
 func AppContainerHealthPort(boot *appv1.Boot, appSpec *config.AppSpec) intstr.IntOrString {
-	healthPort := int32(boot.Spec.Port)
+	healthPort := PrimaryPort(boot).ContainerPort
 	if appSpec.Settings.AppHealthPort > 0 {
 		healthPort = appSpec.Settings.AppHealthPort
 	}
 	return intstr.IntOrString{Type: intstr.Int, IntVal: int32(healthPort)}
 }
 
+// BootPorts return the Boot's ports with default values.
+// If Ports is empty, a single "http" port is returned from the Port shorthand.
+func BootPorts(boot *appv1.Boot) []appv1.BootPort {
+	if len(boot.Spec.Ports) == 0 {
+		return []appv1.BootPort{{
+			Name:          HttpPortName,
+			ContainerPort: boot.Spec.Port,
+			ServicePort:   boot.Spec.Port,
+			Protocol:      corev1.ProtocolTCP,
+		}}
+	}
+
+	ports := make([]appv1.BootPort, 0, len(boot.Spec.Ports))
+	for _, port := range boot.Spec.Ports {
+		if port.ServicePort <= 0 {
+			port.ServicePort = port.ContainerPort
+		}
+		if port.Protocol == "" {
+			port.Protocol = corev1.ProtocolTCP
+		}
+		ports = append(ports, port)
+	}
+	return ports
+}
+
+// PrimaryPort return the Boot's primary port, which is the port named "http", or the first port if not found
+func PrimaryPort(boot *appv1.Boot) appv1.BootPort {
+	ports := BootPorts(boot)
+	for _, port := range ports {
+		if port.Name == HttpPortName {
+			return port
+		}
+	}
+	return ports[0]
+}
+
+// PrometheusPort return the container port scraped by prometheus,
+// which is the port named "metrics" or "management", or the primary port if not found
+func PrometheusPort(boot *appv1.Boot) int32 {
+	for _, name := range []string{metricsPortName, managementPortName} {
+		for _, port := range BootPorts(boot) {
+			if port.Name == name {
+				return port.ContainerPort
+			}
+		}
+	}
+	return PrimaryPort(boot).ContainerPort
+}
+
+// AppContainerPorts return the ports for the created Pod's app container
+func AppContainerPorts(boot *appv1.Boot) []corev1.ContainerPort {
+	ports := make([]corev1.ContainerPort, 0)
+	for _, port := range BootPorts(boot) {
+		ports = append(ports, corev1.ContainerPort{
+			Name:          port.Name,
+			ContainerPort: port.ContainerPort,
+			Protocol:      port.Protocol,
+		})
+	}
+	return ports
+}
+
+// AppServicePorts return the ports for the created app Service
+func AppServicePorts(boot *appv1.Boot) []corev1.ServicePort {
+	ports := make([]corev1.ServicePort, 0)
+	for _, port := range BootPorts(boot) {
+		ports = append(ports, corev1.ServicePort{
+			Name:       port.Name,
+			Port:       port.ServicePort,
+			TargetPort: intstr.IntOrString{Type: intstr.Int, IntVal: port.ContainerPort},
+			Protocol:   port.Protocol,
+		})
+	}
+	return ports
+}
+
+// AppProtocols return the app protocols of the Boot's ports, as "grpc=grpc,http=http". Return empty if not set.
+func AppProtocols(boot *appv1.Boot) string {
+	var protocols []string
+	for _, port := range BootPorts(boot) {
+		if port.AppProtocol != "" {
+			protocols = append(protocols, port.Name+"="+port.AppProtocol)
+		}
+	}
+	return strings.Join(protocols, ",")
+}
+
 // AppContainerImageName return image name for the created Pod's app container
 func AppContainerImageName(boot *appv1.Boot, appSpec *config.AppSpec) string {
 	registry := appSpec.Settings.Registry
@@ -95,6 +185,51 @@ func ServiceAnnotation(prometheusScrape bool, port int) map[string]string {
 	return nil
 }
 
+// managedServiceAnnotationKeys is the app Service's annotation keys managed by the Boot
+var managedServiceAnnotationKeys = []string{
+	keys.PrometheusPathAnnotationKey,
+	keys.PrometheusPortAnnotationKey,
+	keys.PrometheusSchemeAnnotationKey,
+	keys.PrometheusScrapeAnnotationKey,
+	keys.AppProtocolsAnnotationKey,
+}
+
+// servicePortsEqual return true if the service ports have the same name, port, targetPort and protocol.
+// NodePort is ignored, which is allocated by kubernetes.
+func servicePortsEqual(svcPorts, bootPorts []corev1.ServicePort) bool {
+	if len(svcPorts) != len(bootPorts) {
+		return false
+	}
+
+	for i, svcPort := range svcPorts {
+		bootPort := bootPorts[i]
+		svcProtocol := svcPort.Protocol
+		if svcProtocol == "" {
+			svcProtocol = corev1.ProtocolTCP
+		}
+
+		if svcPort.Name != bootPort.Name || svcPort.Port != bootPort.Port ||
+			!reflect.DeepEqual(svcPort.TargetPort, bootPort.TargetPort) || svcProtocol != bootPort.Protocol {
+			return false
+		}
+	}
+
+	return true
+}
+
+// AppServiceAnnotation return the annotations for the created app Service, with the prometheus and app protocols annotations
+func AppServiceAnnotation(boot *appv1.Boot, prometheusScrape bool) map[string]string {
+	annotations := ServiceAnnotation(prometheusScrape, int(PrometheusPort(boot)))
+	appProtocols := AppProtocols(boot)
+	if appProtocols != "" {
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[keys.AppProtocolsAnnotationKey] = appProtocols
+	}
+	return annotations
+}
+
 // TransferServiceNames transfer the services list of []Service to string, split by ,
 func TransferServiceNames(services []corev1.Service) string {
 	var serviceNames []string
@@ -300,21 +435,14 @@ func VolumeMountVarsEq(vol1, vol2 []corev1.VolumeMount) bool {
 	return true
 }
 
-// GetConfigSpec returns the config.AppSpec for the Boot.
-func GetConfigSpec(boot *appv1.Boot) *config.AppSpec {
-	if boot.BootType == logan.BootJava {
-		return config.JavaConfig.AppSpec
-	} else if boot.BootType == logan.BootPhp {
-		return config.PhpConfig.AppSpec
-	} else if boot.BootType == logan.BootPython {
-		return config.PythonConfig.AppSpec
-	} else if boot.BootType == logan.BootNodeJS {
-		return config.NodeJSConfig.AppSpec
-	} else if boot.BootType == logan.BootWeb {
-		return config.WebConfig.AppSpec
+// GetConfigSpec returns the config.AppSpec for the Boot in the config snapshot.
+func GetConfigSpec(boot *appv1.Boot, snapshot *config.Config) *config.AppSpec {
+	bootCfg := snapshot.GetBootConfig(boot.BootType)
+	if bootCfg == nil {
+		return nil
 	}
 
-	return nil
+	return bootCfg.AppSpec
 }
 
 // DecodeAnnotationEnvs decodes the annotation's env
@@ -372,15 +500,15 @@ func ConvertVolume(pvcs []appv1.PersistentVolumeClaimMount) []corev1.Volume {
 	return vols
 }
 
-// GetProfileBootConfig gets the Boot's config by profile annotation
-func GetProfileBootConfig(boot *appv1.Boot, logger logr.Logger) (*config.BootConfig, error) {
+// GetProfileBootConfig gets the Boot's config by profile annotation in the config snapshot
+func GetProfileBootConfig(boot *appv1.Boot, snapshot *config.Config, logger logr.Logger) (*config.BootConfig, error) {
 	if boot.Annotations != nil {
 		if _, exist := boot.Annotations[config.BootProfileAnnotationKey]; exist {
 			bootProfile := boot.Annotations[config.BootProfileAnnotationKey]
-			if bootProfile == logan.BootJava || bootProfile == logan.BootPhp || bootProfile == logan.BootPython || bootProfile == logan.BootNodeJS || bootProfile == logan.BootWeb {
+			if appv1.GetBootTypeByConfigKey(bootProfile) != nil {
 				return nil, fmt.Errorf("boot using profile, but profile [%s] is not allow", bootProfile)
 			}
-			profileConfig := config.ProfileConfig[bootProfile]
+			profileConfig := snapshot.GetProfileConfig(bootProfile)
 			if profileConfig != nil {
 				logger.Info("Boot using profile: ", "profile", bootProfile)
 				return profileConfig, nil
@@ -31,12 +31,22 @@ const (
 
 // BootHandler is the core struct for handling logic for all boots.
 type BootHandler struct {
-	OperatorBoot metav1.Object
-	OperatorSpec *appv1.BootSpec
-	OperatorMeta *metav1.ObjectMeta
-
-	Boot   *appv1.Boot
-	Config *config.BootConfig
+	OperatorBoot   metav1.Object
+	OperatorSpec   *appv1.BootSpec
+	OperatorMeta   *metav1.ObjectMeta
+	OperatorStatus *appv1.BootStatus
+
+	Boot *appv1.Boot
+	// Snapshot is the operator config snapshot, which the Boot is handled with.
+	Snapshot *config.Config
+	Config   *config.BootConfig
+	// ConfigError is the error when resolving the Boot's config(profile), the type's default config is used instead.
+	ConfigError error
+	// NamespaceError is the error when getting the Boot's namespace for the overlays, the Boot is not reconciled
+	// with the config without overlays, and is requeued instead.
+	NamespaceError error
+	// Resolution is how the Boot's config is resolved: the boot type, the profile and the namespace's overlays.
+	Resolution string
 
 	Scheme   *runtime.Scheme
 	Client   util.K8SClient
@@ -44,6 +54,55 @@ type BootHandler struct {
 	Recorder record.EventRecorder
 }
 
+// InitHandler will create the Handler for handling logic of Boot, with the config of the Boot's type or profile,
+// and the overlays of the Boot's namespace, from the provider's current snapshot.
+func InitHandler(bootObj appv1.BootObject, provider config.ConfigProvider, scheme *runtime.Scheme,
+	client util.K8SClient, logger logr.Logger, recorder record.EventRecorder) (handler *BootHandler) {
+	boot := bootObj.DeepCopyBoot()
+
+	snapshot := provider.Config()
+	configKey := boot.BootType
+	profile := ""
+	profileConfig, err := GetProfileBootConfig(boot, snapshot, logger)
+	if err != nil {
+		logger.Info(err.Error())
+	} else if profileConfig != nil {
+		profile = boot.Annotations[config.BootProfileAnnotationKey]
+		configKey = profile
+	}
+
+	namespace, namespaceErr := OverlayNamespace(boot, snapshot, client)
+	if namespaceErr != nil {
+		logger.Info(namespaceErr.Error())
+	}
+
+	bootCfg, overlays, overlayErr := ResolveBootConfig(boot, snapshot, configKey, namespace)
+	if overlayErr != nil {
+		logger.Info(overlayErr.Error())
+		if err == nil {
+			err = overlayErr
+		}
+	}
+
+	return &BootHandler{
+		OperatorBoot:   bootObj,
+		OperatorSpec:   bootObj.GetBootSpec(),
+		OperatorMeta:   bootObj.GetBootMeta(),
+		OperatorStatus: bootObj.GetBootStatus(),
+
+		Boot:           boot,
+		Snapshot:       snapshot,
+		Config:         bootCfg,
+		ConfigError:    err,
+		NamespaceError: namespaceErr,
+		Resolution:     ConfigResolution(boot.BootType, profile, overlays),
+		Scheme:         scheme,
+		Client:         client,
+		Logger:         logger,
+		Recorder:       recorder,
+	}
+}
+
 // UpdateAnnotation handle the logic for annotation value, return true if updated
 func (handler *BootHandler) UpdateAnnotation(annotationMap map[string]string) bool {
 	metaData := handler.OperatorMeta
@@ -76,7 +135,6 @@ func (handler *BootHandler) NewDeployment() *appsv1.Deployment {
 	boot := handler.Boot
 	bootCfg := handler.Config
 
-	revisionHistoryLimits := int32(defaultRevisionHistoryLimits)
 	podLabels := PodLabels(boot)
 	deployLabels := DeployLabels(boot)
 
@@ -113,8 +171,7 @@ func (handler *BootHandler) NewDeployment() *appsv1.Deployment {
 			Labels:    deployLabels,
 		},
 		Spec: appsv1.DeploymentSpec{
-			Replicas:             boot.Spec.Replicas,
-			RevisionHistoryLimit: &revisionHistoryLimits,
+			Replicas: DeployReplicas(boot),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: podLabels,
 			},
@@ -124,45 +181,19 @@ func (handler *BootHandler) NewDeployment() *appsv1.Deployment {
 					Annotations: annotations,
 				},
 				Spec: corev1.PodSpec{
-					Affinity: &corev1.Affinity{
-						PodAntiAffinity: &corev1.PodAntiAffinity{
-							PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
-								{
-									Weight: defaultWeight,
-									PodAffinityTerm: corev1.PodAffinityTerm{
-										LabelSelector: &metav1.LabelSelector{
-											MatchExpressions: []metav1.LabelSelectorRequirement{
-												{
-													Key:      keys.BootNameKey,
-													Operator: metav1.LabelSelectorOpIn,
-													Values:   []string{boot.Name},
-												},
-											},
-										},
-										TopologyKey: "kubernetes.io/hostname",
-									},
-								},
-							},
-						},
-					},
-					Containers:   containers,
-					NodeSelector: boot.Spec.NodeSelector,
+					Affinity:          NewAffinity(boot),
+					Tolerations:       boot.Spec.Tolerations,
+					PriorityClassName: boot.Spec.PriorityClassName,
+					RuntimeClassName:  boot.Spec.RuntimeClassName,
+					Containers:        containers,
+					NodeSelector:      boot.Spec.NodeSelector,
 				},
 			},
-			Strategy: appsv1.DeploymentStrategy{},
 		},
 	}
 
-	// Avoid when boot has more than 4 pods, more than one pod will be RollingUpdate.
-	if boot.BootType == logan.BootJava {
-		maxUnavailable := intstr.FromString("1%")
-		dep.Spec.Strategy = appsv1.DeploymentStrategy{
-			Type: appsv1.RollingUpdateDeploymentStrategyType,
-			RollingUpdate: &appsv1.RollingUpdateDeployment{
-				MaxUnavailable: &maxUnavailable,
-			},
-		}
-	}
+	// Strategy: type, maxSurge, maxUnavailable, minReadySeconds, progressDeadlineSeconds, revisionHistoryLimit
+	SetDeploymentStrategy(&dep.Spec, BootStrategy(boot, bootCfg.AppSpec))
 
 	podSpec := bootCfg.AppSpec.PodSpec
 	if podSpec != nil {
@@ -208,23 +239,18 @@ func (handler *BootHandler) NewAppContainer() *corev1.Container {
 	imageName := AppContainerImageName(boot, handler.Config.AppSpec)
 
 	appContainer := corev1.Container{
-		Image: imageName,
-		Name:  defaultAppName,
-		Ports: []corev1.ContainerPort{{
-			ContainerPort: boot.Spec.Port,
-			Name:          HttpPortName,
-		}},
-		Env:             boot.Spec.Env,
+		Image:           imageName,
+		Name:            defaultAppName,
+		Ports:           AppContainerPorts(boot),
+		Env:             AppContainerEnv(boot, handler.Config.AppSpec),
 		ImagePullPolicy: defaultImagePullPolicy,
 		Resources:       boot.Spec.Resources,
 	}
 
-	// If Spec's health is empty string, disable the health check and readiness.
-	if boot.Spec.Health != nil && *boot.Spec.Health != "" {
-		liveness, readiness := handler.GetHealthProbe()
-		appContainer.LivenessProbe = liveness
-		appContainer.ReadinessProbe = readiness
-	}
+	// If Spec's health is empty string, disable the http health check and readiness.
+	liveness, readiness := handler.GetHealthProbe()
+	appContainer.LivenessProbe = liveness
+	appContainer.ReadinessProbe = readiness
 
 	if boot.Spec.Command != nil && len(boot.Spec.Command) > 0 {
 		appContainer.Command = boot.Spec.Command
@@ -232,7 +258,8 @@ func (handler *BootHandler) NewAppContainer() *corev1.Container {
 
 	specContainer := handler.Config.AppSpec.Container
 	if specContainer != nil {
-		err := util.MergeOverride(&appContainer, *specContainer)
+		// Copy the config's container, the config snapshot is shared and must not be modified.
+		err := util.MergeOverride(&appContainer, *specContainer.DeepCopy())
 		if err != nil {
 			handler.Logger.Error(err, "Merge error.", "type", "container")
 		}
@@ -253,68 +280,29 @@ func (handler *BootHandler) NewAppContainer() *corev1.Container {
 	return &appContainer
 }
 
-// GetHealthProbe return the livenessProbe and readinessProbe for the created container
-func (handler *BootHandler) GetHealthProbe() (*corev1.Probe, *corev1.Probe) {
-	boot := handler.Boot
-	healthPort := AppContainerHealthPort(boot, handler.Config.AppSpec)
-
-	// havok issue #95
-	failureThreshold := int32(10)
-	if boot.BootType == logan.BootPython {
-		failureThreshold = int32(15)
-	}
-
-	livenessProbe := &corev1.Probe{
-		FailureThreshold: failureThreshold,
-		Handler: corev1.Handler{
-			HTTPGet: &corev1.HTTPGetAction{
-				Path:   *boot.Spec.Health,
-				Port:   healthPort,
-				Scheme: corev1.URISchemeHTTP,
-			},
-		},
-		InitialDelaySeconds: 120,
-		PeriodSeconds:       10,
-		SuccessThreshold:    1,
-		TimeoutSeconds:      5,
-	}
-
-	readinessPath := *boot.Spec.Health
-	if boot.Spec.Readiness != nil && *boot.Spec.Readiness != "" {
-		readinessPath = *boot.Spec.Readiness
-	}
-
-	readinessProbe := &corev1.Probe{
-		FailureThreshold: failureThreshold,
-		Handler: corev1.Handler{
-			HTTPGet: &corev1.HTTPGetAction{
-				Path:   readinessPath,
-				Port:   healthPort,
-				Scheme: corev1.URISchemeHTTP,
-			},
-		},
-		InitialDelaySeconds: 60,
-		PeriodSeconds:       10,
-		SuccessThreshold:    1,
-		TimeoutSeconds:      5,
-	}
-
-	return livenessProbe, readinessProbe
-}
-
 // NewServices returns a new created Service instance
 func (handler *BootHandler) NewServices(dep *appsv1.Deployment) []*corev1.Service {
 	boot := handler.Boot
 	//bootCfg := handler.Config
 	// app Service
 	prometheusScrape := allowPrometheusScrape(boot, handler.Config.AppSpec)
-	bootSvc := handler.createService(int(boot.Spec.Port), boot.Name, prometheusScrape, corev1.ServiceTypeClusterIP)
+	selector := PodLabels(boot)
+	if BlueGreenEnabled(boot, handler.Config.AppSpec) {
+		selector = ServiceSelector(boot, dep)
+	}
+	bootSvc := handler.createService(AppServicePorts(boot), boot.Name,
+		AppServiceAnnotation(boot, prometheusScrape), corev1.ServiceTypeClusterIP, selector)
 	allSvcs := []*corev1.Service{bootSvc}
 
 	// only dev environment and nodePort true, create nodePort service
 	if handler.Boot.Spec.NodePort == "true" && logan.OperDev == "dev" {
 		svcName := NodePortServiceName(boot)
-		allSvcs = append(allSvcs, handler.createService(int(boot.Spec.Port), svcName, false, corev1.ServiceTypeNodePort))
+		allSvcs = append(allSvcs, handler.createService(AppServicePorts(boot), svcName, nil, corev1.ServiceTypeNodePort, selector))
+	}
+
+	// headless Service, the StatefulSet's serviceName
+	if StatefulSetEnabled(boot) {
+		allSvcs = append(allSvcs, handler.NewHeadlessService())
 	}
 
 	// additional sidecar Service
@@ -324,7 +312,13 @@ func (handler *BootHandler) NewServices(dep *appsv1.Deployment) []*corev1.Servic
 			if sidecarContainer.Ports != nil {
 				for _, port := range sidecarContainer.Ports {
 					svcName := SideCarServiceName(boot, port)
-					allSvcs = append(allSvcs, handler.createService(int(port.ContainerPort), svcName, true, corev1.ServiceTypeClusterIP))
+					svcPorts := []corev1.ServicePort{{
+						Name:       HttpPortName,
+						Port:       port.ContainerPort,
+						TargetPort: intstr.IntOrString{Type: intstr.Int, IntVal: port.ContainerPort},
+					}}
+					allSvcs = append(allSvcs, handler.createService(svcPorts, svcName,
+						ServiceAnnotation(true, int(port.ContainerPort)), corev1.ServiceTypeClusterIP, selector))
 				}
 			}
 		}
@@ -333,8 +327,9 @@ func (handler *BootHandler) NewServices(dep *appsv1.Deployment) []*corev1.Servic
 	return allSvcs
 }
 
-// createService returns a new created Service instance
-func (handler *BootHandler) createService(port int, name string, prometheusScrape bool, serviceType corev1.ServiceType) *corev1.Service {
+// createService returns a new created Service instance, selecting the pods by the selector
+func (handler *BootHandler) createService(ports []corev1.ServicePort, name string, annotations map[string]string,
+	serviceType corev1.ServiceType, selector map[string]string) *corev1.Service {
 	boot := handler.Boot
 
 	svc := &corev1.Service{
@@ -346,19 +341,13 @@ func (handler *BootHandler) createService(port int, name string, prometheusScrap
 			Name:        name,
 			Namespace:   boot.Namespace,
 			Labels:      ServiceLabels(boot),
-			Annotations: ServiceAnnotation(prometheusScrape, port),
+			Annotations: annotations,
 		},
 	}
 
 	serviceSpec := corev1.ServiceSpec{
-		Ports: []corev1.ServicePort{
-			{
-				Name:       HttpPortName,
-				Port:       int32(port),
-				TargetPort: intstr.IntOrString{Type: intstr.Int, IntVal: int32(port)},
-			},
-		},
-		Selector: PodLabels(boot),
+		Ports:    ports,
+		Selector: selector,
 		Type:     serviceType,
 	}
 
@@ -383,7 +372,8 @@ func getEventType(reason string, err error) string {
 
 	// following failed type can auto fix by reconcile loop
 	if reason == keys.FailedUpdateBootDefaulters || reason == keys.FailedUpdateBootMeta ||
-		reason == keys.FailedGetDeployment || reason == keys.FailedGetService {
+		reason == keys.FailedGetDeployment || reason == keys.FailedGetStatefulSet || reason == keys.FailedGetService ||
+		reason == keys.FailedGetHorizontalPodAutoscaler || reason == keys.FailedGetIngress {
 		return eventTypeNormal
 	}
 
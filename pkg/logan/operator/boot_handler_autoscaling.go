@@ -0,0 +1,304 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	appv1 "github.com/logancloud/logan-app-operator/pkg/apis/app/v1"
+	loganMetrics "github.com/logancloud/logan-app-operator/pkg/logan/metrics"
+	"github.com/logancloud/logan-app-operator/pkg/logan/util/keys"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	defaultAutoscalingMinReplicas = 1
+	// defaultTargetCPUUtilization is the same as HorizontalPodAutoscaler's default, set explicitly to avoid drift.
+	defaultTargetCPUUtilization = 80
+)
+
+// AutoscalingEnabled return true if the Boot's replicas is managed by HorizontalPodAutoscaler
+func AutoscalingEnabled(boot *appv1.Boot) bool {
+	autoscaling := boot.Spec.Autoscaling
+	if autoscaling == nil || autoscaling.MaxReplicas <= 0 {
+		return false
+	}
+
+	return autoscaling.Enabled == nil || *autoscaling.Enabled
+}
+
+// HorizontalPodAutoscalerName return name for the created HorizontalPodAutoscaler
+func HorizontalPodAutoscalerName(boot *appv1.Boot) string {
+	return boot.Name
+}
+
+// autoscalingMinReplicas return the lower limit of the replicas, default is 1
+func autoscalingMinReplicas(autoscaling *appv1.BootAutoscaling) int32 {
+	if autoscaling.MinReplicas != nil && *autoscaling.MinReplicas > 0 {
+		return *autoscaling.MinReplicas
+	}
+	return defaultAutoscalingMinReplicas
+}
+
+// hpaMinReplicas return the HorizontalPodAutoscaler's lower limit,
+// raised by the active scaling schedule's replicas, but no more than maxReplicas.
+func hpaMinReplicas(boot *appv1.Boot) int32 {
+	autoscaling := boot.Spec.Autoscaling
+	minReplicas := autoscalingMinReplicas(autoscaling)
+	if scheduled, found := ScheduledReplicas(boot); found && scheduled > minReplicas {
+		minReplicas = scheduled
+		if minReplicas > autoscaling.MaxReplicas {
+			minReplicas = autoscaling.MaxReplicas
+		}
+	}
+	return minReplicas
+}
+
+// DeployReplicas return the replicas for the created Deployment, the active scaling schedule's replicas overrides the Boot's.
+// If autoscaling is enabled, the Boot's replicas is scaled by HorizontalPodAutoscaler through the Boot's scale subresource,
+// the schedule only raises the lower limit, and the replicas is limited to [minReplicas, maxReplicas],
+// unless scaled to zero by the schedule.
+func DeployReplicas(boot *appv1.Boot) *int32 {
+	replicas := boot.Spec.Replicas
+	scheduled, found := ScheduledReplicas(boot)
+	if found && (!AutoscalingEnabled(boot) || scheduled == 0) {
+		replicas = &scheduled
+	}
+
+	if !AutoscalingEnabled(boot) || replicas == nil || (found && scheduled == 0) {
+		return replicas
+	}
+
+	autoscaling := boot.Spec.Autoscaling
+	limited := *replicas
+	if minReplicas := hpaMinReplicas(boot); limited < minReplicas {
+		limited = minReplicas
+	}
+	if limited > autoscaling.MaxReplicas {
+		limited = autoscaling.MaxReplicas
+	}
+
+	return &limited
+}
+
+// DesiredReplicas return the desired replicas of the Boot, the Deployment's replicas.
+func DesiredReplicas(boot *appv1.Boot) int32 {
+	replicas := DeployReplicas(boot)
+	if replicas == nil {
+		return 0
+	}
+	return *replicas
+}
+
+// bootKind return the Boot's kind, as JavaBoot.
+func bootKind(boot *appv1.Boot) string {
+	if bootType := appv1.GetBootTypeByConfigKey(boot.BootType); bootType != nil {
+		return bootType.Kind
+	}
+	return boot.Kind
+}
+
+// NewHorizontalPodAutoscaler returns a new created HorizontalPodAutoscaler instance, targeting the Boot's scale subresource.
+// The Boot's replicas is scaled, and rolled out to the Deployment or StatefulSet, the canary and the blue-green colors.
+func (handler *BootHandler) NewHorizontalPodAutoscaler() *autoscalingv2beta2.HorizontalPodAutoscaler {
+	boot := handler.Boot
+	autoscaling := boot.Spec.Autoscaling
+	minReplicas := hpaMinReplicas(boot)
+
+	hpa := &autoscalingv2beta2.HorizontalPodAutoscaler{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "autoscaling/v2beta2",
+			Kind:       "HorizontalPodAutoscaler",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      HorizontalPodAutoscalerName(boot),
+			Namespace: boot.Namespace,
+			Labels:    DeployLabels(boot),
+		},
+		Spec: autoscalingv2beta2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2beta2.CrossVersionObjectReference{
+				APIVersion: appv1.SchemeGroupVersion.String(),
+				Kind:       bootKind(boot),
+				Name:       boot.Name,
+			},
+			MinReplicas: &minReplicas,
+			MaxReplicas: autoscaling.MaxReplicas,
+			Metrics:     autoscalingMetrics(autoscaling),
+		},
+	}
+
+	// Set Boot instance as the owner and controller
+	_ = controllerutil.SetControllerReference(handler.OperatorBoot, hpa, handler.Scheme)
+
+	return hpa
+}
+
+// autoscalingMetrics return the metrics of HorizontalPodAutoscaler: cpu, memory and then the custom metrics.
+func autoscalingMetrics(autoscaling *appv1.BootAutoscaling) []autoscalingv2beta2.MetricSpec {
+	metrics := make([]autoscalingv2beta2.MetricSpec, 0)
+
+	resourceMetric := func(name corev1.ResourceName, utilization int32) autoscalingv2beta2.MetricSpec {
+		return autoscalingv2beta2.MetricSpec{
+			Type: autoscalingv2beta2.ResourceMetricSourceType,
+			Resource: &autoscalingv2beta2.ResourceMetricSource{
+				Name: name,
+				Target: autoscalingv2beta2.MetricTarget{
+					Type:               autoscalingv2beta2.UtilizationMetricType,
+					AverageUtilization: &utilization,
+				},
+			},
+		}
+	}
+
+	if autoscaling.TargetCPUUtilizationPercentage != nil {
+		metrics = append(metrics, resourceMetric(corev1.ResourceCPU, *autoscaling.TargetCPUUtilizationPercentage))
+	}
+
+	if autoscaling.TargetMemoryUtilizationPercentage != nil {
+		metrics = append(metrics, resourceMetric(corev1.ResourceMemory, *autoscaling.TargetMemoryUtilizationPercentage))
+	}
+
+	for _, metric := range autoscaling.Metrics {
+		metrics = append(metrics, *metric.DeepCopy())
+	}
+
+	if len(metrics) == 0 {
+		metrics = append(metrics, resourceMetric(corev1.ResourceCPU, defaultTargetCPUUtilization))
+	}
+
+	return metrics
+}
+
+// reconcileCreateAutoscaler will create the HorizontalPodAutoscaler if autoscaling is enabled and it is not found.
+// Return true if created.
+func (handler *BootHandler) reconcileCreateAutoscaler() (bool, error) {
+	boot := handler.Boot
+	logger := handler.Logger
+	c := handler.Client
+
+	if !AutoscalingEnabled(boot) {
+		return false, nil
+	}
+
+	hpaFound := &autoscalingv2beta2.HorizontalPodAutoscaler{}
+	hpaName := HorizontalPodAutoscalerName(boot)
+	err := c.Get(context.TODO(), types.NamespacedName{Name: hpaName, Namespace: boot.Namespace}, hpaFound)
+	if err == nil {
+		return false, nil
+	}
+
+	if !errors.IsNotFound(err) {
+		msg := fmt.Sprintf("Failed to get HorizontalPodAutoscaler: %s", hpaName)
+		logger.Error(err, msg)
+		loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_CREATE_STAGE, loganMetrics.RECONCILE_GET_HPA_SUBSTAGE, boot.Name)
+		handler.RecordEvent(keys.FailedGetHorizontalPodAutoscaler, msg, err)
+		return false, err
+	}
+
+	err = handler.createAutoscaler(loganMetrics.RECONCILE_CREATE_STAGE)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// createAutoscaler will create the HorizontalPodAutoscaler, stage is the reconcile stage for metrics.
+func (handler *BootHandler) createAutoscaler(stage string) error {
+	boot := handler.Boot
+	logger := handler.Logger
+	c := handler.Client
+
+	hpa := handler.NewHorizontalPodAutoscaler()
+	logger.Info("Creating HorizontalPodAutoscaler", "hpa", hpa.Name, "spec", hpa.Spec)
+	err := c.Create(context.TODO(), hpa)
+	if err != nil {
+		msg := fmt.Sprintf("Failed to create HorizontalPodAutoscaler: %s", hpa.Name)
+		logger.Error(err, msg)
+		loganMetrics.UpdateReconcileErrors(boot.Kind, stage, loganMetrics.RECONCILE_CREATE_HPA_SUBSTAGE, boot.Name)
+		handler.RecordEvent(keys.FailedCreateHorizontalPodAutoscaler, msg, err)
+		return err
+	}
+
+	handler.RecordEvent(keys.CreatedHorizontalPodAutoscaler, fmt.Sprintf("Created HorizontalPodAutoscaler: %s", hpa.Name), nil)
+	return nil
+}
+
+// reconcileUpdateAutoscaler handle update logic of HorizontalPodAutoscaler
+// 1. autoscaling enabled: create it if not found, update if the spec is changed.
+// 2. autoscaling disabled: delete the HorizontalPodAutoscaler which is controlled by the Boot.
+func (handler *BootHandler) reconcileUpdateAutoscaler() (reconcile.Result, bool, error) {
+	boot := handler.Boot
+	logger := handler.Logger
+	c := handler.Client
+
+	hpaFound := &autoscalingv2beta2.HorizontalPodAutoscaler{}
+	hpaName := HorizontalPodAutoscalerName(boot)
+	err := c.Get(context.TODO(), types.NamespacedName{Name: hpaName, Namespace: boot.Namespace}, hpaFound)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			msg := fmt.Sprintf("Failed to get HorizontalPodAutoscaler: %s", hpaName)
+			logger.Error(err, msg)
+			loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_UPDATE_STAGE, loganMetrics.RECONCILE_GET_HPA_SUBSTAGE, boot.Name)
+			handler.RecordEvent(keys.FailedGetHorizontalPodAutoscaler, msg, err)
+			return reconcile.Result{Requeue: true}, true, err
+		}
+
+		if !AutoscalingEnabled(boot) {
+			return reconcile.Result{}, false, nil
+		}
+
+		// Autoscaling is enabled after the Boot created.
+		err = handler.createAutoscaler(loganMetrics.RECONCILE_UPDATE_STAGE)
+		if err != nil {
+			return reconcile.Result{Requeue: true}, true, err
+		}
+		return reconcile.Result{Requeue: true}, true, nil
+	}
+
+	// Only handle the HorizontalPodAutoscaler controlled by the Boot.
+	if !metav1.IsControlledBy(hpaFound, handler.OperatorBoot) {
+		logger.Info("HorizontalPodAutoscaler is not controlled by Boot, ignore it", "hpa", hpaName)
+		return reconcile.Result{}, false, nil
+	}
+
+	if !AutoscalingEnabled(boot) {
+		logger.Info("Deleting HorizontalPodAutoscaler", "hpa", hpaName)
+		err := c.Delete(context.TODO(), hpaFound)
+		if err != nil && !errors.IsNotFound(err) {
+			msg := fmt.Sprintf("Failed to delete HorizontalPodAutoscaler: %s", hpaName)
+			logger.Error(err, msg)
+			loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_UPDATE_STAGE, loganMetrics.RECONCILE_DELETE_HPA_SUBSTAGE, boot.Name)
+			handler.RecordEvent(keys.FailedDeleteHorizontalPodAutoscaler, msg, err)
+			return reconcile.Result{Requeue: true}, true, err
+		}
+
+		handler.RecordEvent(keys.DeletedHorizontalPodAutoscaler, fmt.Sprintf("Deleted HorizontalPodAutoscaler: %s", hpaName), nil)
+		return reconcile.Result{Requeue: true}, true, nil
+	}
+
+	expectHpa := handler.NewHorizontalPodAutoscaler()
+	if equality.Semantic.DeepEqual(hpaFound.Spec, expectHpa.Spec) {
+		return reconcile.Result{}, false, nil
+	}
+
+	logger.Info("Updating HorizontalPodAutoscaler", "hpa", hpaName,
+		"old", hpaFound.Spec, "new", expectHpa.Spec)
+	hpaFound.Spec = expectHpa.Spec
+	err = c.Update(context.TODO(), hpaFound)
+	if err != nil {
+		msg := fmt.Sprintf("Failed to update HorizontalPodAutoscaler: %s", hpaName)
+		logger.Info(msg, "err", err.Error())
+		loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_UPDATE_STAGE, loganMetrics.RECONCILE_UPDATE_HPA_SUBSTAGE, boot.Name)
+		handler.RecordEvent(keys.FailedUpdateHorizontalPodAutoscaler, msg, err)
+		return reconcile.Result{Requeue: true}, true, err
+	}
+
+	handler.RecordEvent(keys.UpdatedHorizontalPodAutoscaler, fmt.Sprintf("Updated HorizontalPodAutoscaler: %s", hpaName), nil)
+	return reconcile.Result{Requeue: true}, true, nil
+}
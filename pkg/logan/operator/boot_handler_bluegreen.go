@@ -0,0 +1,610 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	appv1 "github.com/logancloud/logan-app-operator/pkg/apis/app/v1"
+	"github.com/logancloud/logan-app-operator/pkg/logan/config"
+	loganMetrics "github.com/logancloud/logan-app-operator/pkg/logan/metrics"
+	"github.com/logancloud/logan-app-operator/pkg/logan/util/keys"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"strconv"
+	"time"
+)
+
+const (
+	// BlueColor is the color label's value of the Boot's Deployment, which is named as the Boot
+	BlueColor = "blue"
+	// GreenColor is the color label's value of the green Deployment, which is named as the Boot with suffix "-green"
+	GreenColor = "green"
+	// BlueGreenActionSwitch is the annotation value for switching the app Service to the new color
+	BlueGreenActionSwitch = "switch"
+	// BlueGreenActionRollback is the annotation value for switching the app Service back to the old color,
+	// and rolling back the Boot to the old color's revision
+	BlueGreenActionRollback = "rollback"
+
+	defaultScaleDownDelaySeconds = int32(600)
+)
+
+// BlueGreenEnabled return true if the Boot's rollout strategy is blue-green.
+// The StatefulSet is always rolled out by rolling update.
+func BlueGreenEnabled(boot *appv1.Boot, appSpec *config.AppSpec) bool {
+	return !StatefulSetEnabled(boot) && BootStrategy(boot, appSpec).BlueGreen != nil
+}
+
+// ColorDeployName return name of the color's Deployment, the blue Deployment is the Boot's Deployment.
+func ColorDeployName(boot *appv1.Boot, color string) string {
+	if color == GreenColor {
+		return DeployName(boot) + "-" + GreenColor
+	}
+	return DeployName(boot)
+}
+
+// DeploymentColor return the color of the Boot's Deployment.
+func DeploymentColor(boot *appv1.Boot, dep *appsv1.Deployment) string {
+	if dep.Name == ColorDeployName(boot, GreenColor) {
+		return GreenColor
+	}
+	return BlueColor
+}
+
+// ActiveColor return the color which the app Service is switched to.
+// The Service without color selects all the Boot's pods, which are served by the blue Deployment.
+func ActiveColor(svc *corev1.Service) string {
+	if svc.Spec.Selector[keys.BootColorKey] == GreenColor {
+		return GreenColor
+	}
+	return BlueColor
+}
+
+// ServiceSelector return the selector of the Boot's Services.
+// In blue-green rollout, the Services only select the pods of the Deployment's color.
+func ServiceSelector(boot *appv1.Boot, dep *appsv1.Deployment) map[string]string {
+	selector := PodLabels(boot)
+	if dep != nil {
+		if color, found := dep.Spec.Template.Labels[keys.BootColorKey]; found {
+			selector[keys.BootColorKey] = color
+		}
+	}
+	return selector
+}
+
+func otherColor(color string) string {
+	if color == GreenColor {
+		return BlueColor
+	}
+	return GreenColor
+}
+
+// scaleDownDelay return the time which the old color is kept scaled after the switch.
+func scaleDownDelay(blueGreen *appv1.BootBlueGreen) time.Duration {
+	delaySeconds := defaultScaleDownDelaySeconds
+	if blueGreen != nil && blueGreen.ScaleDownDelaySeconds != nil {
+		delaySeconds = *blueGreen.ScaleDownDelaySeconds
+	}
+	return time.Duration(delaySeconds) * time.Second
+}
+
+// NewColorDeployment return a new created Deployment of the color, from the Boot's spec of the new revision.
+// The blue Deployment keeps the Boot's selector, because the Deployment's selector is immutable.
+// The revision label is not included in the pod template's hash, so the rolled back revision do not cause a new rollout.
+func (handler *BootHandler) NewColorDeployment(color string, revisionId string) *appsv1.Deployment {
+	boot := handler.Boot
+
+	dep := handler.NewDeployment()
+	dep.Name = ColorDeployName(boot, color)
+
+	podLabels := PodLabels(boot)
+	podLabels[keys.BootColorKey] = color
+	if color != BlueColor {
+		dep.Spec.Selector = &metav1.LabelSelector{
+			MatchLabels: PodLabels(boot),
+		}
+		dep.Spec.Selector.MatchLabels[keys.BootColorKey] = color
+	}
+	dep.Spec.Template.Labels = podLabels
+
+	dep.Annotations = map[string]string{
+		keys.BlueGreenHashAnnotationKey: podTemplateHash(&dep.Spec.Template),
+	}
+	if revisionId != "" {
+		podLabels[keys.BootRevisionKey] = revisionId
+	}
+
+	return dep
+}
+
+// colorUpToDate return true if the color's Deployment is deployed with the Boot's spec.
+func (handler *BootHandler) colorUpToDate(dep *appsv1.Deployment) bool {
+	expectDeploy := handler.NewColorDeployment(DeploymentColor(handler.Boot, dep), "")
+	return dep.Annotations[keys.BlueGreenHashAnnotationKey] == expectDeploy.Annotations[keys.BlueGreenHashAnnotationKey]
+}
+
+// latestRevisionId return the latest revision's ID of the Boot, empty if not found.
+func (handler *BootHandler) latestRevisionId() string {
+	boot := handler.Boot
+
+	revisionList, err := handler.Client.ListRevision(boot.Namespace, PodLabels(boot))
+	if err != nil {
+		handler.Logger.Info("Failed to list revisions", "err", err.Error())
+		return ""
+	}
+
+	latestRevision := revisionList.SelectLatestRevision()
+	if latestRevision == nil {
+		return ""
+	}
+	return strconv.Itoa(latestRevision.GetRevisionId())
+}
+
+// getAppService return the Boot's app Service, nil if not found.
+func (handler *BootHandler) getAppService(stage string) (*corev1.Service, error) {
+	boot := handler.Boot
+
+	svcFound := &corev1.Service{}
+	err := handler.Client.Get(context.TODO(), types.NamespacedName{Name: boot.Name, Namespace: boot.Namespace}, svcFound)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		handler.Logger.Error(err, "Failed to get Service")
+		loganMetrics.UpdateReconcileErrors(boot.Kind, stage, loganMetrics.RECONCILE_GET_SERVICE_SUBSTAGE, boot.Name)
+		return nil, err
+	}
+
+	return svcFound, nil
+}
+
+// getGreenDeployment return the green Deployment controlled by the Boot, nil if not found.
+func (handler *BootHandler) getGreenDeployment(stage string) (*appsv1.Deployment, error) {
+	boot := handler.Boot
+	logger := handler.Logger
+
+	greenFound := &appsv1.Deployment{}
+	greenName := ColorDeployName(boot, GreenColor)
+	err := handler.Client.Get(context.TODO(), types.NamespacedName{Name: greenName, Namespace: boot.Namespace}, greenFound)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		msg := fmt.Sprintf("Failed to get green Deployment: %s", greenName)
+		logger.Error(err, msg)
+		loganMetrics.UpdateReconcileErrors(boot.Kind, stage, loganMetrics.RECONCILE_GET_COLOR_SUBSTAGE, boot.Name)
+		handler.RecordEvent(keys.FailedGetDeployment, msg, err)
+		return nil, err
+	}
+
+	if !metav1.IsControlledBy(greenFound, handler.OperatorBoot) {
+		logger.Info("Green Deployment is not controlled by Boot, ignore it", "deploy", greenName)
+		return nil, nil
+	}
+
+	return greenFound, nil
+}
+
+// ActiveDeployment return the Deployment which the app Service is switched to, the Boot's Deployment if not in blue-green rollout.
+func (handler *BootHandler) ActiveDeployment(deploy *appsv1.Deployment, stage string) (*appsv1.Deployment, error) {
+	svc, err := handler.getAppService(stage)
+	if err != nil {
+		return nil, err
+	}
+	if svc == nil || ActiveColor(svc) != GreenColor {
+		return deploy, nil
+	}
+
+	green, err := handler.getGreenDeployment(stage)
+	if err != nil {
+		return nil, err
+	}
+	if green == nil {
+		return deploy, nil
+	}
+	return green, nil
+}
+
+// blueGreenRollout return the Deployment which the latest revision is rolled out to,
+// and true if the app Service is switched to it.
+func (handler *BootHandler) blueGreenRollout(activeDeploy *appsv1.Deployment, stage string) (*appsv1.Deployment, bool) {
+	if handler.colorUpToDate(activeDeploy) {
+		return activeDeploy, true
+	}
+
+	if DeploymentColor(handler.Boot, activeDeploy) == BlueColor {
+		green, err := handler.getGreenDeployment(stage)
+		if err == nil && green != nil {
+			return green, false
+		}
+		return activeDeploy, false
+	}
+
+	blue := &appsv1.Deployment{}
+	err := handler.Client.Get(context.TODO(), types.NamespacedName{Name: DeployName(handler.Boot), Namespace: activeDeploy.Namespace}, blue)
+	if err != nil {
+		return activeDeploy, false
+	}
+	return blue, false
+}
+
+// reconcileBlueGreen handle the blue-green rollout, the app Service is switched to the color which is deployed with the Boot's spec.
+// 1. The switch or rollback is requested by the Boot's annotation.
+// 2. The active color is up to date: keep it scaled, and scale down the other color after the grace period.
+// 3. The other color is created or updated with the Boot's spec, and switched to after all its pods are ready.
+func (handler *BootHandler) reconcileBlueGreen(deploy *appsv1.Deployment, updated bool) (reconcile.Result, bool, error) {
+	boot := handler.Boot
+	logger := handler.Logger
+	c := handler.Client
+
+	if updated {
+		return handler.updateColor(deploy)
+	}
+
+	svc, err := handler.getAppService(loganMetrics.RECONCILE_UPDATE_STAGE)
+	if err != nil {
+		return reconcile.Result{Requeue: true}, true, err
+	}
+	if svc == nil {
+		logger.Info("Service resource not found. Ignoring since object is not created successfully yet")
+		return reconcile.Result{Requeue: true}, true, nil
+	}
+
+	green, err := handler.getGreenDeployment(loganMetrics.RECONCILE_UPDATE_STAGE)
+	if err != nil {
+		return reconcile.Result{Requeue: true}, true, err
+	}
+
+	activeColor := ActiveColor(svc)
+	activeDeploy, inactiveDeploy := deploy, green
+	if activeColor == GreenColor {
+		if green == nil {
+			activeColor = BlueColor
+		} else {
+			activeDeploy, inactiveDeploy = green, deploy
+		}
+	}
+	inactiveColor := otherColor(activeColor)
+
+	// 1. Switch or rollback
+	if action, found := handler.OperatorMeta.Annotations[keys.BlueGreenAnnotationKey]; found {
+		return handler.reconcileBlueGreenAction(action, svc, activeDeploy, inactiveDeploy)
+	}
+
+	revisionId := handler.latestRevisionId()
+	blueGreen := BootStrategy(boot, handler.Config.AppSpec).BlueGreen
+
+	// 2. The active color is up to date, only replicas and strategy are updated in place.
+	expectActive := handler.NewColorDeployment(activeColor, revisionId)
+	if handler.colorUpToDate(activeDeploy) {
+		activeUpdated := handler.reconcileUpdateStrategy(activeDeploy)
+		if *activeDeploy.Spec.Replicas != *expectActive.Spec.Replicas {
+			logger.Info("Updating Deployment", "type", "replicas", "deploy", activeDeploy.Name,
+				"old", activeDeploy.Spec.Replicas, "new", expectActive.Spec.Replicas)
+			activeDeploy.Spec.Replicas = expectActive.Spec.Replicas
+			activeUpdated = true
+		}
+		if activeUpdated {
+			return handler.updateColor(activeDeploy)
+		}
+
+		return handler.scaleDownColor(inactiveDeploy, blueGreen)
+	}
+
+	// 3.0 Pin the Services to the active color before the other color is created,
+	// otherwise the Services without color select the other color's pods before switched.
+	if _, colored := svc.Spec.Selector[keys.BootColorKey]; !colored {
+		return handler.pinActiveColor(svc, activeDeploy, activeColor)
+	}
+
+	// 3.1 Create the other color's Deployment with the Boot's spec.
+	expectInactive := handler.NewColorDeployment(inactiveColor, revisionId)
+	if inactiveDeploy == nil {
+		logger.Info("Creating color Deployment", "deploy", expectInactive.Name, "color", inactiveColor)
+		err = c.Create(context.TODO(), expectInactive)
+		if err != nil {
+			msg := fmt.Sprintf("Failed to create color Deployment: %s", expectInactive.Name)
+			logger.Error(err, msg)
+			loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_UPDATE_STAGE, loganMetrics.RECONCILE_CREATE_COLOR_SUBSTAGE, boot.Name)
+			handler.RecordEvent(keys.FailedCreateDeployment, msg, err)
+			return reconcile.Result{Requeue: true}, true, err
+		}
+
+		handler.RecordEvent(keys.CreatedDeployment, fmt.Sprintf("Created color Deployment: %s", expectInactive.Name), nil)
+		return reconcile.Result{Requeue: true}, true, nil
+	}
+
+	// 3.2 Update the other color's Deployment with the Boot's spec and full replicas.
+	if !handler.colorUpToDate(inactiveDeploy) || *inactiveDeploy.Spec.Replicas != *expectInactive.Spec.Replicas {
+		logger.Info("Updating color Deployment", "deploy", inactiveDeploy.Name, "color", inactiveColor)
+		selector := inactiveDeploy.Spec.Selector
+		inactiveDeploy.Spec = expectInactive.Spec
+		inactiveDeploy.Spec.Selector = selector
+		if inactiveDeploy.Annotations == nil {
+			inactiveDeploy.Annotations = make(map[string]string)
+		}
+		inactiveDeploy.Annotations[keys.BlueGreenHashAnnotationKey] = expectInactive.Annotations[keys.BlueGreenHashAnnotationKey]
+		delete(inactiveDeploy.Annotations, keys.BlueGreenSwitchedAtAnnotationKey)
+		return handler.updateColor(inactiveDeploy)
+	}
+
+	// 3.3 Switch after all the pods are ready, automatically or by annotation.
+	if !deploymentComplete(inactiveDeploy, *inactiveDeploy.Spec.Replicas) {
+		logger.V(1).Info("Color Deployment is rolling out", "deploy", inactiveDeploy.Name)
+		return reconcile.Result{}, false, nil
+	}
+
+	if blueGreen.AutoSwitch == nil || !*blueGreen.AutoSwitch {
+		logger.V(1).Info("Color Deployment is ready, waiting for switch", "deploy", inactiveDeploy.Name)
+		return reconcile.Result{}, false, nil
+	}
+
+	return handler.switchColor(svc, inactiveDeploy, activeDeploy)
+}
+
+// pinActiveColor switch the Services without color to the active color's pods.
+// The Deployment created before blue-green is enabled has no color label, its pods are labeled first,
+// which rolls out the Deployment with its current spec, and the Services are switched after it is rolled out.
+func (handler *BootHandler) pinActiveColor(svc *corev1.Service, activeDeploy *appsv1.Deployment, activeColor string) (reconcile.Result, bool, error) {
+	logger := handler.Logger
+
+	if activeDeploy.Spec.Template.Labels[keys.BootColorKey] != activeColor {
+		logger.Info("Labeling color Deployment", "deploy", activeDeploy.Name, "color", activeColor)
+		if activeDeploy.Spec.Template.Labels == nil {
+			activeDeploy.Spec.Template.Labels = make(map[string]string)
+		}
+		activeDeploy.Spec.Template.Labels[keys.BootColorKey] = activeColor
+		return handler.updateColor(activeDeploy)
+	}
+
+	if !deploymentComplete(activeDeploy, *activeDeploy.Spec.Replicas) {
+		logger.V(1).Info("Color Deployment is rolling out", "deploy", activeDeploy.Name)
+		return reconcile.Result{}, false, nil
+	}
+
+	err := handler.switchServices(svc, ServiceSelector(handler.Boot, activeDeploy))
+	if err != nil {
+		return reconcile.Result{Requeue: true}, true, err
+	}
+
+	return reconcile.Result{Requeue: true}, true, nil
+}
+
+// reconcileBlueGreenAction handle the blue-green action requested by the Boot's annotation, the annotation is removed after handled.
+// 1. switch: switch the app Service to the other color, which is up to date and ready.
+// 2. rollback: switch the app Service back to the old color if it is still scaled, or stop the rollout of the other color,
+// and roll back the Boot to the active color's revision.
+func (handler *BootHandler) reconcileBlueGreenAction(action string, svc *corev1.Service,
+	activeDeploy *appsv1.Deployment, inactiveDeploy *appsv1.Deployment) (reconcile.Result, bool, error) {
+	boot := handler.Boot
+	logger := handler.Logger
+	c := handler.Client
+
+	obj, ok := handler.OperatorBoot.(runtime.Object)
+	if !ok {
+		return reconcile.Result{}, true, fmt.Errorf("boot %s/%s is not a runtime object", boot.Namespace, boot.Name)
+	}
+
+	delete(handler.OperatorMeta.Annotations, keys.BlueGreenAnnotationKey)
+
+	inactiveReady := inactiveDeploy != nil && *inactiveDeploy.Spec.Replicas > 0 &&
+		deploymentComplete(inactiveDeploy, *inactiveDeploy.Spec.Replicas)
+	switch action {
+	case BlueGreenActionSwitch:
+		if !inactiveReady || !handler.colorUpToDate(inactiveDeploy) {
+			msg := "Failed to switch, the other color is not up to date or not ready"
+			logger.Info(msg)
+			handler.RecordEvent(keys.FailedSwitchBlueGreen, msg, nil)
+			break
+		}
+
+		result, requeue, err := handler.switchColor(svc, inactiveDeploy, activeDeploy)
+		if err != nil {
+			return result, requeue, err
+		}
+	case BlueGreenActionRollback:
+		rollbackDeploy := activeDeploy
+		if handler.colorUpToDate(activeDeploy) {
+			// The app Service is switched, switch back to the old color.
+			if !inactiveReady {
+				msg := "Failed to roll back, the old color is scaled down or not ready"
+				logger.Info(msg)
+				handler.RecordEvent(keys.FailedSwitchBlueGreen, msg, nil)
+				break
+			}
+
+			result, requeue, err := handler.switchColor(svc, inactiveDeploy, activeDeploy)
+			if err != nil {
+				return result, requeue, err
+			}
+			rollbackDeploy = inactiveDeploy
+		}
+
+		revisionId, found := rollbackDeploy.Spec.Template.Labels[keys.BootRevisionKey]
+		if !found {
+			msg := fmt.Sprintf("Failed to roll back, the revision of Deployment %s is unknown", rollbackDeploy.Name)
+			logger.Info(msg)
+			handler.RecordEvent(keys.FailedSwitchBlueGreen, msg, nil)
+			break
+		}
+		handler.OperatorMeta.Annotations[keys.BootRollbackToAnnotationKey] = revisionId
+	default:
+		logger.Info("Unknown blue-green action, ignore it", "action", action)
+	}
+
+	err := c.Update(context.TODO(), obj)
+	if err != nil {
+		logger.Info("Failed to remove Boot's blue-green annotation", "err", err.Error())
+		loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_UPDATE_STAGE, loganMetrics.RECONCILE_UPDATE_BOOT_META_SUBSTAGE, boot.Name)
+		return reconcile.Result{Requeue: true}, true, err
+	}
+
+	return reconcile.Result{Requeue: true}, true, nil
+}
+
+// switchColor switch the Services to the Deployment's color.
+// The old color's Deployment is marked with the switched time, and scaled down after the grace period.
+func (handler *BootHandler) switchColor(svc *corev1.Service, toDeploy *appsv1.Deployment, fromDeploy *appsv1.Deployment) (reconcile.Result, bool, error) {
+	boot := handler.Boot
+
+	err := handler.switchServices(svc, ServiceSelector(boot, toDeploy))
+	if err != nil {
+		return reconcile.Result{Requeue: true}, true, err
+	}
+
+	color := DeploymentColor(boot, toDeploy)
+	handler.RecordEvent(keys.SwitchedBlueGreen, fmt.Sprintf("Switched to %s Deployment: %s", color, toDeploy.Name), nil)
+
+	if fromDeploy.Annotations == nil {
+		fromDeploy.Annotations = make(map[string]string)
+	}
+	fromDeploy.Annotations[keys.BlueGreenSwitchedAtAnnotationKey] = time.Now().UTC().Format(time.RFC3339)
+	return handler.updateColor(fromDeploy)
+}
+
+// switchServices update the app Service's selector first, which switches the traffic atomically,
+// then the sidecar and nodePort Services.
+func (handler *BootHandler) switchServices(svc *corev1.Service, selector map[string]string) error {
+	boot := handler.Boot
+
+	handler.Logger.Info("Switching Service", "service", svc.Name, "old", svc.Spec.Selector, "new", selector)
+	err := handler.updateServiceSelector(svc, selector)
+	if err != nil {
+		return err
+	}
+
+	svcList, err := handler.listRuntimeService()
+	if err != nil {
+		loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_UPDATE_STAGE, loganMetrics.RECONCILE_LIST_SERVICES_SUBSTAGE, boot.Name)
+		return err
+	}
+	for i := range svcList.Items {
+		otherSvc := &svcList.Items[i]
+		if otherSvc.Name == svc.Name || equality.Semantic.DeepEqual(otherSvc.Spec.Selector, selector) {
+			continue
+		}
+
+		err = handler.updateServiceSelector(otherSvc, selector)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updateServiceSelector update the Service's selector.
+func (handler *BootHandler) updateServiceSelector(svc *corev1.Service, selector map[string]string) error {
+	boot := handler.Boot
+
+	svc.Spec.Selector = selector
+	err := handler.Client.Update(context.TODO(), svc)
+	if err != nil {
+		msg := fmt.Sprintf("Failed to switch Service: %s", svc.Name)
+		handler.Logger.Error(err, msg)
+		loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_UPDATE_STAGE, loganMetrics.RECONCILE_SWITCH_COLOR_SUBSTAGE, boot.Name)
+		handler.RecordEvent(keys.FailedSwitchBlueGreen, msg, err)
+		return err
+	}
+
+	handler.RecordEvent(keys.UpdatedService, fmt.Sprintf("Updated Service: %s", svc.Name), nil)
+	return nil
+}
+
+// scaleDownColor scale down the inactive color's Deployment, after the grace period since the app Service is switched away.
+// The Deployment which is never switched to, is scaled down immediately.
+func (handler *BootHandler) scaleDownColor(inactiveDeploy *appsv1.Deployment, blueGreen *appv1.BootBlueGreen) (reconcile.Result, bool, error) {
+	if inactiveDeploy == nil || *inactiveDeploy.Spec.Replicas == 0 {
+		return reconcile.Result{}, false, nil
+	}
+
+	if switchedAt, found := inactiveDeploy.Annotations[keys.BlueGreenSwitchedAtAnnotationKey]; found {
+		switchedTime, err := time.Parse(time.RFC3339, switchedAt)
+		elapsed := time.Since(switchedTime)
+		if delay := scaleDownDelay(blueGreen); err == nil && elapsed < delay {
+			return reconcile.Result{RequeueAfter: delay - elapsed}, false, nil
+		}
+	}
+
+	handler.Logger.Info("Scaling down color Deployment", "deploy", inactiveDeploy.Name)
+	replicas := int32(0)
+	inactiveDeploy.Spec.Replicas = &replicas
+	return handler.updateColor(inactiveDeploy)
+}
+
+// updateColor update the color's Deployment.
+func (handler *BootHandler) updateColor(dep *appsv1.Deployment) (reconcile.Result, bool, error) {
+	boot := handler.Boot
+	logger := handler.Logger
+
+	err := handler.Client.Update(context.TODO(), dep)
+	if err != nil {
+		msg := fmt.Sprintf("Failed to update Deployment: %s", dep.Name)
+		logger.Info(msg, "err", err.Error())
+		loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_UPDATE_STAGE, loganMetrics.RECONCILE_UPDATE_COLOR_SUBSTAGE, boot.Name)
+		handler.RecordEvent(keys.FailedUpdateDeployment, msg, err)
+		return reconcile.Result{Requeue: true}, true, err
+	}
+
+	handler.RecordEvent(keys.UpdatedDeployment, fmt.Sprintf("Updated Deployment: %s", dep.Name), nil)
+	return reconcile.Result{Requeue: true}, true, nil
+}
+
+// reconcileBlueGreenDisabled switch the Services back to all the Boot's pods and delete the green Deployment,
+// after the blue-green rollout is disabled and the Boot's Deployment is rolled out.
+func (handler *BootHandler) reconcileBlueGreenDisabled(deploy *appsv1.Deployment) (reconcile.Result, bool, error) {
+	boot := handler.Boot
+	logger := handler.Logger
+
+	svc, err := handler.getAppService(loganMetrics.RECONCILE_UPDATE_STAGE)
+	if err != nil {
+		return reconcile.Result{Requeue: true}, true, err
+	}
+	green, err := handler.getGreenDeployment(loganMetrics.RECONCILE_UPDATE_STAGE)
+	if err != nil {
+		return reconcile.Result{Requeue: true}, true, err
+	}
+
+	if svc == nil {
+		return reconcile.Result{}, false, nil
+	}
+
+	_, colored := svc.Spec.Selector[keys.BootColorKey]
+	if !colored && green == nil {
+		return reconcile.Result{}, false, nil
+	}
+
+	// Keep the green pods serving until the Boot's Deployment is rolled out.
+	if colored && ActiveColor(svc) == GreenColor && green != nil &&
+		!deploymentComplete(deploy, DesiredReplicas(boot)) {
+		return reconcile.Result{}, false, nil
+	}
+
+	if colored {
+		err = handler.switchServices(svc, PodLabels(boot))
+		if err != nil {
+			return reconcile.Result{Requeue: true}, true, err
+		}
+	}
+
+	if green != nil {
+		logger.Info("Deleting color Deployment", "deploy", green.Name)
+		err = handler.Client.Delete(context.TODO(), green)
+		if err != nil && !errors.IsNotFound(err) {
+			msg := fmt.Sprintf("Failed to delete color Deployment: %s", green.Name)
+			logger.Error(err, msg)
+			loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_UPDATE_STAGE, loganMetrics.RECONCILE_UPDATE_COLOR_SUBSTAGE, boot.Name)
+			handler.RecordEvent(keys.FailedDeleteDeployment, msg, err)
+			return reconcile.Result{Requeue: true}, true, err
+		}
+		handler.RecordEvent(keys.DeletedDeployment, fmt.Sprintf("Deleted color Deployment: %s", green.Name), nil)
+	}
+
+	return reconcile.Result{Requeue: true}, true, nil
+}
@@ -0,0 +1,399 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	appv1 "github.com/logancloud/logan-app-operator/pkg/apis/app/v1"
+	"github.com/logancloud/logan-app-operator/pkg/logan/config"
+	loganMetrics "github.com/logancloud/logan-app-operator/pkg/logan/metrics"
+	"github.com/logancloud/logan-app-operator/pkg/logan/util/hash"
+	"github.com/logancloud/logan-app-operator/pkg/logan/util/keys"
+	"hash/fnv"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"strconv"
+	"time"
+)
+
+const (
+	// CanaryTrack is the track label's value of the canary pods
+	CanaryTrack = "canary"
+	// CanaryActionPromote is the annotation value for promoting the canary to the next step
+	CanaryActionPromote = "promote"
+	// CanaryActionAbort is the annotation value for aborting the canary, and rolling back to the stable revision
+	CanaryActionAbort = "abort"
+
+	defaultCanaryReplicas = 1
+)
+
+// CanaryEnabled return true if the Boot's rollout strategy is canary.
+// The StatefulSet is always rolled out by rolling update.
+func CanaryEnabled(boot *appv1.Boot, appSpec *config.AppSpec) bool {
+	return !StatefulSetEnabled(boot) && BootStrategy(boot, appSpec).Canary != nil
+}
+
+// CanaryDeployName return name for the created canary Deployment
+func CanaryDeployName(boot *appv1.Boot) string {
+	return DeployName(boot) + "-" + CanaryTrack
+}
+
+// CanarySelectorLabels return the selector labels of the created canary Deployment.
+// The canary pods are also selected by the app Service, which selects the PodLabels.
+func CanarySelectorLabels(boot *appv1.Boot) map[string]string {
+	labels := PodLabels(boot)
+	labels[keys.BootTrackKey] = CanaryTrack
+	return labels
+}
+
+// CanarySteps return the canary's steps, default is a single step with 1 replica.
+func CanarySteps(canary *appv1.BootCanary) []intstr.IntOrString {
+	if canary == nil || len(canary.Steps) == 0 {
+		return []intstr.IntOrString{intstr.FromInt(defaultCanaryReplicas)}
+	}
+	return canary.Steps
+}
+
+// CanaryReplicas return the canary Deployment's replicas of the step, the percentage is rounded up, at least 1.
+func CanaryReplicas(boot *appv1.Boot, canary *appv1.BootCanary, step int) int32 {
+	steps := CanarySteps(canary)
+	if step >= len(steps) {
+		step = len(steps) - 1
+	}
+
+	total := 0
+	if boot.Spec.Replicas != nil {
+		total = int(*boot.Spec.Replicas)
+	}
+
+	replicas, err := intstr.GetValueFromIntOrPercent(&steps[step], total, true)
+	if err != nil || replicas < 1 {
+		replicas = defaultCanaryReplicas
+	}
+	return int32(replicas)
+}
+
+// NewCanaryDeployment return a new created canary Deployment of the step, from the Boot's spec of the new revision.
+// The revision label is only set to the pod template, because the Deployment's selector is immutable.
+func (handler *BootHandler) NewCanaryDeployment(revisionId string, step int) *appsv1.Deployment {
+	boot := handler.Boot
+	canary := BootStrategy(boot, handler.Config.AppSpec).Canary
+
+	dep := handler.NewDeployment()
+	dep.Name = CanaryDeployName(boot)
+
+	podLabels := CanarySelectorLabels(boot)
+	if revisionId != "" {
+		podLabels[keys.BootRevisionKey] = revisionId
+	}
+	dep.Spec.Selector = &metav1.LabelSelector{
+		MatchLabels: CanarySelectorLabels(boot),
+	}
+	dep.Spec.Template.Labels = podLabels
+
+	replicas := CanaryReplicas(boot, canary, step)
+	dep.Spec.Replicas = &replicas
+
+	dep.Annotations = map[string]string{
+		keys.CanaryHashAnnotationKey: podTemplateHash(&dep.Spec.Template),
+		keys.CanaryStepAnnotationKey: strconv.Itoa(step),
+	}
+
+	return dep
+}
+
+// podTemplateHash returns a hash value calculated from the pod template, which is generated by the operator.
+func podTemplateHash(template *corev1.PodTemplateSpec) string {
+	hasher := fnv.New32a()
+	hash.DeepHashObject(hasher, *template)
+	return rand.SafeEncodeString(fmt.Sprint(hasher.Sum32()))
+}
+
+// canaryStep return the current step of the canary Deployment
+func canaryStep(dep *appsv1.Deployment) int {
+	step, err := strconv.Atoi(dep.Annotations[keys.CanaryStepAnnotationKey])
+	if err != nil || step < 0 {
+		return 0
+	}
+	return step
+}
+
+// getCanaryDeployment return the canary Deployment controlled by the Boot, nil if not found.
+func (handler *BootHandler) getCanaryDeployment(stage string) (*appsv1.Deployment, error) {
+	boot := handler.Boot
+	logger := handler.Logger
+	c := handler.Client
+
+	canaryFound := &appsv1.Deployment{}
+	canaryName := CanaryDeployName(boot)
+	err := c.Get(context.TODO(), types.NamespacedName{Name: canaryName, Namespace: boot.Namespace}, canaryFound)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		msg := fmt.Sprintf("Failed to get canary Deployment: %s", canaryName)
+		logger.Error(err, msg)
+		loganMetrics.UpdateReconcileErrors(boot.Kind, stage, loganMetrics.RECONCILE_GET_CANARY_SUBSTAGE, boot.Name)
+		handler.RecordEvent(keys.FailedGetDeployment, msg, err)
+		return nil, err
+	}
+
+	if !metav1.IsControlledBy(canaryFound, handler.OperatorBoot) {
+		logger.Info("Canary Deployment is not controlled by Boot, ignore it", "deploy", canaryName)
+		return nil, nil
+	}
+
+	return canaryFound, nil
+}
+
+// reconcileCanary handle the canary rollout, the stable Deployment is kept until the canary is promoted after the last step.
+// 1. The stable Deployment is up to date or canary is disabled: delete the canary Deployment after the stable rolled out.
+// 2. The canary is requested to promote or abort by the Boot's annotation.
+// 3. The canary Deployment is created, or updated with the Boot's spec or the step's replicas.
+// 4. The canary is promoted to the next step automatically, after all the canary pods are ready for bakeSeconds.
+// Return rollingOut true if the stable Deployment should not be updated to the Boot's spec.
+func (handler *BootHandler) reconcileCanary(deploy *appsv1.Deployment, rebootUpdated bool) (reconcile.Result, bool, error) {
+	boot := handler.Boot
+	logger := handler.Logger
+	c := handler.Client
+
+	canaryFound, err := handler.getCanaryDeployment(loganMetrics.RECONCILE_UPDATE_STAGE)
+	if err != nil {
+		return reconcile.Result{Requeue: true}, false, err
+	}
+
+	// 1. Delete the canary Deployment
+	canaryEnabled := CanaryEnabled(boot, handler.Config.AppSpec)
+	if !rebootUpdated || !canaryEnabled {
+		if canaryFound == nil {
+			return reconcile.Result{}, false, nil
+		}
+
+		// Keep the canary pods serving until the stable Deployment is rolled out.
+		if canaryEnabled && !deploymentComplete(deploy, DesiredReplicas(boot)) {
+			return reconcile.Result{}, false, nil
+		}
+
+		return handler.deleteCanary(canaryFound)
+	}
+
+	// 2. Promote or abort
+	if action, found := handler.OperatorMeta.Annotations[keys.CanaryAnnotationKey]; found {
+		return handler.reconcileCanaryAction(action, canaryFound)
+	}
+
+	revisionId := ""
+	revisionList, err := c.ListRevision(boot.Namespace, PodLabels(boot))
+	if err != nil {
+		logger.Info("Failed to list revisions", "err", err.Error())
+	} else if latestRevision := revisionList.SelectLatestRevision(); latestRevision != nil {
+		revisionId = strconv.Itoa(latestRevision.GetRevisionId())
+	}
+
+	// 3.1 Create the canary Deployment of the first step.
+	if canaryFound == nil {
+		canaryDeploy := handler.NewCanaryDeployment(revisionId, 0)
+		logger.Info("Creating canary Deployment", "deploy", canaryDeploy.Name, "replicas", canaryDeploy.Spec.Replicas)
+		err = c.Create(context.TODO(), canaryDeploy)
+		if err != nil {
+			msg := fmt.Sprintf("Failed to create canary Deployment: %s", canaryDeploy.Name)
+			logger.Error(err, msg)
+			loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_UPDATE_STAGE, loganMetrics.RECONCILE_CREATE_CANARY_SUBSTAGE, boot.Name)
+			handler.RecordEvent(keys.FailedCreateDeployment, msg, err)
+			return reconcile.Result{Requeue: true}, true, err
+		}
+
+		handler.RecordEvent(keys.CreatedDeployment, fmt.Sprintf("Created canary Deployment: %s", canaryDeploy.Name), nil)
+		return reconcile.Result{Requeue: true}, true, nil
+	}
+
+	// 3.2 The Boot's spec is changed during the canary, restart from the first step.
+	step := canaryStep(canaryFound)
+	expectDeploy := handler.NewCanaryDeployment(revisionId, step)
+	if canaryFound.Annotations[keys.CanaryHashAnnotationKey] != expectDeploy.Annotations[keys.CanaryHashAnnotationKey] {
+		logger.Info("Updating canary Deployment", "type", "template", "deploy", canaryFound.Name)
+		return handler.updateCanary(canaryFound, handler.NewCanaryDeployment(revisionId, 0))
+	}
+
+	// 3.3 Promoted after the last step, the stable Deployment is updated to the Boot's spec.
+	canary := BootStrategy(boot, handler.Config.AppSpec).Canary
+	if step >= len(CanarySteps(canary)) {
+		logger.Info("Canary is promoted, updating the stable Deployment", "deploy", deploy.Name)
+		return reconcile.Result{}, false, nil
+	}
+
+	// 3.4 The step's replicas is changed, maybe the Boot's replicas is changed.
+	if *canaryFound.Spec.Replicas != *expectDeploy.Spec.Replicas {
+		logger.Info("Updating canary Deployment", "type", "replicas", "deploy", canaryFound.Name,
+			"old", canaryFound.Spec.Replicas, "new", expectDeploy.Spec.Replicas)
+		return handler.updateCanary(canaryFound, expectDeploy)
+	}
+
+	// 4. Promote automatically, only when bakeSeconds is set.
+	if canary.BakeSeconds == nil {
+		return reconcile.Result{}, true, nil
+	}
+
+	readyAt, found := canaryFound.Annotations[keys.CanaryReadyAtAnnotationKey]
+	if !deploymentComplete(canaryFound, *canaryFound.Spec.Replicas) {
+		if found {
+			// Some canary pods are not ready, restart the bake time.
+			delete(canaryFound.Annotations, keys.CanaryReadyAtAnnotationKey)
+			return handler.updateCanary(canaryFound, nil)
+		}
+		return reconcile.Result{}, true, nil
+	}
+
+	bakeTime := time.Duration(*canary.BakeSeconds) * time.Second
+	readyTime, err := time.Parse(time.RFC3339, readyAt)
+	if !found || err != nil {
+		canaryFound.Annotations[keys.CanaryReadyAtAnnotationKey] = time.Now().UTC().Format(time.RFC3339)
+		result, rollingOut, err := handler.updateCanary(canaryFound, nil)
+		if err != nil {
+			return result, rollingOut, err
+		}
+		return reconcile.Result{RequeueAfter: bakeTime}, true, nil
+	}
+
+	elapsed := time.Since(readyTime)
+	if elapsed < bakeTime {
+		logger.V(1).Info("Canary is baking", "deploy", canaryFound.Name, "step", step, "elapsed", elapsed)
+		return reconcile.Result{RequeueAfter: bakeTime - elapsed}, true, nil
+	}
+
+	return handler.promoteCanary(canaryFound, revisionId)
+}
+
+// reconcileCanaryAction handle the canary action requested by the Boot's annotation, the annotation is removed after handled.
+// 1. promote: promote the canary to the next step.
+// 2. abort: delete the canary Deployment, and roll back the Boot to the stable revision.
+func (handler *BootHandler) reconcileCanaryAction(action string, canaryFound *appsv1.Deployment) (reconcile.Result, bool, error) {
+	boot := handler.Boot
+	logger := handler.Logger
+	c := handler.Client
+
+	obj, ok := handler.OperatorBoot.(runtime.Object)
+	if !ok {
+		return reconcile.Result{}, true, fmt.Errorf("boot %s/%s is not a runtime object", boot.Namespace, boot.Name)
+	}
+
+	delete(handler.OperatorMeta.Annotations, keys.CanaryAnnotationKey)
+
+	switch action {
+	case CanaryActionPromote:
+		if canaryFound != nil {
+			result, rollingOut, err := handler.promoteCanary(canaryFound, canaryFound.Spec.Template.Labels[keys.BootRevisionKey])
+			if err != nil {
+				return result, rollingOut, err
+			}
+		}
+	case CanaryActionAbort:
+		revisionList, err := c.ListRevision(boot.Namespace, PodLabels(boot))
+		if err != nil {
+			msg := "Failed to abort canary, can not list Boot Revision"
+			logger.Error(err, msg)
+			handler.RecordEvent(keys.FailedAbortCanary, msg, err)
+			return reconcile.Result{Requeue: true}, true, err
+		}
+
+		latestRevision := revisionList.SelectLatestRevision()
+		var stable *appv1.BootRevision
+		if latestRevision != nil {
+			stable = SelectStableRevision(revisionList, latestRevision.GetRevisionId())
+		}
+		if stable == nil {
+			msg := "Failed to abort canary, no stable revision to roll back"
+			logger.Info(msg)
+			handler.RecordEvent(keys.FailedAbortCanary, msg, nil)
+		} else {
+			// The canary Deployment is deleted when the Boot is rolled back to the stable revision.
+			stableId := strconv.Itoa(stable.GetRevisionId())
+			handler.OperatorMeta.Annotations[keys.BootRollbackToAnnotationKey] = stableId
+			handler.RecordEvent(keys.AbortedCanary, fmt.Sprintf("Aborted canary, rolling back to revision %s", stableId), nil)
+		}
+	default:
+		logger.Info("Unknown canary action, ignore it", "action", action)
+	}
+
+	err := c.Update(context.TODO(), obj)
+	if err != nil {
+		logger.Info("Failed to remove Boot's canary annotation", "err", err.Error())
+		loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_UPDATE_STAGE, loganMetrics.RECONCILE_UPDATE_BOOT_META_SUBSTAGE, boot.Name)
+		return reconcile.Result{Requeue: true}, true, err
+	}
+
+	return reconcile.Result{Requeue: true}, true, nil
+}
+
+// promoteCanary promote the canary Deployment to the next step.
+func (handler *BootHandler) promoteCanary(canaryFound *appsv1.Deployment, revisionId string) (reconcile.Result, bool, error) {
+	step := canaryStep(canaryFound) + 1
+	expectDeploy := handler.NewCanaryDeployment(revisionId, step)
+
+	result, rollingOut, err := handler.updateCanary(canaryFound, expectDeploy)
+	if err != nil {
+		return result, rollingOut, err
+	}
+
+	steps := len(CanarySteps(BootStrategy(handler.Boot, handler.Config.AppSpec).Canary))
+	msg := fmt.Sprintf("Promoted canary to step %d/%d", step, steps)
+	if step >= steps {
+		msg = "Promoted canary, updating the stable Deployment"
+	}
+	handler.RecordEvent(keys.PromotedCanary, msg, nil)
+	return result, rollingOut, nil
+}
+
+// updateCanary update the canary Deployment, the spec and annotations are replaced by the expected Deployment if not nil.
+// The canary Deployment's selector is kept, because it is immutable.
+func (handler *BootHandler) updateCanary(canaryFound *appsv1.Deployment, expectDeploy *appsv1.Deployment) (reconcile.Result, bool, error) {
+	boot := handler.Boot
+	logger := handler.Logger
+	c := handler.Client
+
+	if expectDeploy != nil {
+		selector := canaryFound.Spec.Selector
+		canaryFound.Spec = expectDeploy.Spec
+		canaryFound.Spec.Selector = selector
+		canaryFound.Annotations = expectDeploy.Annotations
+	}
+
+	err := c.Update(context.TODO(), canaryFound)
+	if err != nil {
+		msg := fmt.Sprintf("Failed to update canary Deployment: %s", canaryFound.Name)
+		logger.Info(msg, "err", err.Error())
+		loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_UPDATE_STAGE, loganMetrics.RECONCILE_UPDATE_CANARY_SUBSTAGE, boot.Name)
+		handler.RecordEvent(keys.FailedUpdateDeployment, msg, err)
+		return reconcile.Result{Requeue: true}, true, err
+	}
+
+	handler.RecordEvent(keys.UpdatedDeployment, fmt.Sprintf("Updated canary Deployment: %s", canaryFound.Name), nil)
+	return reconcile.Result{Requeue: true}, true, nil
+}
+
+// deleteCanary delete the canary Deployment.
+func (handler *BootHandler) deleteCanary(canaryFound *appsv1.Deployment) (reconcile.Result, bool, error) {
+	boot := handler.Boot
+	logger := handler.Logger
+	c := handler.Client
+
+	logger.Info("Deleting canary Deployment", "deploy", canaryFound.Name)
+	err := c.Delete(context.TODO(), canaryFound)
+	if err != nil && !errors.IsNotFound(err) {
+		msg := fmt.Sprintf("Failed to delete canary Deployment: %s", canaryFound.Name)
+		logger.Error(err, msg)
+		loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_UPDATE_STAGE, loganMetrics.RECONCILE_DELETE_CANARY_SUBSTAGE, boot.Name)
+		handler.RecordEvent(keys.FailedDeleteDeployment, msg, err)
+		return reconcile.Result{Requeue: true}, false, err
+	}
+
+	handler.RecordEvent(keys.DeletedDeployment, fmt.Sprintf("Deleted canary Deployment: %s", canaryFound.Name), nil)
+	return reconcile.Result{Requeue: true}, false, nil
+}
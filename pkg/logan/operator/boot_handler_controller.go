@@ -9,10 +9,10 @@ import (
 	"github.com/logancloud/logan-app-operator/pkg/logan/util/keys"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/apimachinery/pkg/util/intstr"
 	"reflect"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -23,39 +23,67 @@ import (
 
 // ReconcileCreate check the existence of components, if not exist, create new one.
 // 1. Deployment not found: Create Deployment, requeue=true
+// 1.1 StatefulSet not found and workloadType is StatefulSet: Create StatefulSet instead of Deployment, requeue=true
+// 1.2 Job or CronJob not found and workloadType is Job or CronJob: Create it only, requeue=true
 // 2. Service not found: Create Service, requeue=true
-// 3. When creating Error: requeue error requeue=true
+// 3. HorizontalPodAutoscaler not found and autoscaling enabled: Create HorizontalPodAutoscaler, requeue=true
+// 4. Ingress not found and subDomain is set: Create Ingress, requeue=true
+// 5. When creating Error: requeue error requeue=true
 func (handler *BootHandler) ReconcileCreate() (reconcile.Result, bool, error) {
 	boot := handler.Boot
 	logger := handler.Logger
 	c := handler.Client
 	requeue := false
 
+	// The Job and CronJob run to completion, no Service, HorizontalPodAutoscaler or Ingress is created.
+	if BatchEnabled(boot) {
+		created, err := handler.reconcileCreateJob()
+		if err != nil {
+			return reconcile.Result{}, true, err
+		}
+		return reconcile.Result{Requeue: created}, created, nil
+	}
+
+	var err error
 	depFound := &appsv1.Deployment{}
-	depName := DeployName(boot)
-	err := c.Get(context.TODO(), types.NamespacedName{Name: depName, Namespace: boot.Namespace}, depFound)
-	if err != nil && errors.IsNotFound(err) {
-		if errors.IsNotFound(err) {
-			dep := handler.NewDeployment()
-			logger.Info("Creating Deployment", "deploy containers", dep.Spec.Template.Spec.Containers)
-			err = c.Create(context.TODO(), dep)
-			if err != nil {
-				msg := fmt.Sprintf("Failed to create Deployment: %s", depName)
+	if StatefulSetEnabled(boot) {
+		var created bool
+		depFound, created, err = handler.reconcileCreateStatefulSet()
+		if err != nil {
+			return reconcile.Result{}, true, err
+		}
+		if created {
+			requeue = true
+		}
+	} else {
+		depName := DeployName(boot)
+		err = c.Get(context.TODO(), types.NamespacedName{Name: depName, Namespace: boot.Namespace}, depFound)
+		if err != nil && errors.IsNotFound(err) {
+			if errors.IsNotFound(err) {
+				dep := handler.NewDeployment()
+				if BlueGreenEnabled(boot, handler.Config.AppSpec) {
+					dep = handler.NewColorDeployment(BlueColor, handler.latestRevisionId())
+				}
+				logger.Info("Creating Deployment", "deploy containers", dep.Spec.Template.Spec.Containers)
+				err = c.Create(context.TODO(), dep)
+				if err != nil {
+					msg := fmt.Sprintf("Failed to create Deployment: %s", depName)
+					logger.Error(err, msg)
+					loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_CREATE_STAGE, loganMetrics.RECONCILE_CREATE_DEPLOYMENT_SUBSTAGE, boot.Name)
+					handler.RecordEvent(keys.FailedCreateDeployment, msg, err)
+					return reconcile.Result{}, true, err
+				}
+
+				handler.RecordEvent(keys.CreatedDeployment, fmt.Sprintf("Created Deployment: %s", depName), nil)
+				depFound = dep
+				requeue = true
+			} else {
+				msg := fmt.Sprintf("Failed to get Deployment: %s", depName)
 				logger.Error(err, msg)
-				loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_CREATE_STAGE, loganMetrics.RECONCILE_CREATE_DEPLOYMENT_SUBSTAGE, boot.Name)
-				handler.RecordEvent(keys.FailedCreateDeployment, msg, err)
+				loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_CREATE_STAGE, loganMetrics.RECONCILE_GET_DEPLOYMENT_SUBSTAGE, boot.Name)
+				handler.RecordEvent(keys.FailedGetDeployment, msg, err)
 				return reconcile.Result{}, true, err
 			}
-
-			handler.RecordEvent(keys.CreatedDeployment, fmt.Sprintf("Created Deployment: %s", depName), nil)
-			depFound = dep
-			requeue = true
-		} else {
-			msg := fmt.Sprintf("Failed to get Deployment: %s", depName)
-			logger.Error(err, msg)
-			loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_CREATE_STAGE, loganMetrics.RECONCILE_GET_DEPLOYMENT_SUBSTAGE, boot.Name)
-			handler.RecordEvent(keys.FailedGetDeployment, msg, err)
-			return reconcile.Result{}, true, err
 		}
 	}
 
@@ -90,34 +118,83 @@ func (handler *BootHandler) ReconcileCreate() (reconcile.Result, bool, error) {
 			return reconcile.Result{}, true, err
 		}
 	}
+
+	// HorizontalPodAutoscaler is only created when autoscaling is enabled.
+	created, err := handler.reconcileCreateAutoscaler()
+	if err != nil {
+		return reconcile.Result{}, true, err
+	}
+	if created {
+		requeue = true
+	}
+
+	// Ingress is only created when subDomain is set.
+	created, err = handler.reconcileCreateIngress()
+	if err != nil {
+		return reconcile.Result{}, true, err
+	}
+	if created {
+		requeue = true
+	}
+
 	// requeue the reconcile, if create deploy and service, k8s need sometime to create it.
 	return reconcile.Result{Requeue: requeue}, requeue, nil
 }
 
 // ReconcileUpdate check the fields of components, if not as desire, update it.
+// 0. Check restart schedule: set the Boot's restartedAt annotation when the schedule fired
 // 1. Check Deployment's existence: error -> requeue=true
 // 1.1. Check Deployment's fields: "replicas", image, env, port, resources, health, nodeSelector
+// 1.2. Check StatefulSet instead of Deployment if workloadType is StatefulSet: "replicas", pod template
+// 1.3. Check Job or CronJob only if workloadType is Job or CronJob: spec
 // 2. Check Service's existence: error -> requeue=true
 // 2.1 Check Service's fields:
+// 3. Check HorizontalPodAutoscaler: create/update/delete by Boot's autoscaling
+// 4. Check Ingress: create/update/delete by Boot's subDomain
 func (handler *BootHandler) ReconcileUpdate() (reconcile.Result, bool, error) {
 	boot := handler.Boot
 	logger := handler.Logger
 	c := handler.Client
 
-	//1 Deployment
-	depFound := &appsv1.Deployment{}
-	depName := DeployName(boot)
-	err := c.Get(context.TODO(), types.NamespacedName{Name: depName, Namespace: boot.Namespace}, depFound)
-	if err != nil {
-		logger.Error(err, "Failed to get Deployment")
-		loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_UPDATE_STAGE, loganMetrics.RECONCILE_GET_DEPLOYMENT_SUBSTAGE, boot.Name)
-		return reconcile.Result{Requeue: true}, true, err
+	// The Job is run again when changed, the CronJob is updated in place.
+	if BatchEnabled(boot) {
+		return handler.reconcileUpdateJob()
 	}
-	result, requeue, err := handler.reconcileUpdateDeploy(depFound)
+
+	//0 Restart schedule
+	result, requeue, err := handler.ReconcileScheduledRestart()
 	if requeue {
 		return result, true, err
 	}
 
+	//1 Deployment, or StatefulSet if workloadType is StatefulSet
+	var deployResult reconcile.Result
+	depFound := &appsv1.Deployment{}
+	if StatefulSetEnabled(boot) {
+		depFound, deployResult, requeue, err = handler.reconcileUpdateStatefulSet()
+		if requeue {
+			return deployResult, true, err
+		}
+	} else {
+		depName := DeployName(boot)
+		err = c.Get(context.TODO(), types.NamespacedName{Name: depName, Namespace: boot.Namespace}, depFound)
+		if err != nil {
+			logger.Error(err, "Failed to get Deployment")
+			loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_UPDATE_STAGE, loganMetrics.RECONCILE_GET_DEPLOYMENT_SUBSTAGE, boot.Name)
+			return reconcile.Result{Requeue: true}, true, err
+		}
+		deployResult, requeue, err = handler.reconcileUpdateDeploy(depFound)
+		if requeue {
+			return deployResult, true, err
+		}
+	}
+
+	// In blue-green rollout, the Services select the pods of the active color.
+	activeDeploy, err := handler.ActiveDeployment(depFound, loganMetrics.RECONCILE_UPDATE_STAGE)
+	if err != nil {
+		return reconcile.Result{Requeue: true}, true, err
+	}
+
 	//2 Service
 	appSvcFound := &corev1.Service{}
 	appSvcName := boot.Name
@@ -131,12 +208,26 @@ func (handler *BootHandler) ReconcileUpdate() (reconcile.Result, bool, error) {
 		logger.Error(err, "Failed to get Service")
 		return reconcile.Result{Requeue: true}, true, err
 	}
-	result, requeue, err = handler.reconcileUpdateService(appSvcFound, depFound)
+	result, requeue, err = handler.reconcileUpdateService(appSvcFound, activeDeploy)
 	if requeue {
 		return result, true, err
 	}
 
-	return reconcile.Result{}, false, nil
+	//3 HorizontalPodAutoscaler
+	result, requeue, err = handler.reconcileUpdateAutoscaler()
+	if requeue {
+		return result, true, err
+	}
+
+	//4 Ingress
+	result, requeue, err = handler.reconcileUpdateIngress()
+	if requeue {
+		return result, true, err
+	}
+
+	// Requeue after the delay requested by the rollout, such as canary's bake time and blue-green's scale down delay.
+	// Requeue at the next boundary of the scaling schedules and the restart schedule.
+	return handler.scheduleResult(deployResult), false, nil
 }
 
 // reconcileUpdateDeploy handle update logic of Deployment
@@ -160,8 +251,14 @@ func (handler *BootHandler) reconcileUpdateDeploy(deploy *appsv1.Deployment) (re
 		updated = true
 	}
 
-	// 2. Check size
-	size := boot.Spec.Replicas
+	// 1.1 Check blue-green: the Boot's spec is rolled out to the other color's Deployment, and switched to after ready.
+	if BlueGreenEnabled(boot, handler.Config.AppSpec) {
+		return handler.reconcileBlueGreen(deploy, updated)
+	}
+
+	// 2. Check size, the active scaling schedule's replicas overrides the Boot's.
+	// If autoscaling is enabled, the Boot's replicas is scaled by HorizontalPodAutoscaler through the Boot's scale subresource.
+	size := DeployReplicas(boot)
 	if *deploy.Spec.Replicas != *size {
 		logger.Info(reason, "type", "replicas", "deploy", deploy.Name,
 			"old", deploy.Spec.Replicas, "new", size)
@@ -170,6 +267,11 @@ func (handler *BootHandler) reconcileUpdateDeploy(deploy *appsv1.Deployment) (re
 		updated = true
 	}
 
+	// 2.1 Check strategy: updated in place, the Deployment's spec is not replaced.
+	if handler.reconcileUpdateStrategy(deploy) {
+		updated = true
+	}
+
 	// "spec.template.spec.containers" is a required value, no need to verify.
 	// 3. Check image and version:
 	deployImg := deploy.Spec.Template.Spec.Containers[0].Image
@@ -182,8 +284,9 @@ func (handler *BootHandler) reconcileUpdateDeploy(deploy *appsv1.Deployment) (re
 	}
 
 	// 4. Check env: check fist container(boot container)
+	// The JVM flags are computed from the resources, so they are re-evaluated when the resources change.
 	deployEnv := deploy.Spec.Template.Spec.Containers[0].Env
-	bootEnv := boot.Spec.Env
+	bootEnv := AppContainerEnv(boot, handler.Config.AppSpec)
 	if !reflect.DeepEqual(deployEnv, bootEnv) {
 		logger.Info(reason, "type", "env", "deploy", deploy.Name,
 			"old", deployEnv, "new", bootEnv)
@@ -193,7 +296,7 @@ func (handler *BootHandler) reconcileUpdateDeploy(deploy *appsv1.Deployment) (re
 
 	// 5. Check port: check fist container(boot container)
 	deployPorts := deploy.Spec.Template.Spec.Containers[0].Ports
-	bootPorts := []corev1.ContainerPort{{Name: HttpPortName, ContainerPort: boot.Spec.Port, Protocol: corev1.ProtocolTCP}}
+	bootPorts := AppContainerPorts(boot)
 	if !reflect.DeepEqual(deployPorts, bootPorts) {
 		logger.Info(reason, "type", "port", "deploy", deploy.Name,
 			"old", deployPorts, "new", bootPorts)
@@ -212,73 +315,26 @@ func (handler *BootHandler) reconcileUpdateDeploy(deploy *appsv1.Deployment) (re
 	}
 
 	// 7 Check liveness and readiness : check fist container(boot container)
+	// Compare the full probe: handler, port, delays and thresholds.
+	expectContainer := handler.NewAppContainer()
 	// 7.1 Check liveness
 	livenessProbe := deploy.Spec.Template.Spec.Containers[0].LivenessProbe
-	bootHealth := *boot.Spec.Health
-	if bootHealth == "" {
-		if livenessProbe != nil {
-			// Remove the 2 existing probes.
-			deployHealth := livenessProbe.HTTPGet.Path
-			logger.Info(reason, "type", "health", "deploy", deploy.Name,
-				"old", deployHealth, "new", "")
-
-			rebootUpdated = true
-		}
-	} else {
-		if livenessProbe == nil {
-			// 1. If probe is nil, add Liveness and Readiness
-			logger.Info(reason, "type", "health", "deploy", deploy.Name,
-				"old", "empty", "new", bootHealth)
+	bootLiveness := expectContainer.LivenessProbe
+	if !equality.Semantic.DeepEqual(livenessProbe, bootLiveness) {
+		logger.Info(reason, "type", "liveness", "deploy", deploy.Name,
+			"old", livenessProbe, "new", bootLiveness)
 
-			rebootUpdated = true
-		} else {
-			deployHealth := livenessProbe.HTTPGet.Path
-			// 2. If livenessProbe is not nil, we only need to update the health path
-			if deployHealth != bootHealth {
-				logger.Info(reason, "type", "health", "deploy", deploy.Name,
-					"old", deployHealth, "new", bootHealth)
-
-				rebootUpdated = true
-			}
-		}
+		rebootUpdated = true
 	}
 
 	// 7.2 Check readiness
 	readinessProbe := deploy.Spec.Template.Spec.Containers[0].ReadinessProbe
-	readinessPath := *boot.Spec.Health
-
-	// if boot.Spec.Health is empty, ignore the Readiness
-	if boot.Spec.Readiness != nil && *boot.Spec.Readiness != "" &&
-		boot.Spec.Health != nil && *boot.Spec.Health != "" {
-		readinessPath = *boot.Spec.Readiness
-	}
-
-	if readinessPath == "" {
-		if readinessProbe != nil {
-			// Remove the 2 existing probes.
-			deployHealth := readinessProbe.HTTPGet.Path
-			logger.Info(reason, "type", "readiness", "deploy", deploy.Name,
-				"old", deployHealth, "new", "")
-
-			rebootUpdated = true
-		}
-	} else {
-		if readinessProbe == nil {
-			// 1. If probe is nil, add  Readiness
-			logger.Info(reason, "type", "readiness", "deploy", deploy.Name,
-				"old", "empty", "new", readinessPath)
-
-			rebootUpdated = true
-		} else {
-			deployHealth := readinessProbe.HTTPGet.Path
-			// 2. If readinessProbe is not nil, we only need to update the readiness path
-			if deployHealth != readinessPath {
-				logger.Info(reason, "type", "readiness", "deploy", deploy.Name,
-					"old", deployHealth, "new", readinessPath)
+	bootReadiness := expectContainer.ReadinessProbe
+	if !equality.Semantic.DeepEqual(readinessProbe, bootReadiness) {
+		logger.Info(reason, "type", "readiness", "deploy", deploy.Name,
+			"old", readinessProbe, "new", bootReadiness)
 
-				rebootUpdated = true
-			}
-		}
+		rebootUpdated = true
 	}
 
 	// 8 Check nodeSelector: map[string]string
@@ -291,6 +347,37 @@ func (handler *BootHandler) reconcileUpdateDeploy(deploy *appsv1.Deployment) (re
 		rebootUpdated = true
 	}
 
+	// 8.1 Check scheduling: affinity, tolerations, priorityClassName, runtimeClassName
+	deployPodSpec := deploy.Spec.Template.Spec
+	expectPodSpec := handler.NewDeployment().Spec.Template.Spec
+	if !equality.Semantic.DeepEqual(deployPodSpec.Affinity, expectPodSpec.Affinity) {
+		logger.Info(reason, "type", "affinity", "deploy", deploy.Name,
+			"old", deployPodSpec.Affinity, "new", expectPodSpec.Affinity)
+
+		rebootUpdated = true
+	}
+
+	if !equality.Semantic.DeepEqual(deployPodSpec.Tolerations, expectPodSpec.Tolerations) {
+		logger.Info(reason, "type", "tolerations", "deploy", deploy.Name,
+			"old", deployPodSpec.Tolerations, "new", expectPodSpec.Tolerations)
+
+		rebootUpdated = true
+	}
+
+	if deployPodSpec.PriorityClassName != expectPodSpec.PriorityClassName {
+		logger.Info(reason, "type", "priorityClassName", "deploy", deploy.Name,
+			"old", deployPodSpec.PriorityClassName, "new", expectPodSpec.PriorityClassName)
+
+		rebootUpdated = true
+	}
+
+	if !equality.Semantic.DeepEqual(deployPodSpec.RuntimeClassName, expectPodSpec.RuntimeClassName) {
+		logger.Info(reason, "type", "runtimeClassName", "deploy", deploy.Name,
+			"old", deployPodSpec.RuntimeClassName, "new", expectPodSpec.RuntimeClassName)
+
+		rebootUpdated = true
+	}
+
 	// 9 Check command
 	deployCommand := deploy.Spec.Template.Spec.Containers[0].Command
 	bootCommand := boot.Spec.Command
@@ -350,6 +437,15 @@ func (handler *BootHandler) reconcileUpdateDeploy(deploy *appsv1.Deployment) (re
 		}
 	}
 
+	// 12 Check canary: the Boot's spec is rolled out to the canary Deployment, the stable Deployment is kept until promoted.
+	canaryResult, canaryRollingOut, err := handler.reconcileCanary(deploy, rebootUpdated)
+	if err != nil {
+		return reconcile.Result{Requeue: true}, true, err
+	}
+	if canaryRollingOut {
+		rebootUpdated = false
+	}
+
 	if rebootUpdated {
 		updateDeploy := handler.NewDeployment()
 		deploy.Spec = updateDeploy.Spec
@@ -371,7 +467,17 @@ func (handler *BootHandler) reconcileUpdateDeploy(deploy *appsv1.Deployment) (re
 		return reconcile.Result{Requeue: true}, true, nil
 	}
 
-	return reconcile.Result{}, false, nil
+	if canaryResult.Requeue {
+		return canaryResult, true, nil
+	}
+
+	// 13 Check blue-green is disabled: switch the Services back to the Deployment, and delete the green Deployment.
+	result, requeue, err := handler.reconcileBlueGreenDisabled(deploy)
+	if requeue {
+		return result, true, err
+	}
+
+	return canaryResult, false, nil
 }
 
 // reconcileUpdateService handle update logic/sidecar/nodePort of Service
@@ -394,38 +500,36 @@ func (handler *BootHandler) reconcileUpdateService(svc *corev1.Service, deploy *
 		updated = true
 	}
 
-	// 2. Check port
-	svcPort := svc.Spec.Ports[0].Port
-	bootPort := boot.Spec.Port
-	// Port changed
-	if !(svcPort == bootPort) {
-		logger.Info(reason, "type", "port", "service", svc.Name, "old", svcPort, "new", bootPort)
-		svc.Spec.Ports[0].Port = bootPort
-		svc.Spec.Ports[0].TargetPort = intstr.IntOrString{Type: intstr.Int, IntVal: int32(bootPort)}
+	// 2. Check ports: name, port, targetPort, protocol
+	svcPorts := svc.Spec.Ports
+	bootPorts := AppServicePorts(boot)
+	if !servicePortsEqual(svcPorts, bootPorts) {
+		logger.Info(reason, "type", "ports", "service", svc.Name, "old", svcPorts, "new", bootPorts)
+		svc.Spec.Ports = bootPorts
 
 		updated = true
 	}
 
-	// 3. Check annotation
-	// Annotation is removed
+	// 3. Check annotation: prometheus and app protocols annotations, others are kept.
 	prometheusScrape := allowPrometheusScrape(boot, handler.Config.AppSpec)
-	if svc.Annotations == nil && prometheusScrape == true {
-		svc.Annotations = ServiceAnnotation(prometheusScrape, int(boot.Spec.Port))
-		updated = true
-	}
+	bootAnnotations := AppServiceAnnotation(boot, prometheusScrape)
+	for _, key := range managedServiceAnnotationKeys {
+		svcValue, svcFound := svc.Annotations[key]
+		bootValue, bootFound := bootAnnotations[key]
+		if svcFound == bootFound && svcValue == bootValue {
+			continue
+		}
 
-	// Annotation port is changed
-	if svc.Annotations != nil {
-		svcAnnoPort := svc.Annotations[keys.PrometheusPortAnnotationKey]
-		if svcAnnoPort != strconv.Itoa(int(boot.Spec.Port)) {
-			svc.Annotations[keys.PrometheusPortAnnotationKey] = strconv.Itoa(int(boot.Spec.Port))
-			updated = true
+		logger.Info(reason, "type", "annotation", "service", svc.Name, "key", key, "old", svcValue, "new", bootValue)
+		if bootFound {
+			if svc.Annotations == nil {
+				svc.Annotations = make(map[string]string)
+			}
+			svc.Annotations[key] = bootValue
+		} else {
+			delete(svc.Annotations, key)
 		}
-	}
 
-	//PrometheusScrape is changed
-	if svc.Annotations != nil && prometheusScrape == false {
-		svc.Annotations = ServiceAnnotation(prometheusScrape, int(boot.Spec.Port))
 		updated = true
 	}
 
@@ -552,27 +656,35 @@ func (handler *BootHandler) reconcileUpdateOtherService(deploy *appsv1.Deploymen
 				found = true
 
 				// 1. check ports
-				// port\name
-				runtimePort := runtimeSvc.Spec.Ports[0]
-				expectPort := expectSvc.Spec.Ports[0]
-				if runtimePort.Name != expectPort.Name ||
-					!reflect.DeepEqual(runtimePort.TargetPort, expectPort.TargetPort) {
+				// ports count changed, NodePort will be allocated by kubernetes
+				if len(runtimeSvc.Spec.Ports) != len(expectSvc.Spec.Ports) {
 					modify = true
 					runtimeSvc.Spec.Ports = expectSvc.Spec.Ports
 				}
 
-				if runtimeSvc.Spec.Type == corev1.ServiceTypeClusterIP &&
-					runtimePort.Port != expectPort.Port {
-					modify = true
-					runtimeSvc.Spec.Ports[0].Port = expectPort.Port
-				}
+				for i := range runtimeSvc.Spec.Ports {
+					// port\name
+					runtimePort := runtimeSvc.Spec.Ports[i]
+					expectPort := expectSvc.Spec.Ports[i]
+					if runtimePort.Name != expectPort.Name ||
+						!reflect.DeepEqual(runtimePort.TargetPort, expectPort.TargetPort) {
+						modify = true
+						runtimeSvc.Spec.Ports[i] = expectPort
+					}
 
-				// make sure Port equal to NodePort
-				if runtimeSvc.Spec.Type == corev1.ServiceTypeNodePort &&
-					runtimePort.NodePort != runtimePort.Port {
-					modify = true
-					runtimeSvc.Spec.Ports[0].Port = runtimePort.NodePort
-					runtimeSvc.Spec.Ports[0].NodePort = runtimePort.NodePort
+					if runtimeSvc.Spec.Type == corev1.ServiceTypeClusterIP &&
+						runtimePort.Port != expectPort.Port {
+						modify = true
+						runtimeSvc.Spec.Ports[i].Port = expectPort.Port
+					}
+
+					// make sure Port equal to NodePort
+					if runtimeSvc.Spec.Type == corev1.ServiceTypeNodePort &&
+						runtimePort.NodePort > 0 && runtimePort.NodePort != runtimePort.Port {
+						modify = true
+						runtimeSvc.Spec.Ports[i].Port = runtimePort.NodePort
+						runtimeSvc.Spec.Ports[i].NodePort = runtimePort.NodePort
+					}
 				}
 
 				// 2. check OwnerReferences
@@ -699,13 +811,15 @@ func (handler *BootHandler) ReconcileUpdateBootMeta() (reconcile.Result, bool, b
 	boot := handler.Boot
 	c := handler.Client
 
-	// 1. Update Deployment's metadata/annotations if needed
-	depFound := &appsv1.Deployment{}
-	depName := DeployName(boot)
-	err := c.Get(context.TODO(), types.NamespacedName{Name: depName, Namespace: boot.Namespace}, depFound)
+	if BatchEnabled(boot) {
+		return handler.reconcileUpdateJobMeta()
+	}
+
+	// 1. Update Deployment's metadata/annotations if needed, the StatefulSet is observed as a Deployment.
+	depFound, err := handler.getWorkload()
 	if err != nil {
-		logger.Error(err, "Failed to get Deployment")
-		loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_UPDATE_BOOT_META_STAGE, loganMetrics.RECONCILE_GET_DEPLOYMENT_SUBSTAGE, boot.Name)
+		logger.Error(err, "Failed to get workload", "type", WorkloadAppType(boot))
+		loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_UPDATE_BOOT_META_STAGE, workloadGetSubstage(boot), boot.Name)
 		return reconcile.Result{}, true, false, err
 	}
 
@@ -718,7 +832,7 @@ func (handler *BootHandler) ReconcileUpdateBootMeta() (reconcile.Result, bool, b
 	}
 
 	// 3. Update Boot's annotation if needed.
-	// 3.1 Update Boot's annotation StatusAvailable
+	// 3.1 List Boot's pods, which are only used by the auto-rollback to find the failed pods of the latest revision.
 	podList := &corev1.PodList{}
 	podLabels := PodLabels(boot)
 	labelSelector := labels.SelectorFromSet(podLabels)
@@ -730,6 +844,12 @@ func (handler *BootHandler) ReconcileUpdateBootMeta() (reconcile.Result, bool, b
 		return reconcile.Result{}, true, false, err
 	}
 
+	// In blue-green rollout, the Boot's state is observed from the active color's Deployment.
+	depFound, err = handler.ActiveDeployment(depFound, loganMetrics.RECONCILE_UPDATE_BOOT_META_STAGE)
+	if err != nil {
+		return reconcile.Result{}, true, false, err
+	}
+
 	runningCount := depFound.Status.Replicas
 	//for _, pod := range podList.Items {
 	//	podStatus := pod.Status.Phase
@@ -745,13 +865,30 @@ func (handler *BootHandler) ReconcileUpdateBootMeta() (reconcile.Result, bool, b
 
 	// 3.2.1 Update Boot's revison's annotation
 	//    set the latest revison's phase to active
+	//    the latest revision is running during the canary rollout, or before blue-green switched to it
+	canaryFound, _ := handler.getCanaryDeployment(loganMetrics.RECONCILE_UPDATE_BOOT_META_STAGE)
+	rolloutDeploy, switched := depFound, true
+	if canaryFound != nil {
+		rolloutDeploy = canaryFound
+	} else if BlueGreenEnabled(boot, handler.Config.AppSpec) {
+		rolloutDeploy, switched = handler.blueGreenRollout(depFound, loganMetrics.RECONCILE_UPDATE_BOOT_META_STAGE)
+	}
 	revisionAnnotationMap := map[string]string{}
-	if runningCount == *boot.Spec.Replicas && runningCount == depFound.Status.AvailableReplicas {
+	if switched && BlueGreenEnabled(boot, handler.Config.AppSpec) {
+		revisionAnnotationMap[keys.BlueGreenColorAnnotationKey] = DeploymentColor(boot, depFound)
+	}
+	if canaryFound == nil && switched && runningCount == DesiredReplicas(boot) && runningCount == depFound.Status.AvailableReplicas {
 		revisionAnnotationMap[keys.BootRevisionPhaseAnnotationKey] = RevisionPhaseActive
 	} else {
 		revisionAnnotationMap[keys.BootRevisionPhaseAnnotationKey] = RevisionPhaseRunning
 	}
 
+	// 3.2.2 Roll back automatically if the latest revision failed to become Active
+	rollbackUpdated := false
+	if latestRevision != nil {
+		rollbackUpdated = handler.reconcileAutoRollback(rolloutDeploy, podList.Items, revisionLst, latestRevision, revisionAnnotationMap)
+	}
+
 	if latestRevision != nil {
 		revisionUpdated := updateRevisionAnnotation(latestRevision, revisionAnnotationMap)
 		if revisionUpdated {
@@ -777,12 +914,13 @@ func (handler *BootHandler) ReconcileUpdateBootMeta() (reconcile.Result, bool, b
 	//}
 
 	// 4 Update boot Meta
+	// Pods count is written through the status subresource by ReconcileUpdateBootStatus,
+	// only keep the annotations which are not changed on every pass.
+	// The revision id annotation still bumps the Boot's resourceVersion once per revision.
 	annotationMap := map[string]string{
-		keys.DeployAnnotationKey:          depFound.Name,
-		keys.AppTypeAnnotationKey:         keys.AppTypeAnnotationDeploy,
-		keys.ServicesAnnotationKey:        TransferServiceNames(svcList.Items),
-		keys.StatusAvailableAnnotationKey: strconv.Itoa(int(runningCount)),
-		keys.StatusDesiredAnnotationKey:   strconv.Itoa(int(*boot.Spec.Replicas)),
+		keys.DeployAnnotationKey:   depFound.Name,
+		keys.AppTypeAnnotationKey:  WorkloadAppType(boot),
+		keys.ServicesAnnotationKey: TransferServiceNames(svcList.Items),
 	}
 
 	if latestRevision != nil {
@@ -790,6 +928,7 @@ func (handler *BootHandler) ReconcileUpdateBootMeta() (reconcile.Result, bool, b
 	}
 
 	updated := handler.UpdateAnnotation(annotationMap)
+	updated = updated || rollbackUpdated
 
 	//if requeue {
 	//	return reconcile.Result{RequeueAfter: time.Second * 10}, true, updated, nil
@@ -5,6 +5,7 @@ import (
 	"github.com/logancloud/logan-app-operator/pkg/logan/util"
 	"github.com/logancloud/logan-app-operator/pkg/logan/util/keys"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"strconv"
 )
 
@@ -22,6 +23,28 @@ func (handler *BootHandler) DefaultValue() bool {
 		return false
 	}
 
+	//ports: set default servicePort and protocol, Port is the containerPort of the primary port.
+	if len(bootSpec.Ports) > 0 {
+		for i := range bootSpec.Ports {
+			port := &bootSpec.Ports[i]
+			if port.ServicePort <= 0 {
+				port.ServicePort = port.ContainerPort
+				changed = true
+			}
+			if port.Protocol == "" {
+				port.Protocol = corev1.ProtocolTCP
+				changed = true
+			}
+		}
+
+		primaryPort := PrimaryPort(&appv1.Boot{Spec: *bootSpec})
+		if bootSpec.Port != primaryPort.ContainerPort {
+			logger.Info("Defaulters", "type", "port", "spec", bootSpec.Port, "default", primaryPort.ContainerPort)
+			bootSpec.Port = primaryPort.ContainerPort
+			changed = true
+		}
+	}
+
 	//port
 	if bootSpec.Port <= 0 && appConfigSpec.Port > 0 {
 		logger.Info("Defaulters", "type", "port", "spec", bootSpec.Port, "default", appConfigSpec.Port)
@@ -144,8 +167,8 @@ func (handler *BootHandler) DefaultValue() bool {
 		changed = true
 	}
 
-	//subDomain:
-	if bootSpec.SubDomain == "" && appConfigSpec.SubDomain != "" {
+	//subDomain: the Job and CronJob have no Service and Ingress.
+	if bootSpec.SubDomain == "" && appConfigSpec.SubDomain != "" && !BatchEnabled(handler.Boot) {
 		logger.Info("Defaulters", "type", "subDomain", "spec", bootSpec.SubDomain, "default", appConfigSpec.SubDomain)
 		bootSpec.SubDomain = appConfigSpec.SubDomain
 		changed = true
@@ -181,11 +204,119 @@ func (handler *BootHandler) DefaultValue() bool {
 		}
 	}
 
+	//scheduling: tolerations, nodeAffinity, topologySpread, priorityClassName, runtimeClassName
+	if handler.DefaultSchedulingValue() {
+		changed = true
+	}
+
+	//autoscaling: the Job and CronJob are not scaled.
+	if !BatchEnabled(handler.Boot) && handler.DefaultAutoscalingValue() {
+		changed = true
+	}
+
+	//cpu envs: before the envs are saved into annotation, the cpu limits could be added after created.
+	cpuEnvChanged := handler.DefaultCPUEnvValue()
+
 	envChanged := handler.DefaultEnvValue()
 
 	pvcChanged := handler.DefaultPvcValue()
 
-	return changed || envChanged || pvcChanged
+	return changed || cpuEnvChanged || envChanged || pvcChanged
+}
+
+// DefaultCPUEnvValue will add the runtime's processor count envs of the Boot's type, as GOMAXPROCS,
+// derived from the app container's cpu limits by the downward API, which is rounded up to an integer.
+// The envs are added only if the Boot has cpu limits and does not specify them.
+// Return true if should be updated, false if should not be updated
+func (handler *BootHandler) DefaultCPUEnvValue() bool {
+	bootSpec := handler.OperatorSpec
+	bootType := appv1.GetBootTypeByConfigKey(handler.Boot.BootType)
+	if bootType == nil || len(bootType.CPUEnvs) == 0 {
+		return false
+	}
+
+	if _, ok := bootSpec.Resources.Limits[corev1.ResourceCPU]; !ok {
+		return false
+	}
+
+	changed := false
+	for _, name := range bootType.CPUEnvs {
+		found := false
+		for _, env := range bootSpec.Env {
+			if env.Name == name {
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+
+		handler.Logger.Info("Defaulters", "type", "env", "cpu", name)
+		bootSpec.Env = append(bootSpec.Env, corev1.EnvVar{
+			Name: name,
+			ValueFrom: &corev1.EnvVarSource{
+				ResourceFieldRef: &corev1.ResourceFieldSelector{
+					Resource: "limits.cpu",
+					Divisor:  resource.MustParse("1"),
+				},
+			},
+		})
+		changed = true
+	}
+
+	return changed
+}
+
+// DefaultAutoscalingValue will set the default autoscaling from config.
+// If Boot do not specify the autoscaling, use the config's autoscaling, otherwise only fill the empty fields.
+// Return true if should be updated, false if should not be updated
+func (handler *BootHandler) DefaultAutoscalingValue() bool {
+	logger := handler.Logger
+	configAutoscaling := handler.Config.AppSpec.Autoscaling
+	bootSpec := handler.OperatorSpec
+
+	if configAutoscaling == nil {
+		return false
+	}
+
+	if bootSpec.Autoscaling == nil {
+		logger.Info("Defaulters", "type", "autoscaling", "spec", nil, "default", configAutoscaling)
+		bootSpec.Autoscaling = configAutoscaling.DeepCopy()
+		return true
+	}
+
+	changed := false
+	bootAutoscaling := bootSpec.Autoscaling
+	if bootAutoscaling.MinReplicas == nil && configAutoscaling.MinReplicas != nil {
+		minReplicas := *configAutoscaling.MinReplicas
+		bootAutoscaling.MinReplicas = &minReplicas
+		changed = true
+	}
+
+	if bootAutoscaling.MaxReplicas <= 0 && configAutoscaling.MaxReplicas > 0 {
+		bootAutoscaling.MaxReplicas = configAutoscaling.MaxReplicas
+		changed = true
+	}
+
+	// Metric targets are used as a whole, only set when Boot do not specify any target.
+	if bootAutoscaling.TargetCPUUtilizationPercentage == nil &&
+		bootAutoscaling.TargetMemoryUtilizationPercentage == nil && len(bootAutoscaling.Metrics) == 0 {
+		configTargets := configAutoscaling.DeepCopy()
+		if configTargets.TargetCPUUtilizationPercentage != nil ||
+			configTargets.TargetMemoryUtilizationPercentage != nil || len(configTargets.Metrics) > 0 {
+			bootAutoscaling.TargetCPUUtilizationPercentage = configTargets.TargetCPUUtilizationPercentage
+			bootAutoscaling.TargetMemoryUtilizationPercentage = configTargets.TargetMemoryUtilizationPercentage
+			bootAutoscaling.Metrics = configTargets.Metrics
+			changed = true
+		}
+	}
+
+	if changed {
+		logger.Info("Defaulters", "type", "autoscaling", "to", bootAutoscaling)
+	}
+
+	return changed
 }
 
 // DefaultPvcValue will handle the pvc changed.
@@ -0,0 +1,57 @@
+package operator
+
+import (
+	appv1 "github.com/logancloud/logan-app-operator/pkg/apis/app/v1"
+	"github.com/logancloud/logan-app-operator/pkg/logan"
+	"github.com/logancloud/logan-app-operator/pkg/logan/config"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+var _ = Describe("Defaulter", func() {
+	Context("With the cpu envs of the Boot's type", func() {
+		newHandler := func() *BootHandler {
+			boot := &appv1.Boot{}
+			boot.Name = "test-go"
+			boot.BootType = logan.BootGo
+			boot.Spec.Image = "test-go"
+
+			return &BootHandler{
+				OperatorBoot: boot,
+				OperatorSpec: &boot.Spec,
+				OperatorMeta: &boot.ObjectMeta,
+				Boot:         boot,
+				Config:       &config.BootConfig{AppSpec: &config.AppSpec{Settings: &config.SettingsConfig{}}},
+				Logger:       logf.Log.WithName("test_defaulter"),
+			}
+		}
+
+		It("test the cpu envs are added when the cpu limits are added after created", func() {
+			handler := newHandler()
+			handler.DefaultValue()
+			for _, env := range handler.OperatorSpec.Env {
+				Expect(env.Name).ShouldNot(Equal("GOMAXPROCS"))
+			}
+
+			handler.OperatorSpec.Resources.Limits = corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("1500m"),
+			}
+			Expect(handler.DefaultValue()).Should(BeTrue())
+			Expect(handler.OperatorSpec.Env).Should(ContainElement(corev1.EnvVar{
+				Name: "GOMAXPROCS",
+				ValueFrom: &corev1.EnvVarSource{
+					ResourceFieldRef: &corev1.ResourceFieldSelector{
+						Resource: "limits.cpu",
+						Divisor:  resource.MustParse("1"),
+					},
+				},
+			}))
+
+			// The cpu envs are saved into annotation, so the next time nothing is changed.
+			Expect(handler.DefaultValue()).Should(BeFalse())
+		})
+	})
+})
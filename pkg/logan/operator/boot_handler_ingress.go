@@ -0,0 +1,276 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	appv1 "github.com/logancloud/logan-app-operator/pkg/apis/app/v1"
+	"github.com/logancloud/logan-app-operator/pkg/logan/config"
+	loganMetrics "github.com/logancloud/logan-app-operator/pkg/logan/metrics"
+	"github.com/logancloud/logan-app-operator/pkg/logan/util/keys"
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"strings"
+)
+
+const (
+	defaultIngressHost = "${APP}.${SUBDOMAIN}"
+	defaultIngressPath = "/"
+)
+
+// IngressEnabled return true if the Ingress should be created for the Boot, decided by Boot's subDomain and config.
+func IngressEnabled(boot *appv1.Boot, appSpec *config.AppSpec) bool {
+	if boot.Spec.SubDomain == "" {
+		return false
+	}
+
+	ingressCfg := appSpec.Ingress
+	return ingressCfg == nil || ingressCfg.Enabled == nil || *ingressCfg.Enabled
+}
+
+// IngressName return name for the created Ingress
+func IngressName(boot *appv1.Boot) string {
+	return boot.Name
+}
+
+// IngressHost return the host of the created Ingress, default is "<name>.<subDomain>"
+func IngressHost(boot *appv1.Boot, appSpec *config.AppSpec) string {
+	host := defaultIngressHost
+	if appSpec.Ingress != nil && appSpec.Ingress.Host != "" {
+		host = appSpec.Ingress.Host
+	}
+
+	host = strings.ReplaceAll(host, "${SUBDOMAIN}", boot.Spec.SubDomain)
+	host, _ = Decode(boot, host)
+	return host
+}
+
+// NewIngress returns a new created Ingress instance, routing the host to the Boot's app service.
+func (handler *BootHandler) NewIngress() *extensionsv1beta1.Ingress {
+	boot := handler.Boot
+	ingressCfg := handler.Config.AppSpec.Ingress
+	if ingressCfg == nil {
+		ingressCfg = &config.IngressConfig{}
+	}
+
+	host := IngressHost(boot, handler.Config.AppSpec)
+
+	annotations := make(map[string]string)
+	for key, value := range ingressCfg.Annotations {
+		annotations[key], _ = Decode(boot, value)
+	}
+	if ingressCfg.Class != "" {
+		annotations[keys.IngressClassAnnotationKey] = ingressCfg.Class
+	}
+
+	paths := ingressCfg.Paths
+	if len(paths) == 0 {
+		paths = []string{defaultIngressPath}
+	}
+
+	httpPaths := make([]extensionsv1beta1.HTTPIngressPath, 0)
+	for _, path := range paths {
+		httpPaths = append(httpPaths, extensionsv1beta1.HTTPIngressPath{
+			Path: path,
+			Backend: extensionsv1beta1.IngressBackend{
+				ServiceName: boot.Name,
+				ServicePort: intstr.IntOrString{Type: intstr.Int, IntVal: PrimaryPort(boot).ServicePort},
+			},
+		})
+	}
+
+	ingress := &extensionsv1beta1.Ingress{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "extensions/v1beta1",
+			Kind:       "Ingress",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        IngressName(boot),
+			Namespace:   boot.Namespace,
+			Labels:      ServiceLabels(boot),
+			Annotations: annotations,
+		},
+		Spec: extensionsv1beta1.IngressSpec{
+			Rules: []extensionsv1beta1.IngressRule{
+				{
+					Host: host,
+					IngressRuleValue: extensionsv1beta1.IngressRuleValue{
+						HTTP: &extensionsv1beta1.HTTPIngressRuleValue{
+							Paths: httpPaths,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if ingressCfg.TLSSecretName != "" {
+		secretName, _ := Decode(boot, ingressCfg.TLSSecretName)
+		ingress.Spec.TLS = []extensionsv1beta1.IngressTLS{
+			{
+				Hosts:      []string{host},
+				SecretName: secretName,
+			},
+		}
+	}
+
+	// Set Boot instance as the owner and controller
+	_ = controllerutil.SetControllerReference(handler.OperatorBoot, ingress, handler.Scheme)
+
+	return ingress
+}
+
+// reconcileCreateIngress will create the Ingress if it is enabled and not found.
+// Return true if created.
+func (handler *BootHandler) reconcileCreateIngress() (bool, error) {
+	boot := handler.Boot
+	logger := handler.Logger
+	c := handler.Client
+
+	if !IngressEnabled(boot, handler.Config.AppSpec) {
+		return false, nil
+	}
+
+	ingressFound := &extensionsv1beta1.Ingress{}
+	ingressName := IngressName(boot)
+	err := c.Get(context.TODO(), types.NamespacedName{Name: ingressName, Namespace: boot.Namespace}, ingressFound)
+	if err == nil {
+		return false, nil
+	}
+
+	if !errors.IsNotFound(err) {
+		msg := fmt.Sprintf("Failed to get Ingress: %s", ingressName)
+		logger.Error(err, msg)
+		loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_CREATE_STAGE, loganMetrics.RECONCILE_GET_INGRESS_SUBSTAGE, boot.Name)
+		handler.RecordEvent(keys.FailedGetIngress, msg, err)
+		return false, err
+	}
+
+	err = handler.createIngress(loganMetrics.RECONCILE_CREATE_STAGE)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// createIngress will create the Ingress, stage is the reconcile stage for metrics.
+func (handler *BootHandler) createIngress(stage string) error {
+	boot := handler.Boot
+	logger := handler.Logger
+	c := handler.Client
+
+	ingress := handler.NewIngress()
+	logger.Info("Creating Ingress", "ingress", ingress.Name, "spec", ingress.Spec)
+	err := c.Create(context.TODO(), ingress)
+	if err != nil {
+		msg := fmt.Sprintf("Failed to create Ingress: %s", ingress.Name)
+		logger.Error(err, msg)
+		loganMetrics.UpdateReconcileErrors(boot.Kind, stage, loganMetrics.RECONCILE_CREATE_INGRESS_SUBSTAGE, boot.Name)
+		handler.RecordEvent(keys.FailedCreateIngress, msg, err)
+		return err
+	}
+
+	handler.RecordEvent(keys.CreatedIngress, fmt.Sprintf("Created Ingress: %s", ingress.Name), nil)
+	return nil
+}
+
+// reconcileUpdateIngress handle update logic of Ingress
+// 1. Ingress enabled: create it if not found, update if the spec or the managed annotations are changed.
+// 2. Ingress disabled: delete the Ingress which is controlled by the Boot.
+func (handler *BootHandler) reconcileUpdateIngress() (reconcile.Result, bool, error) {
+	boot := handler.Boot
+	logger := handler.Logger
+	c := handler.Client
+	enabled := IngressEnabled(boot, handler.Config.AppSpec)
+
+	ingressFound := &extensionsv1beta1.Ingress{}
+	ingressName := IngressName(boot)
+	err := c.Get(context.TODO(), types.NamespacedName{Name: ingressName, Namespace: boot.Namespace}, ingressFound)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			msg := fmt.Sprintf("Failed to get Ingress: %s", ingressName)
+			logger.Error(err, msg)
+			loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_UPDATE_STAGE, loganMetrics.RECONCILE_GET_INGRESS_SUBSTAGE, boot.Name)
+			handler.RecordEvent(keys.FailedGetIngress, msg, err)
+			return reconcile.Result{Requeue: true}, true, err
+		}
+
+		if !enabled {
+			return reconcile.Result{}, false, nil
+		}
+
+		// SubDomain is set after the Boot created.
+		err = handler.createIngress(loganMetrics.RECONCILE_UPDATE_STAGE)
+		if err != nil {
+			return reconcile.Result{Requeue: true}, true, err
+		}
+		return reconcile.Result{Requeue: true}, true, nil
+	}
+
+	// Only handle the Ingress controlled by the Boot.
+	if !metav1.IsControlledBy(ingressFound, handler.OperatorBoot) {
+		logger.Info("Ingress is not controlled by Boot, ignore it", "ingress", ingressName)
+		return reconcile.Result{}, false, nil
+	}
+
+	if !enabled {
+		logger.Info("Deleting Ingress", "ingress", ingressName)
+		err := c.Delete(context.TODO(), ingressFound)
+		if err != nil && !errors.IsNotFound(err) {
+			msg := fmt.Sprintf("Failed to delete Ingress: %s", ingressName)
+			logger.Error(err, msg)
+			loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_UPDATE_STAGE, loganMetrics.RECONCILE_DELETE_INGRESS_SUBSTAGE, boot.Name)
+			handler.RecordEvent(keys.FailedDeleteIngress, msg, err)
+			return reconcile.Result{Requeue: true}, true, err
+		}
+
+		handler.RecordEvent(keys.DeletedIngress, fmt.Sprintf("Deleted Ingress: %s", ingressName), nil)
+		return reconcile.Result{Requeue: true}, true, nil
+	}
+
+	expectIngress := handler.NewIngress()
+	updated := false
+
+	// 1. Check spec: host, paths, tls
+	if !equality.Semantic.DeepEqual(ingressFound.Spec, expectIngress.Spec) {
+		logger.Info("Updating Ingress", "type", "spec", "ingress", ingressName,
+			"old", ingressFound.Spec, "new", expectIngress.Spec)
+		ingressFound.Spec = expectIngress.Spec
+		updated = true
+	}
+
+	// 2. Check annotations: only the annotations from config, others are kept.
+	if ingressFound.Annotations == nil {
+		ingressFound.Annotations = make(map[string]string)
+	}
+	for key, value := range expectIngress.Annotations {
+		if ingressFound.Annotations[key] != value {
+			logger.Info("Updating Ingress", "type", "annotation", "ingress", ingressName,
+				"key", key, "old", ingressFound.Annotations[key], "new", value)
+			ingressFound.Annotations[key] = value
+			updated = true
+		}
+	}
+
+	if !updated {
+		return reconcile.Result{}, false, nil
+	}
+
+	err = c.Update(context.TODO(), ingressFound)
+	if err != nil {
+		msg := fmt.Sprintf("Failed to update Ingress: %s", ingressName)
+		logger.Info(msg, "err", err.Error())
+		loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_UPDATE_STAGE, loganMetrics.RECONCILE_UPDATE_INGRESS_SUBSTAGE, boot.Name)
+		handler.RecordEvent(keys.FailedUpdateIngress, msg, err)
+		return reconcile.Result{Requeue: true}, true, err
+	}
+
+	handler.RecordEvent(keys.UpdatedIngress, fmt.Sprintf("Updated Ingress: %s", ingressName), nil)
+	return reconcile.Result{Requeue: true}, true, nil
+}
@@ -0,0 +1,574 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	appv1 "github.com/logancloud/logan-app-operator/pkg/apis/app/v1"
+	loganMetrics "github.com/logancloud/logan-app-operator/pkg/logan/metrics"
+	"github.com/logancloud/logan-app-operator/pkg/logan/util/hash"
+	"github.com/logancloud/logan-app-operator/pkg/logan/util/keys"
+	"hash/fnv"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"strconv"
+)
+
+const (
+	defaultJobConcurrencyPolicy          = batchv1beta1.ForbidConcurrent
+	defaultJobRestartPolicy              = corev1.RestartPolicyOnFailure
+	defaultJobSuccessfulJobsHistoryLimit = int32(3)
+	defaultJobFailedJobsHistoryLimit     = int32(1)
+)
+
+// JobEnabled return true if the Boot's workload is a one-off Job.
+func JobEnabled(boot *appv1.Boot) bool {
+	return boot.Spec.WorkloadType == appv1.WorkloadTypeJob
+}
+
+// CronJobEnabled return true if the Boot's workload is a CronJob.
+func CronJobEnabled(boot *appv1.Boot) bool {
+	return boot.Spec.WorkloadType == appv1.WorkloadTypeCronJob
+}
+
+// BatchEnabled return true if the Boot's workload is a Job or CronJob, which runs to completion without Service.
+func BatchEnabled(boot *appv1.Boot) bool {
+	return JobEnabled(boot) || CronJobEnabled(boot)
+}
+
+// bootJob return the Boot's Job settings, empty if not set.
+func bootJob(boot *appv1.Boot) *appv1.BootJob {
+	if boot.Spec.Job == nil {
+		return &appv1.BootJob{}
+	}
+	return boot.Spec.Job
+}
+
+// NewJobSpec return the Boot's Job spec, the pod template is the same as the Deployment's,
+// including the sidecar and init containers from the operator config, without the app container's probes.
+func (handler *BootHandler) NewJobSpec() batchv1.JobSpec {
+	boot := handler.Boot
+	job := bootJob(boot)
+
+	template := handler.NewDeployment().Spec.Template.DeepCopy()
+	template.Spec.Containers[0].LivenessProbe = nil
+	template.Spec.Containers[0].ReadinessProbe = nil
+	template.Spec.RestartPolicy = job.RestartPolicy
+	if template.Spec.RestartPolicy == "" {
+		template.Spec.RestartPolicy = defaultJobRestartPolicy
+	}
+
+	return batchv1.JobSpec{
+		BackoffLimit:          job.BackoffLimit,
+		ActiveDeadlineSeconds: job.ActiveDeadlineSeconds,
+		Template:              *template,
+	}
+}
+
+// jobSpecHash returns a hash value calculated from the Job spec, which is generated by the operator.
+func jobSpecHash(spec *batchv1.JobSpec) string {
+	hasher := fnv.New32a()
+	hash.DeepHashObject(hasher, *spec)
+	return rand.SafeEncodeString(fmt.Sprint(hasher.Sum32()))
+}
+
+// jobBootHash returns the boot hash of the Boot's own spec, as the BootRevision's. The replicas, suspend and schedules
+// are excluded, and the operator config is not included, so the Job is not run again when the config is reloaded.
+func jobBootHash(boot *appv1.Boot) string {
+	return InitBootRevision(boot).BootHash()
+}
+
+// NewJob return a new created Boot's one-off Job object.
+func (handler *BootHandler) NewJob() *batchv1.Job {
+	boot := handler.Boot
+	spec := handler.NewJobSpec()
+
+	job := &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "batch/v1",
+			Kind:       "Job",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      DeployName(boot),
+			Namespace: boot.Namespace,
+			Labels:    DeployLabels(boot),
+			Annotations: map[string]string{
+				keys.JobHashAnnotationKey:     jobSpecHash(&spec),
+				keys.JobBootHashAnnotationKey: jobBootHash(boot),
+			},
+		},
+		Spec: spec,
+	}
+
+	// Set Boot instance as the owner and controller
+	_ = controllerutil.SetControllerReference(handler.OperatorBoot, job, handler.Scheme)
+
+	return job
+}
+
+// NewCronJob return a new created Boot's CronJob object, the Jobs are created with the Boot's Deployment labels.
+func (handler *BootHandler) NewCronJob() *batchv1beta1.CronJob {
+	boot := handler.Boot
+	job := bootJob(boot)
+	spec := handler.NewJobSpec()
+
+	concurrencyPolicy := job.ConcurrencyPolicy
+	if concurrencyPolicy == "" {
+		concurrencyPolicy = defaultJobConcurrencyPolicy
+	}
+	successfulJobsHistoryLimit := defaultJobSuccessfulJobsHistoryLimit
+	if job.SuccessfulJobsHistoryLimit != nil {
+		successfulJobsHistoryLimit = *job.SuccessfulJobsHistoryLimit
+	}
+	failedJobsHistoryLimit := defaultJobFailedJobsHistoryLimit
+	if job.FailedJobsHistoryLimit != nil {
+		failedJobsHistoryLimit = *job.FailedJobsHistoryLimit
+	}
+	suspend := Suspended(boot)
+
+	cronJob := &batchv1beta1.CronJob{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "batch/v1beta1",
+			Kind:       "CronJob",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      DeployName(boot),
+			Namespace: boot.Namespace,
+			Labels:    DeployLabels(boot),
+			Annotations: map[string]string{
+				keys.JobHashAnnotationKey: jobSpecHash(&spec),
+			},
+		},
+		Spec: batchv1beta1.CronJobSpec{
+			Schedule:                   job.Schedule,
+			StartingDeadlineSeconds:    job.StartingDeadlineSeconds,
+			ConcurrencyPolicy:          concurrencyPolicy,
+			Suspend:                    &suspend,
+			SuccessfulJobsHistoryLimit: &successfulJobsHistoryLimit,
+			FailedJobsHistoryLimit:     &failedJobsHistoryLimit,
+			JobTemplate: batchv1beta1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: DeployLabels(boot),
+				},
+				Spec: spec,
+			},
+		},
+	}
+
+	// Set Boot instance as the owner and controller
+	_ = controllerutil.SetControllerReference(handler.OperatorBoot, cronJob, handler.Scheme)
+
+	return cronJob
+}
+
+// reconcileCreateJob will create the Job or CronJob if it is not found. Return true if created.
+func (handler *BootHandler) reconcileCreateJob() (bool, error) {
+	boot := handler.Boot
+	logger := handler.Logger
+	c := handler.Client
+
+	var found, obj runtime.Object
+	if CronJobEnabled(boot) {
+		found, obj = &batchv1beta1.CronJob{}, handler.NewCronJob()
+	} else {
+		found, obj = &batchv1.Job{}, handler.NewJob()
+	}
+
+	jobName := DeployName(boot)
+	kind := string(boot.Spec.WorkloadType)
+	err := c.Get(context.TODO(), types.NamespacedName{Name: jobName, Namespace: boot.Namespace}, found)
+	if err == nil {
+		return false, nil
+	}
+
+	if !errors.IsNotFound(err) {
+		msg := fmt.Sprintf("Failed to get %s: %s", kind, jobName)
+		logger.Error(err, msg)
+		loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_CREATE_STAGE, loganMetrics.RECONCILE_GET_JOB_SUBSTAGE, boot.Name)
+		handler.RecordEvent(keys.FailedGetJob, msg, err)
+		return false, err
+	}
+
+	logger.Info("Creating "+kind, "name", jobName)
+	err = c.Create(context.TODO(), obj)
+	if err != nil {
+		msg := fmt.Sprintf("Failed to create %s: %s", kind, jobName)
+		logger.Error(err, msg)
+		loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_CREATE_STAGE, loganMetrics.RECONCILE_CREATE_JOB_SUBSTAGE, boot.Name)
+		handler.RecordEvent(keys.FailedCreateJob, msg, err)
+		return false, err
+	}
+
+	handler.RecordEvent(keys.CreatedJob, fmt.Sprintf("Created %s: %s", kind, jobName), nil)
+	return true, nil
+}
+
+// reconcileUpdateJob handle update logic of the Job or CronJob.
+func (handler *BootHandler) reconcileUpdateJob() (reconcile.Result, bool, error) {
+	if CronJobEnabled(handler.Boot) {
+		return handler.reconcileUpdateCronJob()
+	}
+	return handler.reconcileRerunJob()
+}
+
+// reconcileRerunJob run the one-off Job again when the Boot's own spec is changed. The Job's spec is immutable,
+// the finished Job is deleted and created again with the new spec, the running Job is waited until finished.
+// The changes of the operator config or the operator's generated template do not run the Job again.
+func (handler *BootHandler) reconcileRerunJob() (reconcile.Result, bool, error) {
+	boot := handler.Boot
+	logger := handler.Logger
+	c := handler.Client
+
+	jobFound := &batchv1.Job{}
+	jobName := DeployName(boot)
+	err := c.Get(context.TODO(), types.NamespacedName{Name: jobName, Namespace: boot.Namespace}, jobFound)
+	if err != nil {
+		logger.Error(err, "Failed to get Job")
+		loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_UPDATE_STAGE, loganMetrics.RECONCILE_GET_JOB_SUBSTAGE, boot.Name)
+		return reconcile.Result{Requeue: true}, true, err
+	}
+
+	expectHash := jobBootHash(boot)
+	foundHash, found := jobFound.Annotations[keys.JobBootHashAnnotationKey]
+	if !found {
+		// The Job created before the boot hash is recorded, record the current one without running again.
+		logger.Info("Recording Job's boot hash", "job", jobName)
+		if jobFound.Annotations == nil {
+			jobFound.Annotations = make(map[string]string)
+		}
+		jobFound.Annotations[keys.JobBootHashAnnotationKey] = expectHash
+		err = c.Update(context.TODO(), jobFound)
+		if err != nil {
+			logger.Info("Failed to record Job's boot hash", "err", err.Error())
+			loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_UPDATE_STAGE, loganMetrics.RECONCILE_UPDATE_JOB_SUBSTAGE, boot.Name)
+			return reconcile.Result{Requeue: true}, true, err
+		}
+		return reconcile.Result{}, false, nil
+	}
+	if foundHash == expectHash {
+		return reconcile.Result{}, false, nil
+	}
+
+	if !jobFinished(jobFound) {
+		logger.Info("Job is changed, waiting for the running Job to finish", "job", jobName)
+		return reconcile.Result{}, false, nil
+	}
+
+	logger.Info("Deleting Job to run again", "job", jobName)
+	err = c.Delete(context.TODO(), jobFound, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if err != nil && !errors.IsNotFound(err) {
+		msg := fmt.Sprintf("Failed to delete Job: %s", jobName)
+		logger.Info(msg, "err", err.Error())
+		loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_UPDATE_STAGE, loganMetrics.RECONCILE_DELETE_JOB_SUBSTAGE, boot.Name)
+		handler.RecordEvent(keys.FailedDeleteJob, msg, err)
+		return reconcile.Result{Requeue: true}, true, err
+	}
+
+	handler.RecordEvent(keys.DeletedJob, fmt.Sprintf("Deleted Job to run again: %s", jobName), nil)
+	return reconcile.Result{Requeue: true}, true, nil
+}
+
+// reconcileUpdateCronJob handle update logic of CronJob, the spec is updated in place,
+// the changed job template is used by the next run.
+func (handler *BootHandler) reconcileUpdateCronJob() (reconcile.Result, bool, error) {
+	boot := handler.Boot
+	logger := handler.Logger
+	c := handler.Client
+
+	cronJobFound := &batchv1beta1.CronJob{}
+	cronJobName := DeployName(boot)
+	err := c.Get(context.TODO(), types.NamespacedName{Name: cronJobName, Namespace: boot.Namespace}, cronJobFound)
+	if err != nil {
+		logger.Error(err, "Failed to get CronJob")
+		loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_UPDATE_STAGE, loganMetrics.RECONCILE_GET_JOB_SUBSTAGE, boot.Name)
+		return reconcile.Result{Requeue: true}, true, err
+	}
+
+	expectCronJob := handler.NewCronJob()
+	reason := "Updating CronJob"
+	updated := false
+
+	// 1. Check ownerReferences
+	if len(cronJobFound.OwnerReferences) == 0 {
+		logger.Info(reason, "type", "ownerReferences", "cronjob", cronJobName)
+		_ = controllerutil.SetControllerReference(handler.OperatorBoot, cronJobFound, handler.Scheme)
+		updated = true
+	}
+
+	// 2. Check job template
+	expectHash := expectCronJob.Annotations[keys.JobHashAnnotationKey]
+	if cronJobFound.Annotations[keys.JobHashAnnotationKey] != expectHash {
+		logger.Info(reason, "type", "jobTemplate", "cronjob", cronJobName)
+		if cronJobFound.Annotations == nil {
+			cronJobFound.Annotations = make(map[string]string)
+		}
+		cronJobFound.Annotations[keys.JobHashAnnotationKey] = expectHash
+		cronJobFound.Spec.JobTemplate = expectCronJob.Spec.JobTemplate
+		updated = true
+	}
+
+	// 3. Check schedule, concurrencyPolicy, startingDeadlineSeconds, suspend and history limits
+	foundSpec := cronJobFound.Spec.DeepCopy()
+	foundSpec.JobTemplate = expectCronJob.Spec.JobTemplate
+	if !equality.Semantic.DeepEqual(*foundSpec, expectCronJob.Spec) {
+		logger.Info(reason, "type", "spec", "cronjob", cronJobName,
+			"schedule", expectCronJob.Spec.Schedule, "suspend", *expectCronJob.Spec.Suspend)
+		cronJobFound.Spec = expectCronJob.Spec
+		updated = true
+	}
+
+	if !updated {
+		return reconcile.Result{}, false, nil
+	}
+
+	err = c.Update(context.TODO(), cronJobFound)
+	if err != nil {
+		msg := fmt.Sprintf("Failed to update CronJob: %s", cronJobName)
+		logger.Info(msg, "err", err.Error())
+		loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_UPDATE_STAGE, loganMetrics.RECONCILE_UPDATE_JOB_SUBSTAGE, boot.Name)
+		handler.RecordEvent(keys.FailedUpdateJob, msg, err)
+		return reconcile.Result{Requeue: true}, true, err
+	}
+
+	handler.RecordEvent(keys.UpdatedJob, fmt.Sprintf("Updated CronJob: %s", cronJobName), nil)
+	return reconcile.Result{Requeue: true}, true, nil
+}
+
+// listJobs return the Boot's Jobs, including the one-off Job and the Jobs created by the CronJob.
+func (handler *BootHandler) listJobs() ([]batchv1.Job, error) {
+	boot := handler.Boot
+
+	jobList := &batchv1.JobList{}
+	listOptions := &client.ListOptions{
+		Namespace:     boot.Namespace,
+		LabelSelector: labels.SelectorFromSet(DeployLabels(boot)),
+	}
+	err := handler.Client.List(context.TODO(), listOptions, jobList)
+	if err != nil {
+		return nil, err
+	}
+	return jobList.Items, nil
+}
+
+// jobCondition return true if the Job has the condition with status true, and the condition's transition time.
+func jobCondition(job *batchv1.Job, condType batchv1.JobConditionType) (bool, metav1.Time) {
+	for _, cond := range job.Status.Conditions {
+		if cond.Type == condType && cond.Status == corev1.ConditionTrue {
+			return true, cond.LastTransitionTime
+		}
+	}
+	return false, metav1.Time{}
+}
+
+// jobFinished return true if the Job is completed or failed.
+func jobFinished(job *batchv1.Job) bool {
+	complete, _ := jobCondition(job, batchv1.JobComplete)
+	failed, _ := jobCondition(job, batchv1.JobFailed)
+	return complete || failed
+}
+
+// latestTime return the later one of the times, the decoded time is local, keep the same location
+// to avoid the endless update.
+func latestTime(latest *metav1.Time, t metav1.Time) *metav1.Time {
+	if t.IsZero() || (latest != nil && !t.After(latest.Time)) {
+		return latest
+	}
+	local := metav1.NewTime(t.Local())
+	return &local
+}
+
+// ReconcileUpdateJobStatus will compute the observed state of the Boot's Jobs, and write it through the status subresource.
+func (handler *BootHandler) ReconcileUpdateJobStatus() (reconcile.Result, bool, error) {
+	logger := handler.Logger
+	boot := handler.Boot
+	c := handler.Client
+
+	jobs, err := handler.listJobs()
+	if err != nil {
+		logger.Error(err, "Failed to list Jobs")
+		loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_UPDATE_BOOT_META_STAGE, loganMetrics.RECONCILE_LIST_JOBS_SUBSTAGE, boot.Name)
+		return reconcile.Result{Requeue: true}, true, err
+	}
+
+	revisionLst, err := c.ListRevision(boot.Namespace, PodLabels(boot))
+	var latestRevision *appv1.BootRevision
+	if err != nil {
+		logger.Info("Failed to list revisions", "err", err.Error())
+	} else {
+		latestRevision = revisionLst.SelectLatestRevision()
+	}
+
+	status := handler.NewJobStatus(jobs, latestRevision)
+	err = handler.updateBootStatus(status)
+	if err != nil {
+		return reconcile.Result{Requeue: true}, true, err
+	}
+
+	return reconcile.Result{}, false, nil
+}
+
+// NewJobStatus returns the Boot's observed state, computed from the Boot's Jobs and the latest revision.
+// The phase of the one-off Job is its result, the CronJob is Running unless its latest finished Job failed.
+func (handler *BootHandler) NewJobStatus(jobs []batchv1.Job, latestRevision *appv1.BootRevision) *appv1.BootStatus {
+	boot := handler.Boot
+	status := handler.OperatorStatus.DeepCopy()
+	generation := handler.OperatorMeta.Generation
+
+	status.Type = WorkloadAppType(boot)
+	status.Deploy = DeployName(boot)
+	status.Services = ""
+	status.ObservedGeneration = generation
+	status.Selector = labels.SelectorFromSet(PodLabels(boot)).String()
+	status.ReadyReplicas = 0
+	status.AvailableReplicas = 0
+	status.UpdatedReplicas = 0
+
+	if latestRevision != nil {
+		status.Revision = strconv.Itoa(latestRevision.GetRevisionId())
+		status.RevisionHash = latestRevision.Annotations[keys.BootRevisionHashAnnotationKey]
+	}
+
+	// 1. Runs: active runs, the latest start, success and failure time.
+	var active, replicas int32
+	var lastFinished metav1.Time
+	lastFailed := false
+	for i := range jobs {
+		job := &jobs[i]
+		if !jobFinished(job) {
+			active++
+		}
+		replicas += job.Status.Active
+		if job.Status.StartTime != nil {
+			status.LastRunTime = latestTime(status.LastRunTime, *job.Status.StartTime)
+		}
+		if complete, t := jobCondition(job, batchv1.JobComplete); complete {
+			status.LastSuccessTime = latestTime(status.LastSuccessTime, t)
+			if t.After(lastFinished.Time) {
+				lastFinished, lastFailed = t, false
+			}
+		}
+		if failed, t := jobCondition(job, batchv1.JobFailed); failed {
+			status.LastFailureTime = latestTime(status.LastFailureTime, t)
+			if t.After(lastFinished.Time) {
+				lastFinished, lastFailed = t, true
+			}
+		}
+	}
+	status.Active = active
+	status.Replicas = replicas
+
+	// 2. ReconcileError: reaching here means all the reconcile stages succeeded.
+	status.SetCondition(newBootCondition(appv1.BootReconcileError, corev1.ConditionFalse,
+		ReasonReconcileSuccess, "", generation))
+
+	// 3. ConfigValid
+	handler.setConfigValidCondition(status)
+
+	// 4. Phase
+	switch {
+	case Suspended(boot):
+		status.Phase = appv1.BootPhaseSuspended
+	case CronJobEnabled(boot) && lastFailed:
+		status.Phase = appv1.BootPhaseFailed
+	case CronJobEnabled(boot):
+		status.Phase = appv1.BootPhaseRunning
+	case active > 0:
+		status.Phase = appv1.BootPhaseProgressing
+	case lastFinished.IsZero():
+		status.Phase = appv1.BootPhasePending
+	case lastFailed:
+		status.Phase = appv1.BootPhaseFailed
+	default:
+		status.Phase = appv1.BootPhaseSucceeded
+	}
+
+	return status
+}
+
+// reconcileUpdateJobMeta will handle the metadata update of the Boot with the Job or CronJob workload.
+// The latest revision is Active once the workload is updated, the Jobs' results are reported in the status.
+func (handler *BootHandler) reconcileUpdateJobMeta() (reconcile.Result, bool, bool, error) {
+	logger := handler.Logger
+	boot := handler.Boot
+	c := handler.Client
+
+	revisionLst, _ := c.ListRevision(boot.Namespace, PodLabels(boot))
+	latestRevision := revisionLst.SelectLatestRevision()
+	if latestRevision != nil {
+		revisionAnnotationMap := map[string]string{
+			keys.BootRevisionPhaseAnnotationKey: RevisionPhaseActive,
+		}
+		if updateRevisionAnnotation(latestRevision, revisionAnnotationMap) {
+			reason := "Updating Boot Revision Meta"
+			logger.Info(reason, "new", revisionAnnotationMap, "revision", latestRevision)
+			err := c.Update(context.TODO(), latestRevision)
+			if err != nil {
+				msg := "Failed to update Boot Revision Meta"
+				logger.Info(msg, "err", err.Error())
+				handler.RecordEvent(keys.FailedUpdateBootMeta, msg, err)
+				return reconcile.Result{Requeue: true}, true, false, err
+			}
+			handler.RecordEvent(keys.UpdatedBootMeta, "Updated Boot Revision Meta", nil)
+		}
+	}
+
+	annotationMap := map[string]string{
+		keys.DeployAnnotationKey:   DeployName(boot),
+		keys.AppTypeAnnotationKey:  WorkloadAppType(boot),
+		keys.ServicesAnnotationKey: "",
+	}
+	if latestRevision != nil {
+		annotationMap[keys.BootRevisionIdAnnotationKey] = strconv.Itoa(latestRevision.GetRevisionId())
+	}
+
+	updated := handler.UpdateAnnotation(annotationMap)
+	return reconcile.Result{}, false, updated, nil
+}
+
+// reconcileSuspendJob suspend the Boot's CronJob when suspended, it is resumed by the CronJob's update.
+// Return requeue true if the Boot is suspended, the other reconciliation is paused.
+func (handler *BootHandler) reconcileSuspendJob() (reconcile.Result, bool, error) {
+	logger := handler.Logger
+	boot := handler.Boot
+	c := handler.Client
+
+	if !Suspended(boot) {
+		return reconcile.Result{}, false, nil
+	}
+
+	if CronJobEnabled(boot) {
+		cronJobFound := &batchv1beta1.CronJob{}
+		err := c.Get(context.TODO(), types.NamespacedName{Name: DeployName(boot), Namespace: boot.Namespace}, cronJobFound)
+		if err == nil && (cronJobFound.Spec.Suspend == nil || !*cronJobFound.Spec.Suspend) {
+			logger.Info("Suspending CronJob", "cronjob", cronJobFound.Name)
+			suspend := true
+			cronJobFound.Spec.Suspend = &suspend
+			err = c.Update(context.TODO(), cronJobFound)
+			if err != nil {
+				msg := fmt.Sprintf("Failed to update CronJob: %s", cronJobFound.Name)
+				logger.Info(msg, "err", err.Error())
+				loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_SUSPEND_BOOT_STAGE, loganMetrics.RECONCILE_UPDATE_JOB_SUBSTAGE, boot.Name)
+				handler.RecordEvent(keys.FailedSuspendBoot, msg, err)
+				return reconcile.Result{Requeue: true}, true, err
+			}
+			handler.RecordEvent(keys.SuspendedBoot, "Suspended Boot, suspended the CronJob", nil)
+		}
+	}
+
+	// The status is still observed when suspended.
+	result, requeue, err := handler.ReconcileUpdateJobStatus()
+	if requeue {
+		return result, true, err
+	}
+	return reconcile.Result{}, true, nil
+}
@@ -0,0 +1,146 @@
+package operator
+
+import (
+	"fmt"
+	appv1 "github.com/logancloud/logan-app-operator/pkg/apis/app/v1"
+	"github.com/logancloud/logan-app-operator/pkg/logan/config"
+	corev1 "k8s.io/api/core/v1"
+	"strings"
+)
+
+const (
+	defaultJVMEnvName        = "JAVA_OPTS"
+	defaultJVMHeapPercentage = int32(75)
+)
+
+// AppContainerEnv return the env for the created Pod's app container: the Boot's env, with the JVM flags computed
+// from the Boot's resource limits if the operator config's jvm is enabled.
+func AppContainerEnv(boot *appv1.Boot, appSpec *config.AppSpec) []corev1.EnvVar {
+	jvm := appSpec.JVM
+	if jvm == nil || (jvm.Enabled != nil && !*jvm.Enabled) {
+		return boot.Spec.Env
+	}
+
+	envName := jvm.EnvName
+	if envName == "" {
+		envName = defaultJVMEnvName
+	}
+
+	index := -1
+	for i, env := range boot.Spec.Env {
+		if env.Name == envName {
+			index = i
+			break
+		}
+	}
+
+	// The env from a source could not be merged, keep it.
+	var bootFlags []string
+	if index >= 0 {
+		if boot.Spec.Env[index].ValueFrom != nil {
+			return boot.Spec.Env
+		}
+		bootFlags = strings.Fields(boot.Spec.Env[index].Value)
+	}
+
+	flags := mergeJVMFlags(JVMFlags(boot, jvm), bootFlags)
+	if len(flags) == 0 {
+		return boot.Spec.Env
+	}
+
+	envs := make([]corev1.EnvVar, len(boot.Spec.Env))
+	copy(envs, boot.Spec.Env)
+	jvmEnv := corev1.EnvVar{Name: envName, Value: strings.Join(flags, " ")}
+	if index >= 0 {
+		envs[index] = jvmEnv
+	} else {
+		envs = append(envs, jvmEnv)
+	}
+	return envs
+}
+
+// JVMFlags return the JVM flags computed from the Boot's resource limits by the jvm config.
+//   - heap: -Xmx and -Xms as the percentage of the memory limits, not set if no memory limits
+//   - gc: -XX:+Use<GC>
+//   - processors: -XX:ActiveProcessorCount as the cpu limits rounded up, not set if no cpu limits
+//   - options: the additional flags
+func JVMFlags(boot *appv1.Boot, jvm *config.JVMConfig) []string {
+	flags := make([]string, 0)
+	limits := boot.Spec.Resources.Limits
+
+	if memory := limits.Memory(); !memory.IsZero() {
+		heapPercentage := jvm.HeapPercentage
+		if heapPercentage <= 0 {
+			heapPercentage = defaultJVMHeapPercentage
+		}
+		if heap := memoryPercentageMi(memory.Value(), heapPercentage); heap > 0 {
+			flags = append(flags, fmt.Sprintf("-Xmx%dm", heap))
+		}
+		if heap := memoryPercentageMi(memory.Value(), jvm.InitialHeapPercentage); heap > 0 {
+			flags = append(flags, fmt.Sprintf("-Xms%dm", heap))
+		}
+	}
+
+	if jvm.GC != "" {
+		flags = append(flags, "-XX:+Use"+jvm.GC)
+	}
+
+	if cpu := limits.Cpu(); !cpu.IsZero() && (jvm.ActiveProcessorCount == nil || *jvm.ActiveProcessorCount) {
+		processors := (cpu.MilliValue() + 999) / 1000
+		flags = append(flags, fmt.Sprintf("-XX:ActiveProcessorCount=%d", processors))
+	}
+
+	return append(flags, jvm.Options...)
+}
+
+// memoryPercentageMi return the percentage of the memory bytes, in Mi.
+func memoryPercentageMi(bytes int64, percentage int32) int64 {
+	if percentage <= 0 {
+		return 0
+	}
+	return bytes * int64(percentage) / 100 / (1024 * 1024)
+}
+
+// mergeJVMFlags merge the computed flags with the Boot's flags: the computed flags specified by the Boot are dropped,
+// and the Boot's flags are appended, the JVM takes the last one if a flag is duplicated.
+// The computed -Xms is also dropped if the Boot specifies -Xmx only, which could be less than the computed -Xms.
+func mergeJVMFlags(flags []string, bootFlags []string) []string {
+	bootKeys := make(map[string]bool, len(bootFlags))
+	bootGC := false
+	for _, flag := range bootFlags {
+		key := jvmFlagKey(flag)
+		bootKeys[key] = true
+		if strings.HasPrefix(key, "-XX:Use") && strings.HasSuffix(key, "GC") {
+			bootGC = true
+		}
+	}
+	if bootKeys["-Xmx"] {
+		bootKeys["-Xms"] = true
+	}
+
+	merged := make([]string, 0, len(flags)+len(bootFlags))
+	for _, flag := range flags {
+		key := jvmFlagKey(flag)
+		if bootKeys[key] || (bootGC && strings.HasPrefix(key, "-XX:Use") && strings.HasSuffix(key, "GC")) {
+			continue
+		}
+		merged = append(merged, flag)
+	}
+	return append(merged, bootFlags...)
+}
+
+// jvmFlagKey return the flag's name without the value, as: -Xmx, -XX:ActiveProcessorCount, -XX:UseG1GC, -Dkey
+func jvmFlagKey(flag string) string {
+	for _, prefix := range []string{"-Xmx", "-Xms", "-Xss", "-Xmn"} {
+		if strings.HasPrefix(flag, prefix) {
+			return prefix
+		}
+	}
+	if strings.HasPrefix(flag, "-XX:+") || strings.HasPrefix(flag, "-XX:-") {
+		return "-XX:" + flag[len("-XX:+"):]
+	}
+	if i := strings.Index(flag, "="); i > 0 {
+		return flag[:i]
+	}
+	return flag
+}
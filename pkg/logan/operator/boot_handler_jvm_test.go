@@ -0,0 +1,33 @@
+package operator
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("JVM", func() {
+	Context("With the computed flags merged with the Boot's flags", func() {
+		It("test the Boot's flags override the computed flags", func() {
+			flags := []string{"-Xmx1536m", "-Xms1024m", "-XX:+UseG1GC", "-XX:ActiveProcessorCount=2"}
+
+			merged := mergeJVMFlags(flags, []string{"-XX:+UseParallelGC", "-XX:ActiveProcessorCount=4"})
+			Expect(merged).Should(Equal([]string{"-Xmx1536m", "-Xms1024m", "-XX:+UseParallelGC", "-XX:ActiveProcessorCount=4"}))
+
+			merged = mergeJVMFlags(flags, nil)
+			Expect(merged).Should(Equal(flags))
+		})
+
+		It("test the computed -Xms is dropped with the Boot's -Xmx", func() {
+			flags := []string{"-Xmx1536m", "-Xms2048m", "-XX:+UseG1GC"}
+
+			merged := mergeJVMFlags(flags, []string{"-Xmx512m"})
+			Expect(merged).Should(Equal([]string{"-XX:+UseG1GC", "-Xmx512m"}))
+
+			merged = mergeJVMFlags(flags, []string{"-Xmx512m", "-Xms256m"})
+			Expect(merged).Should(Equal([]string{"-XX:+UseG1GC", "-Xmx512m", "-Xms256m"}))
+
+			merged = mergeJVMFlags(flags, []string{"-Xms256m"})
+			Expect(merged).Should(Equal([]string{"-Xmx1536m", "-XX:+UseG1GC", "-Xms256m"}))
+		})
+	})
+})
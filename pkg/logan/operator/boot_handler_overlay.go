@@ -0,0 +1,61 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	appv1 "github.com/logancloud/logan-app-operator/pkg/apis/app/v1"
+	"github.com/logancloud/logan-app-operator/pkg/logan/config"
+	"github.com/logancloud/logan-app-operator/pkg/logan/util"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"strings"
+)
+
+// OverlayNamespace returns the Boot's namespace to select the overlays, nil if the config has no overlays.
+func OverlayNamespace(boot *appv1.Boot, snapshot *config.Config, client util.K8SClient) (*corev1.Namespace, error) {
+	if snapshot == nil || len(snapshot.OverlayConfig) == 0 {
+		return nil, nil
+	}
+
+	namespace := &corev1.Namespace{}
+	err := client.Get(context.TODO(), types.NamespacedName{Name: boot.Namespace}, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get namespace %s for overlays: %s", boot.Namespace, err.Error())
+	}
+	return namespace, nil
+}
+
+// ResolveBootConfig resolves the Boot's config in order: the boot type, or the profile replacing it, then the
+// overlays selected by the Boot's namespace. The Boot's spec overrides the resolved config by the defaulters.
+// Returns the config, the overlays applied, and the error if the overlays could not be resolved, then the config
+// without overlays is returned.
+func ResolveBootConfig(boot *appv1.Boot, snapshot *config.Config, configKey string,
+	namespace *corev1.Namespace) (*config.BootConfig, []string, error) {
+	bootCfg, _ := snapshot.ResolveBootConfig(configKey, nil)
+	if namespace == nil {
+		return bootCfg, nil, nil
+	}
+
+	overlays := snapshot.MatchOverlays(boot.BootType, namespace)
+	if len(overlays) == 0 {
+		return bootCfg, nil, nil
+	}
+
+	overlaid, err := snapshot.ResolveBootConfig(configKey, overlays)
+	if err != nil {
+		return bootCfg, nil, fmt.Errorf("failed to apply overlays %s: %s", strings.Join(overlays, ","), err.Error())
+	}
+	return overlaid, overlays, nil
+}
+
+// ConfigResolution returns the resolution of the Boot's config, as "type=java;profile=javaext;overlays=a,b".
+func ConfigResolution(bootType string, profile string, overlays []string) string {
+	resolution := "type=" + bootType
+	if profile != "" {
+		resolution += ";profile=" + profile
+	}
+	if len(overlays) > 0 {
+		resolution += ";overlays=" + strings.Join(overlays, ",")
+	}
+	return resolution
+}
@@ -0,0 +1,210 @@
+package operator
+
+import (
+	"fmt"
+	appv1 "github.com/logancloud/logan-app-operator/pkg/apis/app/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// grpcHealthProbeCommand is the command executed by the grpc probe, must be provided by the app image.
+	grpcHealthProbeCommand = "grpc_health_probe"
+
+	defaultLivenessInitialDelaySeconds  = int32(120)
+	defaultReadinessInitialDelaySeconds = int32(60)
+	defaultProbeTimeoutSeconds          = int32(5)
+	defaultProbePeriodSeconds           = int32(10)
+	defaultProbeSuccessThreshold        = int32(1)
+	defaultProbeFailureThreshold        = int32(10)
+
+	// The kubernetes default values of the probe, used to avoid the endless update of Deployment.
+	k8sDefaultProbeTimeoutSeconds   = int32(1)
+	k8sDefaultProbePeriodSeconds    = int32(10)
+	k8sDefaultProbeSuccessThreshold = int32(1)
+	k8sDefaultProbeFailureThreshold = int32(3)
+)
+
+// defaultBootProbe return the built-in probe settings of the Boot's type, overridden by the operator config and Boot's spec.
+func defaultBootProbe(boot *appv1.Boot, initialDelaySeconds int32) appv1.BootProbe {
+	timeoutSeconds := defaultProbeTimeoutSeconds
+	periodSeconds := defaultProbePeriodSeconds
+	successThreshold := defaultProbeSuccessThreshold
+	failureThreshold := defaultProbeFailureThreshold
+
+	probe := appv1.BootProbe{
+		Type:                appv1.BootProbeHTTP,
+		InitialDelaySeconds: &initialDelaySeconds,
+		TimeoutSeconds:      &timeoutSeconds,
+		PeriodSeconds:       &periodSeconds,
+		SuccessThreshold:    &successThreshold,
+		FailureThreshold:    &failureThreshold,
+	}
+	if bootType := appv1.GetBootTypeByConfigKey(boot.BootType); bootType != nil {
+		probe = mergeBootProbe(probe, bootType.Probe)
+	}
+
+	return probe
+}
+
+// mergeBootProbe merge the probes in order, the later non-empty fields override the former.
+// Not use Mergo, because the explicit zero value of the pointer fields should override.
+func mergeBootProbe(probe appv1.BootProbe, probes ...*appv1.BootProbe) appv1.BootProbe {
+	for _, p := range probes {
+		if p == nil {
+			continue
+		}
+
+		p = p.DeepCopy()
+		if p.Type != "" {
+			probe.Type = p.Type
+		}
+		if p.Path != "" {
+			probe.Path = p.Path
+		}
+		if p.Port > 0 {
+			probe.Port = p.Port
+		}
+		if len(p.Command) > 0 {
+			probe.Command = p.Command
+		}
+		if p.Service != "" {
+			probe.Service = p.Service
+		}
+		if p.InitialDelaySeconds != nil {
+			probe.InitialDelaySeconds = p.InitialDelaySeconds
+		}
+		if p.TimeoutSeconds != nil {
+			probe.TimeoutSeconds = p.TimeoutSeconds
+		}
+		if p.PeriodSeconds != nil {
+			probe.PeriodSeconds = p.PeriodSeconds
+		}
+		if p.SuccessThreshold != nil {
+			probe.SuccessThreshold = p.SuccessThreshold
+		}
+		if p.FailureThreshold != nil {
+			probe.FailureThreshold = p.FailureThreshold
+		}
+	}
+
+	return probe
+}
+
+// GetHealthProbe return the livenessProbe and readinessProbe for the created container.
+// The probe is nil if it is disabled: the http probe's path is empty or the exec probe's command is empty.
+func (handler *BootHandler) GetHealthProbe() (*corev1.Probe, *corev1.Probe) {
+	boot := handler.Boot
+	appSpec := handler.Config.AppSpec
+
+	healthPath := ""
+	if boot.Spec.Health != nil {
+		healthPath = *boot.Spec.Health
+	}
+
+	// if boot.Spec.Health is empty, ignore the Readiness
+	readinessPath := healthPath
+	if boot.Spec.Readiness != nil && *boot.Spec.Readiness != "" && healthPath != "" {
+		readinessPath = *boot.Spec.Readiness
+	}
+
+	// 1. liveness: Boot's health path overrides the config's path
+	liveness := mergeBootProbe(defaultBootProbe(boot, defaultLivenessInitialDelaySeconds), appSpec.LivenessProbe)
+	liveness.Path = healthPath
+	liveness = mergeBootProbe(liveness, boot.Spec.LivenessProbe)
+	// Liveness's successThreshold must be 1
+	successThreshold := int32(1)
+	liveness.SuccessThreshold = &successThreshold
+
+	// 2. readiness: Boot's readiness path overrides the config's path
+	readiness := mergeBootProbe(defaultBootProbe(boot, defaultReadinessInitialDelaySeconds), appSpec.ReadinessProbe)
+	readiness.Path = readinessPath
+	readiness = mergeBootProbe(readiness, boot.Spec.ReadinessProbe)
+
+	return handler.NewProbe(liveness), handler.NewProbe(readiness)
+}
+
+// NewProbe returns a new created container Probe from the BootProbe, return nil if the probe is disabled.
+func (handler *BootHandler) NewProbe(bootProbe appv1.BootProbe) *corev1.Probe {
+	boot := handler.Boot
+
+	port := AppContainerHealthPort(boot, handler.Config.AppSpec)
+	if bootProbe.Port > 0 {
+		port = intstr.IntOrString{Type: intstr.Int, IntVal: bootProbe.Port}
+	}
+
+	probe := &corev1.Probe{}
+	switch bootProbe.Type {
+	case appv1.BootProbeTCP:
+		probe.TCPSocket = &corev1.TCPSocketAction{
+			Port: port,
+		}
+	case appv1.BootProbeExec:
+		if len(bootProbe.Command) == 0 {
+			return nil
+		}
+		probe.Exec = &corev1.ExecAction{
+			Command: bootProbe.Command,
+		}
+	case appv1.BootProbeGRPC:
+		command := []string{grpcHealthProbeCommand, fmt.Sprintf("-addr=:%d", port.IntVal)}
+		if bootProbe.Service != "" {
+			command = append(command, "-service="+bootProbe.Service)
+		}
+		probe.Exec = &corev1.ExecAction{
+			Command: command,
+		}
+	default:
+		if bootProbe.Path == "" {
+			return nil
+		}
+		probe.HTTPGet = &corev1.HTTPGetAction{
+			Path:   bootProbe.Path,
+			Port:   port,
+			Scheme: corev1.URISchemeHTTP,
+		}
+	}
+
+	if bootProbe.InitialDelaySeconds != nil {
+		probe.InitialDelaySeconds = *bootProbe.InitialDelaySeconds
+	}
+	if bootProbe.TimeoutSeconds != nil {
+		probe.TimeoutSeconds = *bootProbe.TimeoutSeconds
+	}
+	if bootProbe.PeriodSeconds != nil {
+		probe.PeriodSeconds = *bootProbe.PeriodSeconds
+	}
+	if bootProbe.SuccessThreshold != nil {
+		probe.SuccessThreshold = *bootProbe.SuccessThreshold
+	}
+	if bootProbe.FailureThreshold != nil {
+		probe.FailureThreshold = *bootProbe.FailureThreshold
+	}
+	setProbeDefaults(probe)
+
+	return probe
+}
+
+// setProbeDefaults set the kubernetes default values to the probe's empty fields,
+// so the probe could be compared with the Deployment's probe.
+func setProbeDefaults(probe *corev1.Probe) {
+	if probe == nil {
+		return
+	}
+
+	if probe.TimeoutSeconds <= 0 {
+		probe.TimeoutSeconds = k8sDefaultProbeTimeoutSeconds
+	}
+	if probe.PeriodSeconds <= 0 {
+		probe.PeriodSeconds = k8sDefaultProbePeriodSeconds
+	}
+	if probe.SuccessThreshold <= 0 {
+		probe.SuccessThreshold = k8sDefaultProbeSuccessThreshold
+	}
+	if probe.FailureThreshold <= 0 {
+		probe.FailureThreshold = k8sDefaultProbeFailureThreshold
+	}
+	if probe.HTTPGet != nil && probe.HTTPGet.Scheme == "" {
+		probe.HTTPGet.Scheme = corev1.URISchemeHTTP
+	}
+}
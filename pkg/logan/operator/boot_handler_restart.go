@@ -0,0 +1,156 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	appv1 "github.com/logancloud/logan-app-operator/pkg/apis/app/v1"
+	"github.com/logancloud/logan-app-operator/pkg/logan"
+	"github.com/logancloud/logan-app-operator/pkg/logan/config"
+	loganMetrics "github.com/logancloud/logan-app-operator/pkg/logan/metrics"
+	"github.com/logancloud/logan-app-operator/pkg/logan/util"
+	"github.com/logancloud/logan-app-operator/pkg/logan/util/keys"
+	"hash/fnv"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"strconv"
+	"time"
+)
+
+// BootRestartSchedule return the Boot's restart schedule, merged in order: the operator config's restartSchedule
+// and the Boot's restartSchedule. The later non-empty fields override the former. Return nil if not enabled.
+func BootRestartSchedule(boot *appv1.Boot, appSpec *config.AppSpec) *appv1.BootRestartSchedule {
+	restart := &appv1.BootRestartSchedule{}
+	for _, r := range []*appv1.BootRestartSchedule{appSpec.RestartSchedule, boot.Spec.RestartSchedule} {
+		if r == nil {
+			continue
+		}
+
+		r = r.DeepCopy()
+		if r.Enabled != nil {
+			restart.Enabled = r.Enabled
+		}
+		if r.Schedule != "" {
+			restart.Schedule = r.Schedule
+		}
+		if r.MaxJitterSeconds != nil {
+			restart.MaxJitterSeconds = r.MaxJitterSeconds
+		}
+	}
+
+	if restart.Schedule == "" || (restart.Enabled != nil && !*restart.Enabled) {
+		return nil
+	}
+	return restart
+}
+
+// restartJitter return the delay after the schedule fired at the time, in [0, maxJitterSeconds].
+// The delay is hashed from the Boot's name and the fired time, so it is stable between the reconciles.
+func restartJitter(boot *appv1.Boot, restart *appv1.BootRestartSchedule, fired time.Time) time.Duration {
+	if restart.MaxJitterSeconds == nil || *restart.MaxJitterSeconds <= 0 {
+		return 0
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(boot.Namespace + "/" + boot.Name + "/" + strconv.FormatInt(fired.Unix(), 10)))
+	seconds := h.Sum32() % uint32(*restart.MaxJitterSeconds+1)
+	return time.Duration(seconds) * time.Second
+}
+
+// NextScheduledRestart return the next time when the Boot is restarted by the restart schedule, including the jitter.
+// The last fired time is the latest handled fired time, the schedule fired after it is pending until its jitter passed.
+// Return zero time if the restart schedule is not enabled or invalid.
+func NextScheduledRestart(boot *appv1.Boot, appSpec *config.AppSpec, lastFired time.Time, now time.Time) time.Time {
+	restart := BootRestartSchedule(boot, appSpec)
+	if restart == nil {
+		return time.Time{}
+	}
+
+	cron, err := util.ParseCron(restart.Schedule)
+	if err != nil {
+		return time.Time{}
+	}
+
+	now = now.In(logan.Location)
+	if prev := cron.Prev(now); !prev.IsZero() && prev.After(lastFired) {
+		return prev.Add(restartJitter(boot, restart, prev))
+	}
+
+	next := cron.Next(now)
+	if next.IsZero() {
+		return next
+	}
+	return next.Add(restartJitter(boot, restart, next))
+}
+
+// ReconcileScheduledRestart restart the Boot by setting the annotation "app.logancloud.com/restartedAt",
+// when the restart schedule fired and the jitter passed. The fired time is recorded in the Boot's status,
+// when the schedule is first observed, the latest fired time is recorded without restarting.
+// Return requeue true if the Boot is updated, the reconcile should be started again with the new Boot.
+func (handler *BootHandler) ReconcileScheduledRestart() (reconcile.Result, bool, error) {
+	logger := handler.Logger
+	boot := handler.Boot
+	c := handler.Client
+
+	restart := BootRestartSchedule(boot, handler.Config.AppSpec)
+	if restart == nil {
+		return reconcile.Result{}, false, nil
+	}
+
+	cron, err := util.ParseCron(restart.Schedule)
+	if err != nil {
+		logger.Info("Invalid restart schedule, ignore it", "schedule", restart.Schedule, "err", err.Error())
+		return reconcile.Result{}, false, nil
+	}
+
+	now := time.Now().In(logan.Location)
+	prev := cron.Prev(now)
+	if prev.IsZero() {
+		return reconcile.Result{}, false, nil
+	}
+
+	status := handler.OperatorStatus.DeepCopy()
+	lastFired := status.LastScheduledRestartTime
+	if lastFired != nil && !prev.After(lastFired.Time) {
+		return reconcile.Result{}, false, nil
+	}
+
+	// 1. The schedule fired, restart after the jitter.
+	if lastFired != nil {
+		restartAt := prev.Add(restartJitter(boot, restart, prev))
+		if restartAt.After(now) {
+			return reconcile.Result{}, false, nil
+		}
+
+		obj, ok := handler.OperatorBoot.(runtime.Object)
+		if !ok {
+			return reconcile.Result{}, true, fmt.Errorf("boot %s/%s is not a runtime object", boot.Namespace, boot.Name)
+		}
+
+		restartedAt := restartAt.Format(time.RFC3339)
+		logger.Info("Restarting Boot by the restart schedule", "schedule", restart.Schedule, "restartedAt", restartedAt)
+		handler.UpdateAnnotation(map[string]string{
+			keys.BootRestartedAtAnnotationKey: restartedAt,
+		})
+		err = c.Update(context.TODO(), obj)
+		if err != nil {
+			msg := "Failed to restart Boot by the restart schedule"
+			logger.Info(msg, "err", err.Error())
+			loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_UPDATE_STAGE, loganMetrics.RECONCILE_RESTART_BOOT_SUBSTAGE, boot.Name)
+			handler.RecordEvent(keys.FailedScheduledRestartBoot, msg, err)
+			return reconcile.Result{Requeue: true}, true, err
+		}
+
+		handler.RecordEvent(keys.ScheduledRestartBoot, fmt.Sprintf("Restarted Boot by the restart schedule at %s", restartedAt), nil)
+	}
+
+	// 2. Record the handled fired time. The decoded time is local, keep the same location to avoid the endless update.
+	fired := metav1.NewTime(prev.Local())
+	status.LastScheduledRestartTime = &fired
+	err = handler.updateBootStatus(status)
+	if err != nil {
+		return reconcile.Result{Requeue: true}, true, err
+	}
+
+	return reconcile.Result{Requeue: true}, true, nil
+}
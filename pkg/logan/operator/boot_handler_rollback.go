@@ -0,0 +1,309 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	appv1 "github.com/logancloud/logan-app-operator/pkg/apis/app/v1"
+	"github.com/logancloud/logan-app-operator/pkg/logan"
+	"github.com/logancloud/logan-app-operator/pkg/logan/config"
+	loganMetrics "github.com/logancloud/logan-app-operator/pkg/logan/metrics"
+	"github.com/logancloud/logan-app-operator/pkg/logan/util/keys"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"strconv"
+)
+
+const (
+	// podCrashLoopBackOff is the reason of the container's waiting state, when the container restarts repeatedly.
+	podCrashLoopBackOff = "CrashLoopBackOff"
+	// crashLoopRestartThreshold is the minimum restart count of the crash looping container to fail the rollout.
+	crashLoopRestartThreshold = int32(3)
+	// deploymentRevisionAnnotation is the revision annotation of the Deployment and its ReplicaSets.
+	deploymentRevisionAnnotation = "deployment.kubernetes.io/revision"
+)
+
+// RollbackRevisionId return the revision's ID which the Boot is requested to roll back to by annotation.
+// Return false if the Boot is not requested to roll back.
+func RollbackRevisionId(meta metav1.Object) (string, bool) {
+	annotations := meta.GetAnnotations()
+	if annotations == nil {
+		return "", false
+	}
+
+	revisionId, found := annotations[keys.BootRollbackToAnnotationKey]
+	return revisionId, found
+}
+
+// FindRevision return the revision with the ID from the revision list, nil if not found.
+func FindRevision(revisionList *appv1.BootRevisionList, revisionId string) *appv1.BootRevision {
+	id, err := strconv.Atoi(revisionId)
+	if err != nil || revisionList == nil {
+		return nil
+	}
+
+	for i := range revisionList.Items {
+		if revisionList.Items[i].GetRevisionId() == id {
+			return &revisionList.Items[i]
+		}
+	}
+	return nil
+}
+
+// RestoreRevisionSpec return the Boot's spec restored from the revision.
+// The Boot's replicas, suspend, scaling schedules and restart schedule are kept, and the business envs which are not recorded in the revision are re-applied from the Boot.
+func RestoreRevisionSpec(boot *appv1.Boot, revision *appv1.BootRevision) *appv1.BootSpec {
+	spec := revision.Spec.DeepCopy()
+	spec.Replicas = boot.Spec.Replicas
+	spec.Suspend = boot.Spec.Suspend
+	spec.ScalingSchedules = boot.Spec.ScalingSchedules
+	spec.RestartSchedule = boot.Spec.RestartSchedule
+
+	envs := cleanEnv(spec.Env)
+	for _, env := range boot.Spec.Env {
+		if _, found := logan.BizEnvs[env.Name]; found {
+			envs = append(envs, *env.DeepCopy())
+		}
+	}
+	spec.Env = envs
+
+	return spec
+}
+
+// ReconcileRollback roll back the Boot to the revision specified by the annotation "app.logancloud.com/rollback-to".
+// The annotation is removed after handled, and the Boot is updated with the restored spec,
+// a new revision marked as a rollback will be recorded by the webhook.
+// Return requeue true if the Boot is updated, the reconcile should be started again with the new Boot.
+func (handler *BootHandler) ReconcileRollback() (reconcile.Result, bool, error) {
+	logger := handler.Logger
+	boot := handler.Boot
+	c := handler.Client
+
+	revisionId, found := RollbackRevisionId(handler.OperatorMeta)
+	if !found {
+		return reconcile.Result{}, false, nil
+	}
+
+	obj, ok := handler.OperatorBoot.(runtime.Object)
+	if !ok {
+		return reconcile.Result{}, true, fmt.Errorf("boot %s/%s is not a runtime object", boot.Namespace, boot.Name)
+	}
+
+	revisionList, err := c.ListRevision(boot.Namespace, PodLabels(boot))
+	if err != nil {
+		msg := "Failed to list Boot Revision"
+		logger.Error(err, msg)
+		loganMetrics.UpdateMainStageErrors(boot.Kind, loganMetrics.RECONCILE_ROLLBACK_BOOT_STAGE, boot.Name)
+		handler.RecordEvent(keys.FailedRollbackBoot, msg, err)
+		return reconcile.Result{}, true, err
+	}
+
+	// 1. The revision is not found, only remove the annotation.
+	revision := FindRevision(revisionList, revisionId)
+	delete(handler.OperatorMeta.Annotations, keys.BootRollbackToAnnotationKey)
+	if revision == nil {
+		msg := fmt.Sprintf("Failed to roll back Boot, revision %s is not found", revisionId)
+		logger.Info(msg)
+		handler.RecordEvent(keys.FailedRollbackBoot, msg, nil)
+
+		err = c.Update(context.TODO(), obj)
+		if err != nil {
+			logger.Info("Failed to remove Boot's rollback annotation", "err", err.Error())
+			loganMetrics.UpdateMainStageErrors(boot.Kind, loganMetrics.RECONCILE_ROLLBACK_BOOT_STAGE, boot.Name)
+		}
+		return reconcile.Result{Requeue: true}, true, nil
+	}
+
+	// 2. Restore the revision's spec and profile.
+	spec := RestoreRevisionSpec(boot, revision)
+	logger.Info("Rolling back Boot", "revision", revisionId, "spec", spec)
+	*handler.OperatorSpec = *spec
+
+	profile, found := revision.Annotations[config.BootProfileAnnotationKey]
+	if found {
+		handler.OperatorMeta.Annotations[config.BootProfileAnnotationKey] = profile
+	} else {
+		delete(handler.OperatorMeta.Annotations, config.BootProfileAnnotationKey)
+	}
+
+	err = c.Update(context.TODO(), obj)
+	if err != nil {
+		msg := fmt.Sprintf("Failed to roll back Boot to revision %s", revisionId)
+		logger.Info(msg, "err", err.Error())
+		loganMetrics.UpdateMainStageErrors(boot.Kind, loganMetrics.RECONCILE_ROLLBACK_BOOT_STAGE, boot.Name)
+		handler.RecordEvent(keys.FailedRollbackBoot, msg, err)
+		return reconcile.Result{Requeue: true}, true, nil
+	}
+
+	handler.RecordEvent(keys.RolledBackBoot, fmt.Sprintf("Rolled back Boot to revision %s", revisionId), nil)
+	return reconcile.Result{Requeue: true}, true, nil
+}
+
+// RolloutFailed return the reason if the Boot's rollout is failed:
+// the Deployment exceeded its progress deadline, or the pods crash loop.
+// The pods must be of the Deployment's current template, and the Deployment's conditions are ignored until its
+// current generation is observed, since they may be of the previous rollout.
+func RolloutFailed(dep *appsv1.Deployment, pods []corev1.Pod) (string, bool) {
+	if dep.Status.ObservedGeneration < dep.Generation {
+		return "", false
+	}
+
+	if deploymentProgressExceeded(dep) {
+		return ReasonProgressDeadlineExceeded, true
+	}
+
+	for _, pod := range pods {
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			waiting := containerStatus.State.Waiting
+			if waiting != nil && waiting.Reason == podCrashLoopBackOff &&
+				containerStatus.RestartCount >= crashLoopRestartThreshold {
+				return podCrashLoopBackOff, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// currentPods return the pods of the workload's current template, the pods of the previous templates are excluded.
+// The Deployment's current pods are selected by the pod-template-hash of its ReplicaSet of the current revision,
+// the StatefulSet's by its update revision. No pods are returned if the current template is not found yet.
+func (handler *BootHandler) currentPods(dep *appsv1.Deployment, pods []corev1.Pod) ([]corev1.Pod, error) {
+	c := handler.Client
+
+	hashKey, hash := "", ""
+	if StatefulSetEnabled(handler.Boot) {
+		sts := &appsv1.StatefulSet{}
+		err := c.Get(context.TODO(), types.NamespacedName{Name: dep.Name, Namespace: dep.Namespace}, sts)
+		if err != nil {
+			return nil, err
+		}
+		hashKey, hash = appsv1.ControllerRevisionHashLabelKey, sts.Status.UpdateRevision
+	} else if revision := dep.Annotations[deploymentRevisionAnnotation]; revision != "" && dep.Spec.Selector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(dep.Spec.Selector)
+		if err != nil {
+			return nil, err
+		}
+		rsList := &appsv1.ReplicaSetList{}
+		err = c.List(context.TODO(), &client.ListOptions{Namespace: dep.Namespace, LabelSelector: selector}, rsList)
+		if err != nil {
+			return nil, err
+		}
+		for i := range rsList.Items {
+			rs := &rsList.Items[i]
+			if metav1.IsControlledBy(rs, dep) && rs.Annotations[deploymentRevisionAnnotation] == revision {
+				hashKey, hash = appsv1.DefaultDeploymentUniqueLabelKey, rs.Labels[appsv1.DefaultDeploymentUniqueLabelKey]
+				break
+			}
+		}
+	}
+
+	current := make([]corev1.Pod, 0)
+	if hash == "" {
+		return current, nil
+	}
+	for _, pod := range pods {
+		if pod.Labels[hashKey] == hash {
+			current = append(current, pod)
+		}
+	}
+	return current, nil
+}
+
+// SelectStableRevision return the latest Complete or Active revision before the revision's ID, nil if not found.
+func SelectStableRevision(revisionList *appv1.BootRevisionList, beforeId int) *appv1.BootRevision {
+	var stable *appv1.BootRevision
+	for i := range revisionList.Items {
+		revision := &revisionList.Items[i]
+		revisionId := revision.GetRevisionId()
+		if revisionId >= beforeId || (stable != nil && revisionId <= stable.GetRevisionId()) {
+			continue
+		}
+
+		phase := revision.Annotations[keys.BootRevisionPhaseAnnotationKey]
+		if phase == RevisionPhaseComplete || phase == RevisionPhaseActive {
+			stable = revision
+		}
+	}
+	return stable
+}
+
+// reconcileAutoRollback will mark the latest revision Failed and request to roll back to the last stable revision,
+// if the Boot's auto rollback is enabled and the latest revision failed to become Active.
+// The rollback is requested by the Boot's annotation, and done by ReconcileRollback in the next reconcile.
+// Return true if the Boot's annotation is updated.
+func (handler *BootHandler) reconcileAutoRollback(dep *appsv1.Deployment, pods []corev1.Pod,
+	revisionList *appv1.BootRevisionList, latestRevision *appv1.BootRevision, revisionAnnotationMap map[string]string) bool {
+	logger := handler.Logger
+	boot := handler.Boot
+
+	strategy := BootStrategy(boot, handler.Config.AppSpec)
+	if strategy.AutoRollback == nil || !*strategy.AutoRollback {
+		return false
+	}
+
+	// The latest revision keeps Failed until the rollback is done.
+	reason := ""
+	if latestRevision.Annotations[keys.BootRevisionPhaseAnnotationKey] != RevisionPhaseFailed {
+		if revisionAnnotationMap[keys.BootRevisionPhaseAnnotationKey] != RevisionPhaseRunning {
+			return false
+		}
+
+		current, err := handler.currentPods(dep, pods)
+		if err != nil {
+			logger.Info("Failed to select the pods of the current template", "err", err.Error())
+			return false
+		}
+		failedReason, failed := RolloutFailed(dep, current)
+		if !failed {
+			return false
+		}
+		reason = failedReason
+	}
+	revisionAnnotationMap[keys.BootRevisionPhaseAnnotationKey] = RevisionPhaseFailed
+
+	latestId := latestRevision.GetRevisionId()
+	stable := SelectStableRevision(revisionList, latestId)
+	if stable == nil {
+		if reason != "" {
+			msg := fmt.Sprintf("Revision %d failed to become Active: %s, no stable revision to roll back", latestId, reason)
+			logger.Info(msg)
+			handler.RecordEvent(keys.FailedRolloutBoot, msg, nil)
+		}
+		return false
+	}
+
+	stableId := strconv.Itoa(stable.GetRevisionId())
+
+	// A rollback revision, or a revision of the stable spec, is not rolled back again, which would restore the same
+	// spec without a new revision and request the rollback on every reconcile.
+	rollbackId, isRollback := latestRevision.Annotations[keys.BootRevisionRollbackAnnotationKey]
+	if isRollback || latestRevision.Annotations[keys.BootRevisionHashAnnotationKey] == stable.Annotations[keys.BootRevisionHashAnnotationKey] {
+		if reason != "" {
+			msg := fmt.Sprintf("Revision %d failed to become Active: %s, not rolled back automatically", latestId, reason)
+			if isRollback {
+				msg = fmt.Sprintf("%s, it is a rollback to revision %s", msg, rollbackId)
+			} else {
+				msg = fmt.Sprintf("%s, it is the same as the stable revision %s", msg, stableId)
+			}
+			logger.Info(msg)
+			handler.RecordEvent(keys.FailedRolloutBoot, msg, nil)
+		}
+		return false
+	}
+
+	if reason != "" {
+		msg := fmt.Sprintf("Revision %d failed to become Active: %s, rolling back to revision %s", latestId, reason, stableId)
+		logger.Info(msg)
+		handler.RecordEvent(keys.FailedRolloutBoot, msg, nil)
+		loganMetrics.UpdateAutoRollbacks(boot.Kind, boot.Name, reason)
+	}
+
+	return handler.UpdateAnnotation(map[string]string{
+		keys.BootRollbackToAnnotationKey: stableId,
+	})
+}
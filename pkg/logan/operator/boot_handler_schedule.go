@@ -0,0 +1,79 @@
+package operator
+
+import (
+	appv1 "github.com/logancloud/logan-app-operator/pkg/apis/app/v1"
+	"github.com/logancloud/logan-app-operator/pkg/logan"
+	"github.com/logancloud/logan-app-operator/pkg/logan/util"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"time"
+)
+
+// minScheduleRequeueAfter is the minimum delay to requeue at the next schedule's boundary.
+const minScheduleRequeueAfter = time.Second
+
+// ActiveScalingSchedule return the Boot's scaling schedule which fired latest at the time, nil if none fired,
+// and the next time when one of the schedules fires, zero if none. The schedules are in the operator's timezone.
+// The invalid schedules are ignored, which are rejected by the webhook.
+func ActiveScalingSchedule(boot *appv1.Boot, now time.Time) (*appv1.BootScalingSchedule, time.Time) {
+	now = now.In(logan.Location)
+
+	var active *appv1.BootScalingSchedule
+	var activeTime, nextTime time.Time
+	for i := range boot.Spec.ScalingSchedules {
+		schedule := &boot.Spec.ScalingSchedules[i]
+		cron, err := util.ParseCron(schedule.Schedule)
+		if err != nil {
+			continue
+		}
+
+		if prev := cron.Prev(now); !prev.IsZero() && (active == nil || prev.After(activeTime)) {
+			active, activeTime = schedule, prev
+		}
+		if next := cron.Next(now); !next.IsZero() && (nextTime.IsZero() || next.Before(nextTime)) {
+			nextTime = next
+		}
+	}
+
+	return active, nextTime
+}
+
+// ScheduledReplicas return the replicas of the Boot's active scaling schedule.
+// Return false if no schedule is active, or the active schedule scales back to the Boot's replicas.
+func ScheduledReplicas(boot *appv1.Boot) (int32, bool) {
+	active, _ := ActiveScalingSchedule(boot, time.Now())
+	if active == nil || active.Replicas == nil {
+		return 0, false
+	}
+	return *active.Replicas, true
+}
+
+// scheduleResult return the result requeued at the next time when one of the Boot's scaling schedules fires,
+// or the Boot is restarted by the restart schedule, merged with the delayed result, the earliest one wins.
+func (handler *BootHandler) scheduleResult(result reconcile.Result) reconcile.Result {
+	now := time.Now()
+	_, nextScale := ActiveScalingSchedule(handler.Boot, now)
+	result = requeueAt(result, nextScale)
+
+	var lastFired time.Time
+	if handler.OperatorStatus.LastScheduledRestartTime != nil {
+		lastFired = handler.OperatorStatus.LastScheduledRestartTime.Time
+	}
+	nextRestart := NextScheduledRestart(handler.Boot, handler.Config.AppSpec, lastFired, now)
+	return requeueAt(result, nextRestart)
+}
+
+// requeueAt return the result requeued at the time if it is earlier than the result's delay, the zero time is ignored.
+func requeueAt(result reconcile.Result, t time.Time) reconcile.Result {
+	if t.IsZero() {
+		return result
+	}
+
+	after := time.Until(t)
+	if after < minScheduleRequeueAfter {
+		after = minScheduleRequeueAfter
+	}
+	if result.RequeueAfter == 0 || after < result.RequeueAfter {
+		result.RequeueAfter = after
+	}
+	return result
+}
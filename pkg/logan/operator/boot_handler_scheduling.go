@@ -0,0 +1,284 @@
+package operator
+
+import (
+	appv1 "github.com/logancloud/logan-app-operator/pkg/apis/app/v1"
+	"github.com/logancloud/logan-app-operator/pkg/logan/util/keys"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// hostnameTopologyKey is the default topology key of the Boot's pods spread
+	hostnameTopologyKey = "kubernetes.io/hostname"
+)
+
+// BootTopologySpread return the Boot's topology spread with default values.
+// If Boot do not specify the topology spread, spread across hosts, preferred with weight 100.
+func BootTopologySpread(boot *appv1.Boot) []appv1.BootTopologySpread {
+	if len(boot.Spec.TopologySpread) == 0 {
+		return []appv1.BootTopologySpread{{
+			TopologyKey:       hostnameTopologyKey,
+			WhenUnsatisfiable: appv1.ScheduleAnyway,
+			Weight:            defaultWeight,
+		}}
+	}
+
+	spreads := make([]appv1.BootTopologySpread, 0, len(boot.Spec.TopologySpread))
+	for _, spread := range boot.Spec.TopologySpread {
+		if spread.WhenUnsatisfiable == "" {
+			spread.WhenUnsatisfiable = appv1.ScheduleAnyway
+		}
+		if spread.Weight <= 0 {
+			spread.Weight = defaultWeight
+		}
+		spreads = append(spreads, spread)
+	}
+	return spreads
+}
+
+// NewAffinity returns the pod's affinity, node affinity from the Boot's spec,
+// and pod anti-affinity from the Boot's topology spread.
+// The anti-affinity is not a topology spread constraint: there is no maxSkew, the required one allows at most one pod
+// per topology domain, and the preferred one only avoids the domains which already have the Boot's pods.
+func NewAffinity(boot *appv1.Boot) *corev1.Affinity {
+	antiAffinity := &corev1.PodAntiAffinity{}
+	for _, spread := range BootTopologySpread(boot) {
+		term := corev1.PodAffinityTerm{
+			LabelSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{
+						Key:      keys.BootNameKey,
+						Operator: metav1.LabelSelectorOpIn,
+						Values:   []string{boot.Name},
+					},
+				},
+			},
+			TopologyKey: spread.TopologyKey,
+		}
+
+		if spread.WhenUnsatisfiable == appv1.DoNotSchedule {
+			antiAffinity.RequiredDuringSchedulingIgnoredDuringExecution =
+				append(antiAffinity.RequiredDuringSchedulingIgnoredDuringExecution, term)
+		} else {
+			antiAffinity.PreferredDuringSchedulingIgnoredDuringExecution =
+				append(antiAffinity.PreferredDuringSchedulingIgnoredDuringExecution, corev1.WeightedPodAffinityTerm{
+					Weight:          spread.Weight,
+					PodAffinityTerm: term,
+				})
+		}
+	}
+
+	affinity := &corev1.Affinity{
+		PodAntiAffinity: antiAffinity,
+	}
+	if boot.Spec.NodeAffinity != nil {
+		affinity.NodeAffinity = boot.Spec.NodeAffinity.DeepCopy()
+	}
+
+	return affinity
+}
+
+// DefaultSchedulingValue will set the scheduling fields from config.
+// 1. Defaults: used when Boot do not specify the field.
+// 2. Mandatory: always merged into the Boot's fields.
+// Return true if should be updated, false if should not be updated
+func (handler *BootHandler) DefaultSchedulingValue() bool {
+	logger := handler.Logger
+	scheduling := handler.Config.AppSpec.Scheduling
+	bootSpec := handler.OperatorSpec
+
+	if scheduling == nil {
+		return false
+	}
+
+	changed := false
+
+	// 1. Defaults
+	defaults := scheduling.Defaults
+	if defaults != nil {
+		if len(bootSpec.Tolerations) == 0 && len(defaults.Tolerations) > 0 {
+			logger.Info("Defaulters", "type", "tolerations", "spec", bootSpec.Tolerations, "default", defaults.Tolerations)
+			bootSpec.Tolerations = copyTolerations(defaults.Tolerations)
+			changed = true
+		}
+
+		if bootSpec.NodeAffinity == nil && defaults.NodeAffinity != nil {
+			logger.Info("Defaulters", "type", "nodeAffinity", "spec", nil, "default", defaults.NodeAffinity)
+			bootSpec.NodeAffinity = defaults.NodeAffinity.DeepCopy()
+			changed = true
+		}
+
+		if len(bootSpec.TopologySpread) == 0 && len(defaults.TopologySpread) > 0 {
+			logger.Info("Defaulters", "type", "topologySpread", "spec", bootSpec.TopologySpread, "default", defaults.TopologySpread)
+			bootSpec.TopologySpread = append([]appv1.BootTopologySpread{}, defaults.TopologySpread...)
+			changed = true
+		}
+
+		if bootSpec.PriorityClassName == "" && defaults.PriorityClassName != "" {
+			logger.Info("Defaulters", "type", "priorityClassName", "spec", "", "default", defaults.PriorityClassName)
+			bootSpec.PriorityClassName = defaults.PriorityClassName
+			changed = true
+		}
+
+		if bootSpec.RuntimeClassName == nil && defaults.RuntimeClassName != nil {
+			runtimeClassName := *defaults.RuntimeClassName
+			logger.Info("Defaulters", "type", "runtimeClassName", "spec", nil, "default", runtimeClassName)
+			bootSpec.RuntimeClassName = &runtimeClassName
+			changed = true
+		}
+	}
+
+	// 2. Mandatory
+	mandatory := scheduling.Mandatory
+	if mandatory != nil {
+		for _, toleration := range mandatory.Tolerations {
+			if mergeToleration(bootSpec, toleration) {
+				logger.Info("Defaulters", "type", "tolerations", "mandatory", toleration)
+				changed = true
+			}
+		}
+
+		if mandatory.NodeAffinity != nil && mergeNodeAffinity(bootSpec, mandatory.NodeAffinity) {
+			logger.Info("Defaulters", "type", "nodeAffinity", "to", bootSpec.NodeAffinity)
+			changed = true
+		}
+
+		// Keep the default spread across hosts when Boot do not specify the topology spread.
+		if len(bootSpec.TopologySpread) == 0 && len(mandatory.TopologySpread) > 0 {
+			bootSpec.TopologySpread = BootTopologySpread(&appv1.Boot{Spec: *bootSpec})
+			logger.Info("Defaulters", "type", "topologySpread", "default", bootSpec.TopologySpread)
+			changed = true
+		}
+		for _, spread := range mandatory.TopologySpread {
+			if mergeTopologySpread(bootSpec, spread) {
+				logger.Info("Defaulters", "type", "topologySpread", "mandatory", spread)
+				changed = true
+			}
+		}
+
+		if mandatory.PriorityClassName != "" && bootSpec.PriorityClassName != mandatory.PriorityClassName {
+			logger.Info("Defaulters", "type", "priorityClassName", "spec", bootSpec.PriorityClassName, "mandatory", mandatory.PriorityClassName)
+			bootSpec.PriorityClassName = mandatory.PriorityClassName
+			changed = true
+		}
+
+		if mandatory.RuntimeClassName != nil &&
+			(bootSpec.RuntimeClassName == nil || *bootSpec.RuntimeClassName != *mandatory.RuntimeClassName) {
+			runtimeClassName := *mandatory.RuntimeClassName
+			logger.Info("Defaulters", "type", "runtimeClassName", "spec", bootSpec.RuntimeClassName, "mandatory", runtimeClassName)
+			bootSpec.RuntimeClassName = &runtimeClassName
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+func copyTolerations(tolerations []corev1.Toleration) []corev1.Toleration {
+	ret := make([]corev1.Toleration, 0, len(tolerations))
+	for _, toleration := range tolerations {
+		ret = append(ret, *toleration.DeepCopy())
+	}
+	return ret
+}
+
+// mergeToleration add or replace the toleration by key and effect, return true if changed.
+func mergeToleration(bootSpec *appv1.BootSpec, toleration corev1.Toleration) bool {
+	for i, bootToleration := range bootSpec.Tolerations {
+		if bootToleration.Key == toleration.Key && bootToleration.Effect == toleration.Effect {
+			if equality.Semantic.DeepEqual(bootToleration, toleration) {
+				return false
+			}
+			bootSpec.Tolerations[i] = *toleration.DeepCopy()
+			return true
+		}
+	}
+
+	bootSpec.Tolerations = append(bootSpec.Tolerations, *toleration.DeepCopy())
+	return true
+}
+
+// mergeTopologySpread add or replace the topology spread by topologyKey, return true if changed.
+func mergeTopologySpread(bootSpec *appv1.BootSpec, spread appv1.BootTopologySpread) bool {
+	for i, bootSpread := range bootSpec.TopologySpread {
+		if bootSpread.TopologyKey == spread.TopologyKey {
+			if bootSpread == spread {
+				return false
+			}
+			bootSpec.TopologySpread[i] = spread
+			return true
+		}
+	}
+
+	bootSpec.TopologySpread = append(bootSpec.TopologySpread, spread)
+	return true
+}
+
+// mergeNodeAffinity merge the mandatory node affinity, return true if changed.
+// The required match expressions are added to every node selector term of the Boot, because the terms are ORed.
+// The preferred terms are added if not found.
+func mergeNodeAffinity(bootSpec *appv1.BootSpec, mandatory *corev1.NodeAffinity) bool {
+	changed := false
+	nodeAffinity := bootSpec.NodeAffinity
+	if nodeAffinity == nil {
+		nodeAffinity = &corev1.NodeAffinity{}
+	}
+
+	// 1. required
+	mandatoryRequired := mandatory.RequiredDuringSchedulingIgnoredDuringExecution
+	if mandatoryRequired != nil && len(mandatoryRequired.NodeSelectorTerms) > 0 {
+		expressions := make([]corev1.NodeSelectorRequirement, 0)
+		for _, term := range mandatoryRequired.NodeSelectorTerms {
+			expressions = append(expressions, term.MatchExpressions...)
+		}
+
+		if nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil ||
+			len(nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms) == 0 {
+			nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{{}},
+			}
+		}
+
+		terms := nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+		for i := range terms {
+			for _, expression := range expressions {
+				if !containsNodeSelectorRequirement(terms[i].MatchExpressions, expression) {
+					terms[i].MatchExpressions = append(terms[i].MatchExpressions, *expression.DeepCopy())
+					changed = true
+				}
+			}
+		}
+	}
+
+	// 2. preferred
+	for _, preferred := range mandatory.PreferredDuringSchedulingIgnoredDuringExecution {
+		found := false
+		for _, bootPreferred := range nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+			if equality.Semantic.DeepEqual(bootPreferred, preferred) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution =
+				append(nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution, *preferred.DeepCopy())
+			changed = true
+		}
+	}
+
+	if changed {
+		bootSpec.NodeAffinity = nodeAffinity
+	}
+	return changed
+}
+
+func containsNodeSelectorRequirement(requirements []corev1.NodeSelectorRequirement, requirement corev1.NodeSelectorRequirement) bool {
+	for _, r := range requirements {
+		if equality.Semantic.DeepEqual(r, requirement) {
+			return true
+		}
+	}
+	return false
+}
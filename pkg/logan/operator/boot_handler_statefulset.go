@@ -0,0 +1,399 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	appv1 "github.com/logancloud/logan-app-operator/pkg/apis/app/v1"
+	loganMetrics "github.com/logancloud/logan-app-operator/pkg/logan/metrics"
+	"github.com/logancloud/logan-app-operator/pkg/logan/util/keys"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// StatefulSetEnabled return true if the Boot's workload is StatefulSet.
+func StatefulSetEnabled(boot *appv1.Boot) bool {
+	return boot.Spec.WorkloadType == appv1.WorkloadTypeStatefulSet
+}
+
+// WorkloadAppType return the Boot's workload type recorded in the Boot's annotation and status.
+func WorkloadAppType(boot *appv1.Boot) string {
+	switch boot.Spec.WorkloadType {
+	case appv1.WorkloadTypeStatefulSet:
+		return keys.AppTypeAnnotationStatefulSet
+	case appv1.WorkloadTypeJob:
+		return keys.AppTypeAnnotationJob
+	case appv1.WorkloadTypeCronJob:
+		return keys.AppTypeAnnotationCronJob
+	}
+	return keys.AppTypeAnnotationDeploy
+}
+
+// HeadlessServiceName return name for the created headless Service, which is the StatefulSet's serviceName.
+func HeadlessServiceName(boot *appv1.Boot) string {
+	return boot.Name + "-headless"
+}
+
+// podManagementPolicy return the StatefulSet's pod management policy, default is OrderedReady.
+func podManagementPolicy(boot *appv1.Boot) appsv1.PodManagementPolicyType {
+	if boot.Spec.PodManagementPolicy == "" {
+		return appsv1.OrderedReadyPodManagement
+	}
+	return boot.Spec.PodManagementPolicy
+}
+
+// NewStatefulSet return a new created Boot's StatefulSet object. The pod template is the same as the Deployment's,
+// the Boot's own PVCs are replaced by the volumeClaimTemplates, the shared PVCs are still mounted by all the pods.
+func (handler *BootHandler) NewStatefulSet() (*appsv1.StatefulSet, error) {
+	boot := handler.Boot
+
+	dep := handler.NewDeployment()
+	template := dep.Spec.Template.DeepCopy()
+	claimTemplates, err := handler.volumeClaimTemplates(template.Spec.Volumes)
+	if err != nil {
+		return nil, err
+	}
+
+	volumes := make([]corev1.Volume, 0, len(template.Spec.Volumes))
+	for _, vol := range template.Spec.Volumes {
+		if !claimTemplateFound(claimTemplates, vol.Name) {
+			volumes = append(volumes, vol)
+		}
+	}
+	template.Spec.Volumes = volumes
+
+	sts := &appsv1.StatefulSet{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "apps/v1",
+			Kind:       "StatefulSet",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      DeployName(boot),
+			Namespace: boot.Namespace,
+			Labels:    DeployLabels(boot),
+			Annotations: map[string]string{
+				keys.StatefulSetHashAnnotationKey: podTemplateHash(template),
+			},
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas:             dep.Spec.Replicas,
+			Selector:             dep.Spec.Selector,
+			Template:             *template,
+			VolumeClaimTemplates: claimTemplates,
+			ServiceName:          HeadlessServiceName(boot),
+			PodManagementPolicy:  podManagementPolicy(boot),
+			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+				Type: appsv1.RollingUpdateStatefulSetStrategyType,
+			},
+			RevisionHistoryLimit: dep.Spec.RevisionHistoryLimit,
+		},
+	}
+
+	// Set Boot instance as the owner and controller
+	_ = controllerutil.SetControllerReference(handler.OperatorBoot, sts, handler.Scheme)
+
+	return sts, nil
+}
+
+// volumeClaimTemplates return the StatefulSet's volumeClaimTemplates, derived from the Boot's own PVCs.
+// Each pod gets its PVC "<pvc>-<name>-<ordinal>" with the same storage class, access modes and size.
+// The template is named as the pod template's volume of the PVC, which replaces the volume and is referred by
+// the volumeMounts.
+func (handler *BootHandler) volumeClaimTemplates(volumes []corev1.Volume) ([]corev1.PersistentVolumeClaim, error) {
+	boot := handler.Boot
+
+	templates := make([]corev1.PersistentVolumeClaim, 0)
+	for _, pvcMount := range boot.Spec.Pvc {
+		pvcName, _ := Decode(boot, pvcMount.Name)
+		pvc := &corev1.PersistentVolumeClaim{}
+		err := handler.Client.Get(context.TODO(), types.NamespacedName{Name: pvcName, Namespace: boot.Namespace}, pvc)
+		if err != nil {
+			return nil, err
+		}
+
+		// The shared PVC is mounted by all the pods.
+		if pvc.Labels[keys.SharedKey] == "true" {
+			continue
+		}
+
+		volumeName := pvcName
+		for _, vol := range volumes {
+			if vol.PersistentVolumeClaim != nil && vol.PersistentVolumeClaim.ClaimName == pvcName {
+				volumeName = vol.Name
+				break
+			}
+		}
+
+		templates = append(templates, corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   volumeName,
+				Labels: PodLabels(boot),
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes:      pvc.Spec.AccessModes,
+				Resources:        pvc.Spec.Resources,
+				StorageClassName: pvc.Spec.StorageClassName,
+				VolumeMode:       pvc.Spec.VolumeMode,
+			},
+		})
+	}
+	return templates, nil
+}
+
+func claimTemplateFound(templates []corev1.PersistentVolumeClaim, name string) bool {
+	for _, template := range templates {
+		if template.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// NewHeadlessService return a new created headless Service, which gives the StatefulSet's pods stable network names.
+// The not ready pods are also published, so the pods could find each other when starting.
+func (handler *BootHandler) NewHeadlessService() *corev1.Service {
+	boot := handler.Boot
+
+	svc := handler.createService(AppServicePorts(boot), HeadlessServiceName(boot), nil,
+		corev1.ServiceTypeClusterIP, PodLabels(boot))
+	svc.Spec.ClusterIP = corev1.ClusterIPNone
+	svc.Spec.PublishNotReadyAddresses = true
+	return svc
+}
+
+// StatefulSetView return the Deployment view of the StatefulSet, which shares the Services, status and revision phase
+// computing with the Deployment. The pinned kubernetes has no available replicas of StatefulSet, use the ready replicas.
+func StatefulSetView(sts *appsv1.StatefulSet) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: sts.ObjectMeta,
+		Spec: appsv1.DeploymentSpec{
+			Replicas: sts.Spec.Replicas,
+			Selector: sts.Spec.Selector,
+			Template: sts.Spec.Template,
+		},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: sts.Status.ObservedGeneration,
+			Replicas:           sts.Status.Replicas,
+			ReadyReplicas:      sts.Status.ReadyReplicas,
+			AvailableReplicas:  sts.Status.ReadyReplicas,
+			UpdatedReplicas:    sts.Status.UpdatedReplicas,
+		},
+	}
+}
+
+// getWorkload return the Boot's Deployment, or the Deployment view of the StatefulSet if the workload is StatefulSet.
+func (handler *BootHandler) getWorkload() (*appsv1.Deployment, error) {
+	boot := handler.Boot
+	c := handler.Client
+	key := types.NamespacedName{Name: DeployName(boot), Namespace: boot.Namespace}
+
+	if StatefulSetEnabled(boot) {
+		stsFound := &appsv1.StatefulSet{}
+		err := c.Get(context.TODO(), key, stsFound)
+		if err != nil {
+			return nil, err
+		}
+		return StatefulSetView(stsFound), nil
+	}
+
+	depFound := &appsv1.Deployment{}
+	err := c.Get(context.TODO(), key, depFound)
+	if err != nil {
+		return nil, err
+	}
+	return depFound, nil
+}
+
+// reconcileCreateStatefulSet will create the StatefulSet if it is not found.
+// Return the Deployment view of the StatefulSet, and true if created.
+func (handler *BootHandler) reconcileCreateStatefulSet() (*appsv1.Deployment, bool, error) {
+	boot := handler.Boot
+	logger := handler.Logger
+	c := handler.Client
+
+	stsFound := &appsv1.StatefulSet{}
+	stsName := DeployName(boot)
+	err := c.Get(context.TODO(), types.NamespacedName{Name: stsName, Namespace: boot.Namespace}, stsFound)
+	if err == nil {
+		return StatefulSetView(stsFound), false, nil
+	}
+
+	if !errors.IsNotFound(err) {
+		msg := fmt.Sprintf("Failed to get StatefulSet: %s", stsName)
+		logger.Error(err, msg)
+		loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_CREATE_STAGE, loganMetrics.RECONCILE_GET_STATEFULSET_SUBSTAGE, boot.Name)
+		handler.RecordEvent(keys.FailedGetStatefulSet, msg, err)
+		return nil, false, err
+	}
+
+	sts, err := handler.NewStatefulSet()
+	if err == nil {
+		logger.Info("Creating StatefulSet", "statefulset", sts.Name, "containers", sts.Spec.Template.Spec.Containers)
+		err = c.Create(context.TODO(), sts)
+	}
+	if err != nil {
+		msg := fmt.Sprintf("Failed to create StatefulSet: %s", stsName)
+		logger.Error(err, msg)
+		loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_CREATE_STAGE, loganMetrics.RECONCILE_CREATE_STATEFULSET_SUBSTAGE, boot.Name)
+		handler.RecordEvent(keys.FailedCreateStatefulSet, msg, err)
+		return nil, false, err
+	}
+
+	handler.RecordEvent(keys.CreatedStatefulSet, fmt.Sprintf("Created StatefulSet: %s", stsName), nil)
+	return StatefulSetView(sts), true, nil
+}
+
+// reconcileUpdateStatefulSet handle update logic of StatefulSet, return the Deployment view of the StatefulSet.
+// 1. replicas: the same as Deployment, decided by the Boot, scaling schedules and HorizontalPodAutoscaler.
+// 2. pod template: replaced with rolling update if the template's hash is changed, or the live template's fields are
+// changed as the Deployment's are checked, so the manual changes are reverted, including the restartedAt annotation.
+// 3. revisionHistoryLimit: updated in place.
+// The volumeClaimTemplates and podManagementPolicy are immutable, they are kept as created.
+func (handler *BootHandler) reconcileUpdateStatefulSet() (*appsv1.Deployment, reconcile.Result, bool, error) {
+	boot := handler.Boot
+	logger := handler.Logger
+	c := handler.Client
+
+	stsFound := &appsv1.StatefulSet{}
+	stsName := DeployName(boot)
+	err := c.Get(context.TODO(), types.NamespacedName{Name: stsName, Namespace: boot.Namespace}, stsFound)
+	if err != nil {
+		logger.Error(err, "Failed to get StatefulSet")
+		loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_UPDATE_STAGE, loganMetrics.RECONCILE_GET_STATEFULSET_SUBSTAGE, boot.Name)
+		return nil, reconcile.Result{Requeue: true}, true, err
+	}
+
+	expectSts, err := handler.NewStatefulSet()
+	if err != nil {
+		msg := fmt.Sprintf("Failed to update StatefulSet: %s", stsName)
+		logger.Info(msg, "err", err.Error())
+		loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_UPDATE_STAGE, loganMetrics.RECONCILE_UPDATE_STATEFULSET_SUBSTAGE, boot.Name)
+		handler.RecordEvent(keys.FailedUpdateStatefulSet, msg, err)
+		return nil, reconcile.Result{Requeue: true}, true, err
+	}
+
+	reason := "Updating StatefulSet"
+	updated := false
+
+	// 1. Check ownerReferences
+	if len(stsFound.OwnerReferences) == 0 {
+		logger.Info(reason, "type", "ownerReferences", "statefulset", stsName)
+		_ = controllerutil.SetControllerReference(handler.OperatorBoot, stsFound, handler.Scheme)
+		updated = true
+	}
+
+	// 2. Check size, the same as Deployment.
+	size := expectSts.Spec.Replicas
+	if *stsFound.Spec.Replicas != *size {
+		logger.Info(reason, "type", "replicas", "statefulset", stsName,
+			"old", stsFound.Spec.Replicas, "new", size)
+		*stsFound.Spec.Replicas = *size
+		updated = true
+	}
+
+	// 3. Check pod template: the hash of the expected template, and the live template's fields.
+	expectHash := expectSts.Annotations[keys.StatefulSetHashAnnotationKey]
+	templateChanged := stsFound.Annotations[keys.StatefulSetHashAnnotationKey] != expectHash
+	if !templateChanged {
+		templateChanged = handler.podTemplateChanged(stsName, &stsFound.Spec.Template, &expectSts.Spec.Template)
+	}
+	if templateChanged {
+		logger.Info(reason, "type", "template", "statefulset", stsName, "new", expectSts.Spec.Template)
+		if stsFound.Annotations == nil {
+			stsFound.Annotations = make(map[string]string)
+		}
+		stsFound.Annotations[keys.StatefulSetHashAnnotationKey] = expectHash
+		stsFound.Spec.Template = expectSts.Spec.Template
+		updated = true
+	}
+
+	// 4. Check revisionHistoryLimit
+	if !equality.Semantic.DeepEqual(stsFound.Spec.RevisionHistoryLimit, expectSts.Spec.RevisionHistoryLimit) {
+		logger.Info(reason, "type", "revisionHistoryLimit", "statefulset", stsName,
+			"old", stsFound.Spec.RevisionHistoryLimit, "new", expectSts.Spec.RevisionHistoryLimit)
+		stsFound.Spec.RevisionHistoryLimit = expectSts.Spec.RevisionHistoryLimit
+		updated = true
+	}
+
+	if !updated {
+		return StatefulSetView(stsFound), reconcile.Result{}, false, nil
+	}
+
+	err = c.Update(context.TODO(), stsFound)
+	if err != nil {
+		msg := fmt.Sprintf("Failed to update StatefulSet: %s", stsName)
+		logger.Info(msg, "err", err.Error())
+		loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_UPDATE_STAGE, loganMetrics.RECONCILE_UPDATE_STATEFULSET_SUBSTAGE, boot.Name)
+		handler.RecordEvent(keys.FailedUpdateStatefulSet, msg, err)
+		return nil, reconcile.Result{Requeue: true}, true, err
+	}
+
+	handler.RecordEvent(keys.UpdatedStatefulSet, fmt.Sprintf("Updated StatefulSet: %s", stsName), nil)
+	return nil, reconcile.Result{Requeue: true}, true, nil
+}
+
+// podTemplateChanged return true if the live pod template's fields are changed from the expected template, the same
+// fields as the Deployment's: the app container's image, env, ports, resources, probes, command and volumeMounts,
+// the nodeSelector, affinity, tolerations, priorityClassName, runtimeClassName and the restartedAt annotation.
+func (handler *BootHandler) podTemplateChanged(name string, live *corev1.PodTemplateSpec, expect *corev1.PodTemplateSpec) bool {
+	logger := handler.Logger
+	reason := "Updating StatefulSet"
+
+	if len(live.Spec.Containers) == 0 {
+		logger.Info(reason, "type", "containers", "statefulset", name)
+		return true
+	}
+
+	liveContainer := live.Spec.Containers[0]
+	expectContainer := expect.Spec.Containers[0]
+	fields := []struct {
+		name   string
+		live   interface{}
+		expect interface{}
+	}{
+		{"image", liveContainer.Image, expectContainer.Image},
+		{"env", liveContainer.Env, expectContainer.Env},
+		{"port", liveContainer.Ports, expectContainer.Ports},
+		{"resources", liveContainer.Resources, expectContainer.Resources},
+		{"liveness", liveContainer.LivenessProbe, expectContainer.LivenessProbe},
+		{"readiness", liveContainer.ReadinessProbe, expectContainer.ReadinessProbe},
+		{"command", liveContainer.Command, expectContainer.Command},
+		{"volumeMounts", liveContainer.VolumeMounts, expectContainer.VolumeMounts},
+		{"nodeSelector", live.Spec.NodeSelector, expect.Spec.NodeSelector},
+		{"affinity", live.Spec.Affinity, expect.Spec.Affinity},
+		{"tolerations", live.Spec.Tolerations, expect.Spec.Tolerations},
+		{"priorityClassName", live.Spec.PriorityClassName, expect.Spec.PriorityClassName},
+		{"runtimeClassName", live.Spec.RuntimeClassName, expect.Spec.RuntimeClassName},
+		{"restartedAt", live.Annotations[keys.BootRestartedAtAnnotationKey], expect.Annotations[keys.BootRestartedAtAnnotationKey]},
+	}
+
+	changed := false
+	for _, field := range fields {
+		if !equality.Semantic.DeepEqual(field.live, field.expect) {
+			logger.Info(reason, "type", field.name, "statefulset", name, "old", field.live, "new", field.expect)
+			changed = true
+		}
+	}
+	return changed
+}
+
+// workloadGetSubstage return the metrics substage of getting the Boot's workload.
+func workloadGetSubstage(boot *appv1.Boot) string {
+	if StatefulSetEnabled(boot) {
+		return loganMetrics.RECONCILE_GET_STATEFULSET_SUBSTAGE
+	}
+	return loganMetrics.RECONCILE_GET_DEPLOYMENT_SUBSTAGE
+}
+
+// workloadKind return the kind of the Boot's workload, which is the HorizontalPodAutoscaler's target.
+func workloadKind(boot *appv1.Boot) string {
+	if StatefulSetEnabled(boot) {
+		return "StatefulSet"
+	}
+	return "Deployment"
+}
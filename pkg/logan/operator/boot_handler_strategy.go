@@ -0,0 +1,154 @@
+package operator
+
+import (
+	appv1 "github.com/logancloud/logan-app-operator/pkg/apis/app/v1"
+	"github.com/logancloud/logan-app-operator/pkg/logan/config"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// The kubernetes default values of the Deployment, used to avoid the endless update of Deployment.
+	k8sDefaultMaxSurge                = "25%"
+	k8sDefaultMaxUnavailable          = "25%"
+	k8sDefaultProgressDeadlineSeconds = int32(600)
+)
+
+// defaultBootStrategy return the built-in strategy, overridden by the Boot's type, the operator config and Boot's spec.
+func defaultBootStrategy() appv1.BootStrategy {
+	revisionHistoryLimit := int32(defaultRevisionHistoryLimits)
+	return appv1.BootStrategy{
+		RevisionHistoryLimit: &revisionHistoryLimit,
+	}
+}
+
+// BootStrategy return the Boot's rollout strategy, merged in order: built-in default of the Boot's type,
+// the operator config's strategy and the Boot's strategy. The later non-empty fields override the former.
+func BootStrategy(boot *appv1.Boot, appSpec *config.AppSpec) appv1.BootStrategy {
+	var typeStrategy *appv1.BootStrategy
+	if bootType := appv1.GetBootTypeByConfigKey(boot.BootType); bootType != nil {
+		typeStrategy = bootType.Strategy
+	}
+
+	strategy := defaultBootStrategy()
+	for _, s := range []*appv1.BootStrategy{typeStrategy, appSpec.Strategy, boot.Spec.Strategy} {
+		if s == nil {
+			continue
+		}
+
+		s = s.DeepCopy()
+		if s.Type != "" {
+			strategy.Type = s.Type
+		}
+		if s.MaxSurge != nil {
+			strategy.MaxSurge = s.MaxSurge
+		}
+		if s.MaxUnavailable != nil {
+			strategy.MaxUnavailable = s.MaxUnavailable
+		}
+		if s.MinReadySeconds != nil {
+			strategy.MinReadySeconds = s.MinReadySeconds
+		}
+		if s.ProgressDeadlineSeconds != nil {
+			strategy.ProgressDeadlineSeconds = s.ProgressDeadlineSeconds
+		}
+		if s.RevisionHistoryLimit != nil {
+			strategy.RevisionHistoryLimit = s.RevisionHistoryLimit
+		}
+		if s.AutoRollback != nil {
+			strategy.AutoRollback = s.AutoRollback
+		}
+		if s.Canary != nil {
+			strategy.Canary = s.Canary
+		}
+		if s.BlueGreen != nil {
+			strategy.BlueGreen = s.BlueGreen
+		}
+	}
+
+	return strategy
+}
+
+// SetDeploymentStrategy set the Deployment's strategy, minReadySeconds, progressDeadlineSeconds and revisionHistoryLimit
+// from the Boot's strategy. The empty fields are set to the kubernetes default values.
+func SetDeploymentStrategy(spec *appsv1.DeploymentSpec, strategy appv1.BootStrategy) {
+	if strategy.Type == appsv1.RecreateDeploymentStrategyType {
+		spec.Strategy = appsv1.DeploymentStrategy{
+			Type: appsv1.RecreateDeploymentStrategyType,
+		}
+	} else {
+		maxSurge := intstr.FromString(k8sDefaultMaxSurge)
+		if strategy.MaxSurge != nil {
+			maxSurge = *strategy.MaxSurge
+		}
+		maxUnavailable := intstr.FromString(k8sDefaultMaxUnavailable)
+		if strategy.MaxUnavailable != nil {
+			maxUnavailable = *strategy.MaxUnavailable
+		}
+
+		spec.Strategy = appsv1.DeploymentStrategy{
+			Type: appsv1.RollingUpdateDeploymentStrategyType,
+			RollingUpdate: &appsv1.RollingUpdateDeployment{
+				MaxSurge:       &maxSurge,
+				MaxUnavailable: &maxUnavailable,
+			},
+		}
+	}
+
+	spec.MinReadySeconds = 0
+	if strategy.MinReadySeconds != nil {
+		spec.MinReadySeconds = *strategy.MinReadySeconds
+	}
+
+	progressDeadlineSeconds := k8sDefaultProgressDeadlineSeconds
+	if strategy.ProgressDeadlineSeconds != nil {
+		progressDeadlineSeconds = *strategy.ProgressDeadlineSeconds
+	}
+	spec.ProgressDeadlineSeconds = &progressDeadlineSeconds
+
+	revisionHistoryLimit := int32(defaultRevisionHistoryLimits)
+	if strategy.RevisionHistoryLimit != nil {
+		revisionHistoryLimit = *strategy.RevisionHistoryLimit
+	}
+	spec.RevisionHistoryLimit = &revisionHistoryLimit
+}
+
+// reconcileUpdateStrategy update the Deployment's rollout fields in place, which do not cause rolling update.
+// Return true if the Deployment is changed.
+func (handler *BootHandler) reconcileUpdateStrategy(deploy *appsv1.Deployment) bool {
+	logger := handler.Logger
+	expectSpec := deploy.Spec.DeepCopy()
+	SetDeploymentStrategy(expectSpec, BootStrategy(handler.Boot, handler.Config.AppSpec))
+
+	updated := false
+	if !equality.Semantic.DeepEqual(deploy.Spec.Strategy, expectSpec.Strategy) {
+		logger.Info("Updating Deployment", "type", "strategy", "deploy", deploy.Name,
+			"old", deploy.Spec.Strategy, "new", expectSpec.Strategy)
+		deploy.Spec.Strategy = expectSpec.Strategy
+		updated = true
+	}
+
+	if deploy.Spec.MinReadySeconds != expectSpec.MinReadySeconds {
+		logger.Info("Updating Deployment", "type", "minReadySeconds", "deploy", deploy.Name,
+			"old", deploy.Spec.MinReadySeconds, "new", expectSpec.MinReadySeconds)
+		deploy.Spec.MinReadySeconds = expectSpec.MinReadySeconds
+		updated = true
+	}
+
+	if !equality.Semantic.DeepEqual(deploy.Spec.ProgressDeadlineSeconds, expectSpec.ProgressDeadlineSeconds) {
+		logger.Info("Updating Deployment", "type", "progressDeadlineSeconds", "deploy", deploy.Name,
+			"old", deploy.Spec.ProgressDeadlineSeconds, "new", expectSpec.ProgressDeadlineSeconds)
+		deploy.Spec.ProgressDeadlineSeconds = expectSpec.ProgressDeadlineSeconds
+		updated = true
+	}
+
+	if !equality.Semantic.DeepEqual(deploy.Spec.RevisionHistoryLimit, expectSpec.RevisionHistoryLimit) {
+		logger.Info("Updating Deployment", "type", "revisionHistoryLimit", "deploy", deploy.Name,
+			"old", deploy.Spec.RevisionHistoryLimit, "new", expectSpec.RevisionHistoryLimit)
+		deploy.Spec.RevisionHistoryLimit = expectSpec.RevisionHistoryLimit
+		updated = true
+	}
+
+	return updated
+}
@@ -0,0 +1,199 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	appv1 "github.com/logancloud/logan-app-operator/pkg/apis/app/v1"
+	loganMetrics "github.com/logancloud/logan-app-operator/pkg/logan/metrics"
+	"github.com/logancloud/logan-app-operator/pkg/logan/util/keys"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"strconv"
+)
+
+// Suspended return true if the Boot is suspended by spec or annotation.
+func Suspended(boot *appv1.Boot) bool {
+	if boot.Spec.Suspend != nil && *boot.Spec.Suspend {
+		return true
+	}
+
+	suspend, err := strconv.ParseBool(boot.Annotations[keys.BootSuspendAnnotationKey])
+	return err == nil && suspend
+}
+
+// listDeployments return the Deployments controlled by the Boot,
+// including the canary and blue-green Deployments.
+func (handler *BootHandler) listDeployments() ([]appsv1.Deployment, error) {
+	boot := handler.Boot
+
+	depList := &appsv1.DeploymentList{}
+	listOptions := &client.ListOptions{
+		Namespace:     boot.Namespace,
+		LabelSelector: labels.SelectorFromSet(DeployLabels(boot)),
+	}
+	err := handler.Client.List(context.TODO(), listOptions, depList)
+	if err != nil {
+		return nil, err
+	}
+
+	deps := make([]appsv1.Deployment, 0, len(depList.Items))
+	for _, dep := range depList.Items {
+		if metav1.IsControlledBy(&dep, handler.OperatorBoot) {
+			deps = append(deps, dep)
+		}
+	}
+	return deps, nil
+}
+
+// listStatefulSets return the StatefulSets controlled by the Boot.
+func (handler *BootHandler) listStatefulSets() ([]appsv1.StatefulSet, error) {
+	boot := handler.Boot
+
+	stsList := &appsv1.StatefulSetList{}
+	listOptions := &client.ListOptions{
+		Namespace:     boot.Namespace,
+		LabelSelector: labels.SelectorFromSet(DeployLabels(boot)),
+	}
+	err := handler.Client.List(context.TODO(), listOptions, stsList)
+	if err != nil {
+		return nil, err
+	}
+
+	stss := make([]appsv1.StatefulSet, 0, len(stsList.Items))
+	for _, sts := range stsList.Items {
+		if metav1.IsControlledBy(&sts, handler.OperatorBoot) {
+			stss = append(stss, sts)
+		}
+	}
+	return stss, nil
+}
+
+// suspendReplicas scale the workload's replicas to zero when suspended, remembering the replicas in its annotation,
+// and restore the remembered replicas when resumed. Return true if the workload is changed.
+func (handler *BootHandler) suspendReplicas(meta *metav1.ObjectMeta, replicas **int32, suspended bool) bool {
+	logger := handler.Logger
+	priorReplicas, found := meta.Annotations[keys.SuspendedReplicasAnnotationKey]
+
+	if suspended {
+		// 1. Suspend: remember the replicas, and scale to zero.
+		if found || *replicas == nil || **replicas == 0 {
+			return false
+		}
+
+		logger.Info("Suspending workload", "name", meta.Name, "replicas", **replicas)
+		if meta.Annotations == nil {
+			meta.Annotations = make(map[string]string)
+		}
+		meta.Annotations[keys.SuspendedReplicasAnnotationKey] = strconv.Itoa(int(**replicas))
+		zero := int32(0)
+		*replicas = &zero
+		return true
+	}
+
+	// 2. Resume: restore the remembered replicas.
+	if !found {
+		return false
+	}
+
+	logger.Info("Resuming workload", "name", meta.Name, "replicas", priorReplicas)
+	delete(meta.Annotations, keys.SuspendedReplicasAnnotationKey)
+	if prior, err := strconv.Atoi(priorReplicas); err == nil && *replicas != nil && **replicas == 0 {
+		restored := int32(prior)
+		*replicas = &restored
+	}
+	return true
+}
+
+// ReconcileSuspend scale the Boot's Deployments and StatefulSets to zero when suspended, and restore the prior replicas
+// when resumed. The prior replicas are stored in the workload's annotation, so the Boot's replicas and
+// the HorizontalPodAutoscaler's scaled replicas are both kept.
+// Return requeue true if the Boot is suspended, the other reconciliation is paused,
+// or the workloads are resumed, the reconcile should be started again.
+func (handler *BootHandler) ReconcileSuspend() (reconcile.Result, bool, error) {
+	logger := handler.Logger
+	boot := handler.Boot
+	c := handler.Client
+
+	// The CronJob is suspended by its spec, the one-off Job is not run again when suspended.
+	if BatchEnabled(boot) {
+		return handler.reconcileSuspendJob()
+	}
+
+	suspended := Suspended(boot)
+	deps, err := handler.listDeployments()
+	if err != nil {
+		msg := "Failed to list Deployments"
+		logger.Error(err, msg)
+		loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_SUSPEND_BOOT_STAGE, loganMetrics.RECONCILE_LIST_DEPLOYMENTS_SUBSTAGE, boot.Name)
+		handler.RecordEvent(keys.FailedSuspendBoot, msg, err)
+		return reconcile.Result{Requeue: true}, true, err
+	}
+
+	stss, err := handler.listStatefulSets()
+	if err != nil {
+		msg := "Failed to list StatefulSets"
+		logger.Error(err, msg)
+		loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_SUSPEND_BOOT_STAGE, loganMetrics.RECONCILE_LIST_STATEFULSETS_SUBSTAGE, boot.Name)
+		handler.RecordEvent(keys.FailedSuspendBoot, msg, err)
+		return reconcile.Result{Requeue: true}, true, err
+	}
+
+	updated := false
+	for i := range deps {
+		dep := &deps[i]
+		if !handler.suspendReplicas(&dep.ObjectMeta, &dep.Spec.Replicas, suspended) {
+			continue
+		}
+
+		err = c.Update(context.TODO(), dep)
+		if err != nil {
+			msg := fmt.Sprintf("Failed to update Deployment: %s", dep.Name)
+			logger.Info(msg, "err", err.Error())
+			loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_SUSPEND_BOOT_STAGE, loganMetrics.RECONCILE_UPDATE_DEPLOYMENT_SUBSTAGE, boot.Name)
+			handler.RecordEvent(keys.FailedSuspendBoot, msg, err)
+			return reconcile.Result{Requeue: true}, true, err
+		}
+		updated = true
+	}
+
+	for i := range stss {
+		sts := &stss[i]
+		if !handler.suspendReplicas(&sts.ObjectMeta, &sts.Spec.Replicas, suspended) {
+			continue
+		}
+
+		err = c.Update(context.TODO(), sts)
+		if err != nil {
+			msg := fmt.Sprintf("Failed to update StatefulSet: %s", sts.Name)
+			logger.Info(msg, "err", err.Error())
+			loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_SUSPEND_BOOT_STAGE, loganMetrics.RECONCILE_UPDATE_STATEFULSET_SUBSTAGE, boot.Name)
+			handler.RecordEvent(keys.FailedSuspendBoot, msg, err)
+			return reconcile.Result{Requeue: true}, true, err
+		}
+		updated = true
+	}
+
+	if !suspended {
+		if updated {
+			handler.RecordEvent(keys.ResumedBoot, "Resumed Boot", nil)
+			return reconcile.Result{Requeue: true}, true, nil
+		}
+		return reconcile.Result{}, false, nil
+	}
+
+	if updated {
+		handler.RecordEvent(keys.SuspendedBoot, "Suspended Boot, scaled to zero", nil)
+	}
+
+	// The status is still observed when suspended.
+	if len(deps) > 0 || len(stss) > 0 {
+		result, requeue, err := handler.ReconcileUpdateBootStatus()
+		if requeue {
+			return result, true, err
+		}
+	}
+	return reconcile.Result{}, true, nil
+}
@@ -0,0 +1,273 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	appv1 "github.com/logancloud/logan-app-operator/pkg/apis/app/v1"
+	loganMetrics "github.com/logancloud/logan-app-operator/pkg/logan/metrics"
+	"github.com/logancloud/logan-app-operator/pkg/logan/util/keys"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"reflect"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"strconv"
+	"time"
+)
+
+const (
+	// ReasonMinimumReplicasAvailable is the condition reason when the Boot has its desired number of pods available
+	ReasonMinimumReplicasAvailable = "MinimumReplicasAvailable"
+	// ReasonMinimumReplicasUnavailable is the condition reason when the Boot has not its desired number of pods available
+	ReasonMinimumReplicasUnavailable = "MinimumReplicasUnavailable"
+	// ReasonRollingOut is the condition reason when the Boot's latest revision is rolling out
+	ReasonRollingOut = "RollingOut"
+	// ReasonRolloutComplete is the condition reason when the Boot's latest revision is rolled out
+	ReasonRolloutComplete = "RolloutComplete"
+	// ReasonProgressDeadlineExceeded is the condition reason when the Boot's rollout exceeded the progress deadline
+	ReasonProgressDeadlineExceeded = "ProgressDeadlineExceeded"
+	// ReasonReconcileSuccess is the condition reason when the last reconcile of the Boot succeeded
+	ReasonReconcileSuccess = "ReconcileSuccess"
+	// ReasonReconcileFailed is the condition reason when the last reconcile of the Boot failed
+	ReasonReconcileFailed = "ReconcileFailed"
+	// ReasonConfigLoaded is the condition reason when the Boot's operator config is valid
+	ReasonConfigLoaded = "ConfigLoaded"
+	// ReasonConfigInvalid is the condition reason when the Boot's operator config(profile) is invalid
+	ReasonConfigInvalid = "ConfigInvalid"
+
+	// deploymentProgressDeadlineExceeded is the reason of Deployment's Progressing condition, when the rollout timeout.
+	deploymentProgressDeadlineExceeded = "ProgressDeadlineExceeded"
+)
+
+// ReconcileUpdateBootStatus will compute the observed state of the Boot, and write it through the status subresource.
+// The status is only written if changed, so the Boot's resourceVersion is not bumped on every pass.
+func (handler *BootHandler) ReconcileUpdateBootStatus() (reconcile.Result, bool, error) {
+	logger := handler.Logger
+	boot := handler.Boot
+	c := handler.Client
+
+	if BatchEnabled(boot) {
+		return handler.ReconcileUpdateJobStatus()
+	}
+
+	// The StatefulSet is observed as a Deployment if workloadType is StatefulSet.
+	depFound, err := handler.getWorkload()
+	if err != nil {
+		logger.Error(err, "Failed to get workload", "type", WorkloadAppType(boot))
+		loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_UPDATE_BOOT_STATUS_STAGE, workloadGetSubstage(boot), boot.Name)
+		return reconcile.Result{Requeue: true}, true, err
+	}
+
+	// In blue-green rollout, the Boot's state is observed from the active color's Deployment.
+	depFound, err = handler.ActiveDeployment(depFound, loganMetrics.RECONCILE_UPDATE_BOOT_STATUS_STAGE)
+	if err != nil {
+		return reconcile.Result{Requeue: true}, true, err
+	}
+
+	svcList, err := handler.listRuntimeService()
+	if err != nil {
+		loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_UPDATE_BOOT_STATUS_STAGE, loganMetrics.RECONCILE_LIST_SERVICES_SUBSTAGE, boot.Name)
+		return reconcile.Result{Requeue: true}, true, err
+	}
+
+	revisionLst, err := c.ListRevision(boot.Namespace, PodLabels(boot))
+	var latestRevision *appv1.BootRevision
+	if err != nil {
+		logger.Info("Failed to list revisions", "err", err.Error())
+	} else {
+		latestRevision = revisionLst.SelectLatestRevision()
+	}
+
+	status := handler.NewBootStatus(depFound, svcList.Items, latestRevision)
+	err = handler.updateBootStatus(status)
+	if err != nil {
+		return reconcile.Result{Requeue: true}, true, err
+	}
+
+	return reconcile.Result{}, false, nil
+}
+
+// NewBootStatus returns the Boot's observed state, computed from the Deployment, Services and the latest revision.
+func (handler *BootHandler) NewBootStatus(dep *appsv1.Deployment, svcs []corev1.Service, latestRevision *appv1.BootRevision) *appv1.BootStatus {
+	boot := handler.Boot
+	status := handler.OperatorStatus.DeepCopy()
+	generation := handler.OperatorMeta.Generation
+
+	desired := DesiredReplicas(boot)
+
+	status.Type = WorkloadAppType(boot)
+	status.Deploy = dep.Name
+	status.Services = TransferServiceNames(svcs)
+	status.Config = handler.Resolution
+	status.ObservedGeneration = generation
+	status.Replicas = dep.Status.Replicas
+	status.Selector = labels.SelectorFromSet(PodLabels(boot)).String()
+	status.ReadyReplicas = dep.Status.ReadyReplicas
+	status.AvailableReplicas = dep.Status.AvailableReplicas
+	status.UpdatedReplicas = dep.Status.UpdatedReplicas
+
+	if latestRevision != nil {
+		status.Revision = strconv.Itoa(latestRevision.GetRevisionId())
+		status.RevisionHash = latestRevision.Annotations[keys.BootRevisionHashAnnotationKey]
+	}
+
+	// 1. Available
+	availableMsg := fmt.Sprintf("%d/%d replicas available", dep.Status.AvailableReplicas, desired)
+	if dep.Status.AvailableReplicas >= desired {
+		status.SetCondition(newBootCondition(appv1.BootAvailable, corev1.ConditionTrue,
+			ReasonMinimumReplicasAvailable, availableMsg, generation))
+	} else {
+		status.SetCondition(newBootCondition(appv1.BootAvailable, corev1.ConditionFalse,
+			ReasonMinimumReplicasUnavailable, availableMsg, generation))
+	}
+
+	// 2. Progressing
+	complete := deploymentComplete(dep, desired)
+	exceeded := deploymentProgressExceeded(dep)
+	if exceeded {
+		status.SetCondition(newBootCondition(appv1.BootProgressing, corev1.ConditionFalse,
+			ReasonProgressDeadlineExceeded, fmt.Sprintf("Deployment %s exceeded its progress deadline", dep.Name), generation))
+	} else if complete {
+		status.SetCondition(newBootCondition(appv1.BootProgressing, corev1.ConditionFalse,
+			ReasonRolloutComplete, fmt.Sprintf("Revision %s is rolled out", status.Revision), generation))
+	} else {
+		status.SetCondition(newBootCondition(appv1.BootProgressing, corev1.ConditionTrue,
+			ReasonRollingOut, fmt.Sprintf("%d/%d replicas updated", dep.Status.UpdatedReplicas, desired), generation))
+	}
+
+	// 3. ReconcileError: reaching here means all the reconcile stages succeeded.
+	status.SetCondition(newBootCondition(appv1.BootReconcileError, corev1.ConditionFalse,
+		ReasonReconcileSuccess, "", generation))
+
+	// 4. ConfigValid
+	handler.setConfigValidCondition(status)
+
+	// 5. Scaling schedule
+	status.ScalingSchedule = ""
+	status.NextScheduleTime = nil
+	active, next := ActiveScalingSchedule(boot, time.Now())
+	if active != nil {
+		status.ScalingSchedule = active.Name
+	}
+	if !next.IsZero() {
+		// The decoded time is local, keep the same location to avoid the endless update.
+		nextScheduleTime := metav1.NewTime(next.Local())
+		status.NextScheduleTime = &nextScheduleTime
+	}
+
+	// 6. Restart schedule
+	status.NextScheduledRestartTime = nil
+	var lastFired time.Time
+	if status.LastScheduledRestartTime != nil {
+		lastFired = status.LastScheduledRestartTime.Time
+	}
+	if nextRestart := NextScheduledRestart(boot, handler.Config.AppSpec, lastFired, time.Now()); !nextRestart.IsZero() {
+		nextScheduledRestartTime := metav1.NewTime(nextRestart.Local())
+		status.NextScheduledRestartTime = &nextScheduledRestartTime
+	}
+
+	// 7. Phase
+	if Suspended(boot) {
+		status.Phase = appv1.BootPhaseSuspended
+	} else if exceeded {
+		status.Phase = appv1.BootPhaseFailed
+	} else if complete {
+		status.Phase = appv1.BootPhaseRunning
+	} else if dep.Status.AvailableReplicas == 0 {
+		status.Phase = appv1.BootPhasePending
+	} else {
+		status.Phase = appv1.BootPhaseProgressing
+	}
+
+	return status
+}
+
+// UpdateReconcileErrorStatus will record the reconcile error into the Boot's ReconcileError condition.
+// The status writing is best effort, the reconcile loop will requeue with the original error.
+func (handler *BootHandler) UpdateReconcileErrorStatus(reconcileErr error) {
+	if reconcileErr == nil {
+		return
+	}
+
+	status := handler.OperatorStatus.DeepCopy()
+	generation := handler.OperatorMeta.Generation
+	status.SetCondition(newBootCondition(appv1.BootReconcileError, corev1.ConditionTrue,
+		ReasonReconcileFailed, reconcileErr.Error(), generation))
+	handler.setConfigValidCondition(status)
+	status.Phase = appv1.BootPhaseFailed
+
+	err := handler.updateBootStatus(status)
+	if err != nil {
+		handler.Logger.Info("Failed to update Boot Status with reconcile error", "err", err.Error())
+	}
+}
+
+func (handler *BootHandler) setConfigValidCondition(status *appv1.BootStatus) {
+	generation := handler.OperatorMeta.Generation
+	if handler.ConfigError != nil {
+		status.SetCondition(newBootCondition(appv1.BootConfigValid, corev1.ConditionFalse,
+			ReasonConfigInvalid, handler.ConfigError.Error(), generation))
+	} else {
+		status.SetCondition(newBootCondition(appv1.BootConfigValid, corev1.ConditionTrue,
+			ReasonConfigLoaded, "", generation))
+	}
+}
+
+// updateBootStatus will write the status through the status subresource if changed.
+func (handler *BootHandler) updateBootStatus(status *appv1.BootStatus) error {
+	logger := handler.Logger
+	boot := handler.Boot
+
+	if reflect.DeepEqual(*handler.OperatorStatus, *status) {
+		return nil
+	}
+
+	obj, ok := handler.OperatorBoot.(runtime.Object)
+	if !ok {
+		return fmt.Errorf("boot %s/%s is not a runtime object", boot.Namespace, boot.Name)
+	}
+
+	status.DeepCopyInto(handler.OperatorStatus)
+	logger.V(1).Info("Updating Boot Status", "new", status)
+	err := handler.Client.Status().Update(context.TODO(), obj)
+	if err != nil {
+		msg := "Failed to update Boot Status"
+		logger.Info(msg, "err", err.Error())
+		loganMetrics.UpdateReconcileErrors(boot.Kind, loganMetrics.RECONCILE_UPDATE_BOOT_STATUS_STAGE, loganMetrics.RECONCILE_UPDATE_BOOT_STATUS_SUBSTAGE, boot.Name)
+		handler.RecordEvent(keys.FailedUpdateBootStatus, msg, err)
+		return err
+	}
+
+	return nil
+}
+
+func newBootCondition(condType appv1.BootConditionType, status corev1.ConditionStatus,
+	reason, message string, generation int64) appv1.BootCondition {
+	return appv1.BootCondition{
+		Type:               condType,
+		Status:             status,
+		ObservedGeneration: generation,
+		Reason:             reason,
+		Message:            message,
+	}
+}
+
+// deploymentComplete returns true if the Deployment's latest template is rolled out, and all desired pods are available.
+func deploymentComplete(dep *appsv1.Deployment, desired int32) bool {
+	return dep.Status.ObservedGeneration >= dep.Generation &&
+		dep.Status.UpdatedReplicas == desired &&
+		dep.Status.Replicas == desired &&
+		dep.Status.AvailableReplicas == desired
+}
+
+// deploymentProgressExceeded returns true if the Deployment's rollout exceeded its progress deadline.
+func deploymentProgressExceeded(dep *appsv1.Deployment) bool {
+	for _, cond := range dep.Status.Conditions {
+		if cond.Type == appsv1.DeploymentProgressing {
+			return cond.Reason == deploymentProgressDeadlineExceeded
+		}
+	}
+	return false
+}
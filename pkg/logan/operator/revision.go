@@ -21,6 +21,8 @@ const (
 	RevisionPhaseComplete = "Complete"
 	// RevisionPhaseCancel is the revision phase for Cancelled
 	RevisionPhaseCancel = "Cancelled"
+	// RevisionPhaseFailed is the revision phase for Failed, the revision failed to become Active
+	RevisionPhaseFailed = "Failed"
 )
 
 // InitBootRevision will init a revision from boot
@@ -37,6 +39,9 @@ func InitBootRevision(boot *v1.Boot) *v1.BootRevision {
 	}
 	replica := int32(0)
 	revisionBoot.Spec.Replicas = &replica
+	revisionBoot.Spec.Suspend = nil
+	revisionBoot.Spec.ScalingSchedules = nil
+	revisionBoot.Spec.RestartSchedule = nil
 
 	revisionBoot.Spec.Env = cleanEnv(revisionBoot.Spec.Env)
 
@@ -0,0 +1,195 @@
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSearchDays is the maximum days to search the next or previous time, long enough for "29 2 *".
+const cronSearchDays = 366 * 5
+
+// cronField is the range of a cron expression's field.
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = []cronField{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// CronSchedule is a parsed standard cron expression: "minute hour dayOfMonth month dayOfWeek".
+// Each field supports "*", numbers, ranges "a-b", steps "*/n" or "a-b/n", and lists split by ",".
+// Day of week is 0-7, both 0 and 7 are Sunday.
+type CronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	// domStar and dowStar are true if the field is "*", when both day fields are restricted, either matches.
+	domStar, dowStar bool
+}
+
+// ParseCron parse the standard cron expression.
+func ParseCron(spec string) (*CronSchedule, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("expected %d fields in cron expression %q, found %d", len(cronFields), spec, len(fields))
+	}
+
+	bits := make([]uint64, len(cronFields))
+	for i, field := range fields {
+		b, err := parseCronField(field, cronFields[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %s", spec, err.Error())
+		}
+		bits[i] = b
+	}
+
+	// Sunday is both 0 and 7.
+	dow := bits[4]
+	if dow&(1<<7) != 0 {
+		dow |= 1
+	}
+
+	return &CronSchedule{
+		minute:  bits[0],
+		hour:    bits[1],
+		dom:     bits[2],
+		month:   bits[3],
+		dow:     dow,
+		domStar: strings.HasPrefix(fields[2], "*"),
+		dowStar: strings.HasPrefix(fields[4], "*"),
+	}, nil
+}
+
+// parseCronField return the bit set of the field's values.
+func parseCronField(field string, f cronField) (uint64, error) {
+	var bits uint64
+	for _, expr := range strings.Split(field, ",") {
+		rangeExpr, step := expr, 1
+		if i := strings.Index(expr, "/"); i >= 0 {
+			s, err := strconv.Atoi(expr[i+1:])
+			if err != nil || s <= 0 {
+				return 0, fmt.Errorf("invalid step %q of %s", expr[i+1:], f.name)
+			}
+			rangeExpr, step = expr[:i], s
+		}
+
+		start, end := f.min, f.max
+		switch {
+		case rangeExpr == "*":
+		case strings.Contains(rangeExpr, "-"):
+			bounds := strings.SplitN(rangeExpr, "-", 2)
+			var err error
+			if start, err = parseCronValue(bounds[0], f); err != nil {
+				return 0, err
+			}
+			if end, err = parseCronValue(bounds[1], f); err != nil {
+				return 0, err
+			}
+			if start > end {
+				return 0, fmt.Errorf("invalid range %q of %s", rangeExpr, f.name)
+			}
+		default:
+			value, err := parseCronValue(rangeExpr, f)
+			if err != nil {
+				return 0, err
+			}
+			start = value
+			// "a/n" means from a to the max.
+			if step == 1 {
+				end = value
+			}
+		}
+
+		for v := start; v <= end; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// parseCronValue parse a number in the field's range.
+func parseCronValue(value string, f cronField) (int, error) {
+	v, err := strconv.Atoi(value)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("invalid value %q of %s, should be in [%d, %d]", value, f.name, f.min, f.max)
+	}
+	return v, nil
+}
+
+// matchDay return true if the schedule fires on the day.
+func (s *CronSchedule) matchDay(t time.Time) bool {
+	if s.month&(1<<uint(t.Month())) == 0 {
+		return false
+	}
+
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// Next return the first time after t which the schedule fires, in t's location.
+// Return zero time if not found.
+func (s *CronSchedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	year, month, day := t.Date()
+	for d := 0; d <= cronSearchDays; d++ {
+		date := time.Date(year, month, day+d, 0, 0, 0, 0, loc)
+		if !s.matchDay(date) {
+			continue
+		}
+
+		for h := 0; h <= 23; h++ {
+			if s.hour&(1<<uint(h)) == 0 {
+				continue
+			}
+			for m := 0; m <= 59; m++ {
+				if s.minute&(1<<uint(m)) == 0 {
+					continue
+				}
+				next := time.Date(date.Year(), date.Month(), date.Day(), h, m, 0, 0, loc)
+				if next.After(t) {
+					return next
+				}
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// Prev return the latest time at or before t which the schedule fired, in t's location.
+// Return zero time if not found.
+func (s *CronSchedule) Prev(t time.Time) time.Time {
+	loc := t.Location()
+	year, month, day := t.Date()
+	for d := 0; d <= cronSearchDays; d++ {
+		date := time.Date(year, month, day-d, 0, 0, 0, 0, loc)
+		if !s.matchDay(date) {
+			continue
+		}
+
+		for h := 23; h >= 0; h-- {
+			if s.hour&(1<<uint(h)) == 0 {
+				continue
+			}
+			for m := 59; m >= 0; m-- {
+				if s.minute&(1<<uint(m)) == 0 {
+					continue
+				}
+				prev := time.Date(date.Year(), date.Month(), date.Day(), h, m, 0, 0, loc)
+				if !prev.After(t) {
+					return prev
+				}
+			}
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,96 @@
+package util
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cron", func() {
+	loc := time.UTC
+	// 2019-10-18 is Friday.
+	friday := time.Date(2019, 10, 18, 12, 30, 0, 0, loc)
+
+	Context("With the cron expression parsed", func() {
+		It("test invalid expressions", func() {
+			for _, spec := range []string{
+				"",
+				"* * * *",
+				"60 * * * *",
+				"* 24 * * *",
+				"* * 0 * *",
+				"* * * 13 *",
+				"* * * * 8",
+				"5-1 * * * *",
+				"*/0 * * * *",
+				"a * * * *",
+			} {
+				_, err := ParseCron(spec)
+				Expect(err).Should(HaveOccurred(), spec)
+			}
+		})
+
+		It("test next and previous time", func() {
+			testDataS := []struct {
+				Spec string
+				Prev time.Time
+				Next time.Time
+			}{
+				{
+					"0 20 * * 1-5",
+					time.Date(2019, 10, 17, 20, 0, 0, 0, loc),
+					time.Date(2019, 10, 18, 20, 0, 0, 0, loc),
+				},
+				{
+					"0 8 * * 1-5",
+					time.Date(2019, 10, 18, 8, 0, 0, 0, loc),
+					time.Date(2019, 10, 21, 8, 0, 0, 0, loc),
+				},
+				{
+					"*/15 * * * *",
+					time.Date(2019, 10, 18, 12, 30, 0, 0, loc),
+					time.Date(2019, 10, 18, 12, 45, 0, 0, loc),
+				},
+				{
+					"0 0 1,15 * *",
+					time.Date(2019, 10, 15, 0, 0, 0, 0, loc),
+					time.Date(2019, 11, 1, 0, 0, 0, 0, loc),
+				},
+				{
+					"0 9 * * 0,7",
+					time.Date(2019, 10, 13, 9, 0, 0, 0, loc),
+					time.Date(2019, 10, 20, 9, 0, 0, 0, loc),
+				},
+				{
+					// Day of month or day of week, when both are restricted.
+					"0 0 1 * 6",
+					time.Date(2019, 10, 12, 0, 0, 0, 0, loc),
+					time.Date(2019, 10, 19, 0, 0, 0, 0, loc),
+				},
+				{
+					"0 0 29 2 *",
+					time.Date(2016, 2, 29, 0, 0, 0, 0, loc),
+					time.Date(2020, 2, 29, 0, 0, 0, 0, loc),
+				},
+			}
+
+			for _, testData := range testDataS {
+				schedule, err := ParseCron(testData.Spec)
+				Expect(err).ShouldNot(HaveOccurred(), testData.Spec)
+				Expect(schedule.Prev(friday)).Should(Equal(testData.Prev), testData.Spec)
+				Expect(schedule.Next(friday)).Should(Equal(testData.Next), testData.Spec)
+			}
+		})
+
+		It("test the location", func() {
+			shanghai := time.FixedZone("Asia/Shanghai", 8*60*60)
+			schedule, err := ParseCron("0 8 * * *")
+			Expect(err).ShouldNot(HaveOccurred())
+
+			next := schedule.Next(friday.In(shanghai))
+			Expect(next).Should(Equal(time.Date(2019, 10, 19, 8, 0, 0, 0, shanghai)))
+			Expect(next.UTC()).Should(Equal(time.Date(2019, 10, 19, 0, 0, 0, 0, loc)))
+		})
+	})
+})
@@ -19,6 +19,12 @@ const (
 	// PrometheusScrapeAnnotationValue is the Boot's created service's prometheus scrape annotation value
 	PrometheusScrapeAnnotationValue = "true"
 
+	// AppProtocolsAnnotationKey is the Boot's created service's annotation key for the ports' app protocols, as "grpc=grpc,http=http"
+	AppProtocolsAnnotationKey = "app.logancloud.com/app-protocols"
+
+	// IngressClassAnnotationKey is the Boot's created ingress's class annotation key
+	IngressClassAnnotationKey = "kubernetes.io/ingress.class"
+
 	// EnvAnnotationKey is the annotation key for storing when changed env
 	EnvAnnotationKey = "app.logancloud.com/env"
 	// EnvAnnotationValue is default value for for env
@@ -42,10 +48,18 @@ const (
 	AppTypeAnnotationKey = "app.logancloud.com/type"
 	// AppTypeAnnotationDeploy is the annotation value for Deployment
 	AppTypeAnnotationDeploy = "deploy"
+	// AppTypeAnnotationStatefulSet is the annotation value for StatefulSet
+	AppTypeAnnotationStatefulSet = "statefulset"
+	// AppTypeAnnotationJob is the annotation value for Job
+	AppTypeAnnotationJob = "job"
+	// AppTypeAnnotationCronJob is the annotation value for CronJob
+	AppTypeAnnotationCronJob = "cronjob"
 
 	// StatusAvailableAnnotationKey is the annotation key for storing boot's current pods
+	// Deprecated: use the Boot's status.availableReplicas
 	StatusAvailableAnnotationKey = "app.logancloud.com/status.available"
 	// StatusDesiredAnnotationKey is the annotation key for storing boot's desired pods
+	// Deprecated: use the Boot's spec.replicas and status.conditions
 	StatusDesiredAnnotationKey = "app.logancloud.com/status.desired"
 	// StatusModificationTimeAnnotationKey is the annotation key for storing boot's type
 	StatusModificationTimeAnnotationKey = "app.logancloud.com/status.lastUpdateTimeStamp"
@@ -60,6 +74,44 @@ const (
 	BootRevisionDiffAnnotationKey = "app.logancloud.com/diff"
 	// BootRevisionRetryAnnotationKey is the annotation key for boot revision's fail retry times
 	BootRevisionRetryAnnotationKey = "app.logancloud.com/retry"
+	// BootRevisionRollbackAnnotationKey is the annotation key for boot revision's ID which this revision is rolled back to
+	BootRevisionRollbackAnnotationKey = "app.logancloud.com/rollback"
+	// BootRollbackToAnnotationKey is the annotation key for requesting the boot to roll back to the revision's ID
+	BootRollbackToAnnotationKey = "app.logancloud.com/rollback-to"
+
+	// CanaryAnnotationKey is the annotation key for requesting the boot's canary to promote or abort
+	CanaryAnnotationKey = "app.logancloud.com/canary"
+	// CanaryStepAnnotationKey is the annotation key for storing the canary Deployment's current step
+	CanaryStepAnnotationKey = "app.logancloud.com/canary-step"
+	// CanaryReadyAtAnnotationKey is the annotation key for storing the time when all the canary pods are ready
+	CanaryReadyAtAnnotationKey = "app.logancloud.com/canary-ready-at"
+	// CanaryHashAnnotationKey is the annotation key for storing the hash of the canary Deployment's pod template
+	CanaryHashAnnotationKey = "app.logancloud.com/canary-hash"
+
+	// BootSuspendAnnotationKey is the annotation key for suspending the boot, the value is "true"
+	BootSuspendAnnotationKey = "app.logancloud.com/suspend"
+	// SuspendedReplicasAnnotationKey is the annotation key for storing the Deployment's replicas before suspended
+	SuspendedReplicasAnnotationKey = "app.logancloud.com/suspended-replicas"
+
+	// BlueGreenAnnotationKey is the annotation key for requesting the boot's blue-green to switch or roll back
+	BlueGreenAnnotationKey = "app.logancloud.com/blue-green"
+	// BlueGreenHashAnnotationKey is the annotation key for storing the hash of the color Deployment's pod template
+	BlueGreenHashAnnotationKey = "app.logancloud.com/blue-green-hash"
+	// BlueGreenSwitchedAtAnnotationKey is the annotation key for storing the time when the app Service is switched away from the color Deployment
+	BlueGreenSwitchedAtAnnotationKey = "app.logancloud.com/blue-green-switched-at"
+	// BlueGreenColorAnnotationKey is the annotation key for recording the color which the revision is deployed to
+	BlueGreenColorAnnotationKey = "app.logancloud.com/blue-green-color"
+
+	// StatefulSetHashAnnotationKey is the annotation key for storing the pod template hash of the StatefulSet
+	StatefulSetHashAnnotationKey = "app.logancloud.com/statefulset-hash"
+	// JobHashAnnotationKey is the annotation key for storing the pod template hash of the Job or CronJob
+	JobHashAnnotationKey = "app.logancloud.com/job-hash"
+	// JobBootHashAnnotationKey is the annotation key for storing the boot hash of the Boot's spec run by the one-off Job,
+	// the same as the BootRevision's "app.logancloud.com/hash"
+	JobBootHashAnnotationKey = "app.logancloud.com/job-boot-hash"
+
+	// MigratedFromAnnotationKey is the LoganOperatorConfig's annotation key for the ConfigMap migrated from, as "logan/logan-app-operator-config"
+	MigratedFromAnnotationKey = "app.logancloud.com/migrated-from"
 
 	// BootSecretAnnotaionKeyPrefix is the annotation key prefix for flags whether permission is granted
 	BootSecretAnnotaionKeyPrefix = "app.logancloud.com/secret-"
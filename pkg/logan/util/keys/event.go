@@ -11,8 +11,36 @@ const (
 	UpdatedDeployment = "UpdatedDeployment"
 	// FailedUpdateDeployment is the failed event reason for updated deployment
 	FailedUpdateDeployment = "FailedUpdateDeployment"
+	// DeletedDeployment is the event reason for deleted deployment
+	DeletedDeployment = "DeletedDeployment"
+	// FailedDeleteDeployment is the failed event reason for deleted deployment
+	FailedDeleteDeployment = "FailedDeleteDeployment"
 	// FailedUpdateDeployment is the failed event reason for got deployment
 	FailedGetDeployment = "FailedGetDeployment"
+	// CreatedStatefulSet is the event reason for created statefulset
+	CreatedStatefulSet = "CreatedStatefulSet"
+	// FailedCreateStatefulSet is the failed event reason for created statefulset
+	FailedCreateStatefulSet = "FailedCreateStatefulSet"
+	// UpdatedStatefulSet is the event reason for updated statefulset
+	UpdatedStatefulSet = "UpdatedStatefulSet"
+	// FailedUpdateStatefulSet is the failed event reason for updated statefulset
+	FailedUpdateStatefulSet = "FailedUpdateStatefulSet"
+	// FailedGetStatefulSet is the failed event reason for got statefulset
+	FailedGetStatefulSet = "FailedGetStatefulSet"
+	// CreatedJob is the event reason for created job or cronjob
+	CreatedJob = "CreatedJob"
+	// FailedCreateJob is the failed event reason for created job or cronjob
+	FailedCreateJob = "FailedCreateJob"
+	// UpdatedJob is the event reason for updated job or cronjob
+	UpdatedJob = "UpdatedJob"
+	// FailedUpdateJob is the failed event reason for updated job or cronjob
+	FailedUpdateJob = "FailedUpdateJob"
+	// DeletedJob is the event reason for deleted job, which is run again
+	DeletedJob = "DeletedJob"
+	// FailedDeleteJob is the failed event reason for deleted job
+	FailedDeleteJob = "FailedDeleteJob"
+	// FailedGetJob is the failed event reason for got job or cronjob
+	FailedGetJob = "FailedGetJob"
 
 	// CreatedService is the event reason for created service
 	CreatedService = "CreatedService"
@@ -29,6 +57,36 @@ const (
 	// FailedGetService is the failed event reason for got service
 	FailedGetService = "FailedGetService"
 
+	// CreatedHorizontalPodAutoscaler is the event reason for created hpa
+	CreatedHorizontalPodAutoscaler = "CreatedHorizontalPodAutoscaler"
+	// FailedCreateHorizontalPodAutoscaler is the failed event reason for created hpa
+	FailedCreateHorizontalPodAutoscaler = "FailedCreateHorizontalPodAutoscaler"
+	// UpdatedHorizontalPodAutoscaler is the event reason for updated hpa
+	UpdatedHorizontalPodAutoscaler = "UpdatedHorizontalPodAutoscaler"
+	// FailedUpdateHorizontalPodAutoscaler is the failed event reason for updated hpa
+	FailedUpdateHorizontalPodAutoscaler = "FailedUpdateHorizontalPodAutoscaler"
+	// DeletedHorizontalPodAutoscaler is the event reason for deleted hpa
+	DeletedHorizontalPodAutoscaler = "DeletedHorizontalPodAutoscaler"
+	// FailedDeleteHorizontalPodAutoscaler is the failed event reason for deleted hpa
+	FailedDeleteHorizontalPodAutoscaler = "FailedDeleteHorizontalPodAutoscaler"
+	// FailedGetHorizontalPodAutoscaler is the failed event reason for got hpa
+	FailedGetHorizontalPodAutoscaler = "FailedGetHorizontalPodAutoscaler"
+
+	// CreatedIngress is the event reason for created ingress
+	CreatedIngress = "CreatedIngress"
+	// FailedCreateIngress is the failed event reason for created ingress
+	FailedCreateIngress = "FailedCreateIngress"
+	// UpdatedIngress is the event reason for updated ingress
+	UpdatedIngress = "UpdatedIngress"
+	// FailedUpdateIngress is the failed event reason for updated ingress
+	FailedUpdateIngress = "FailedUpdateIngress"
+	// DeletedIngress is the event reason for deleted ingress
+	DeletedIngress = "DeletedIngress"
+	// FailedDeleteIngress is the failed event reason for deleted ingress
+	FailedDeleteIngress = "FailedDeleteIngress"
+	// FailedGetIngress is the failed event reason for got ingress
+	FailedGetIngress = "FailedGetIngress"
+
 	// UpdatedBootDefaulters is the event reason for updated boot defaulters
 	UpdatedBootDefaulters = "UpdatedBootDefaulters"
 	// FailedUpdateBootDefaulters is the failed event reason for updated boot defaulters
@@ -37,4 +95,43 @@ const (
 	UpdatedBootMeta = "UpdatedBootMeta"
 	// FailedUpdateBootMeta is the failed event reason for updated boot meta
 	FailedUpdateBootMeta = "FailedUpdateBootMeta"
+	// RolledBackBoot is the event reason for rolled back boot to a revision
+	RolledBackBoot = "RolledBackBoot"
+	// FailedRollbackBoot is the failed event reason for rolled back boot to a revision
+	FailedRollbackBoot = "FailedRollbackBoot"
+	// FailedRolloutBoot is the failed event reason for the boot's latest revision failed to become active
+	FailedRolloutBoot = "FailedRolloutBoot"
+	// PromotedCanary is the event reason for promoted the canary to the next step
+	PromotedCanary = "PromotedCanary"
+	// AbortedCanary is the event reason for aborted the canary
+	AbortedCanary = "AbortedCanary"
+	// FailedAbortCanary is the failed event reason for aborted the canary
+	FailedAbortCanary = "FailedAbortCanary"
+	// SuspendedBoot is the event reason for suspended the boot
+	SuspendedBoot = "SuspendedBoot"
+	// ResumedBoot is the event reason for resumed the boot
+	ResumedBoot = "ResumedBoot"
+	// FailedSuspendBoot is the failed event reason for suspended or resumed the boot
+	FailedSuspendBoot = "FailedSuspendBoot"
+	// SwitchedBlueGreen is the event reason for switched the app service to the other color
+	SwitchedBlueGreen = "SwitchedBlueGreen"
+	// FailedSwitchBlueGreen is the failed event reason for switched the app service to the other color
+	FailedSwitchBlueGreen = "FailedSwitchBlueGreen"
+	// ScheduledRestartBoot is the event reason for restarted the boot by the restart schedule
+	ScheduledRestartBoot = "ScheduledRestartBoot"
+	// FailedScheduledRestartBoot is the failed event reason for restarted the boot by the restart schedule
+	FailedScheduledRestartBoot = "FailedScheduledRestartBoot"
+	// FailedUpdateBootStatus is the failed event reason for updated boot status
+	FailedUpdateBootStatus = "FailedUpdateBootStatus"
+
+	// Operator config event reason list
+
+	// ReloadedConfig is the event reason for reloaded the operator config
+	ReloadedConfig = "ReloadedConfig"
+	// FailedReloadConfig is the failed event reason for reloaded the operator config, the last good config is kept
+	FailedReloadConfig = "FailedReloadConfig"
+	// MigratedConfig is the event reason for migrated the operator config from the ConfigMap to the LoganOperatorConfig
+	MigratedConfig = "MigratedConfig"
+	// FailedMigrateConfig is the failed event reason for migrated the operator config from the ConfigMap
+	FailedMigrateConfig = "FailedMigrateConfig"
 )
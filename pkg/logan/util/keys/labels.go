@@ -7,6 +7,15 @@ const (
 	// BootTypeKey is the boot type's label selector key
 	BootTypeKey = "bootType"
 
+	// BootTrackKey is the boot's canary pods' label selector key, the value is "canary"
+	BootTrackKey = "bootTrack"
+
+	// BootColorKey is the boot's blue-green pods' label selector key, the value is "blue" or "green"
+	BootColorKey = "bootColor"
+
+	// BootRevisionKey is the boot revision's ID label key of the boot's canary and blue-green pods
+	BootRevisionKey = "bootRevision"
+
 	// SharedKey is the boot's pvc's shared type label selector key
 	SharedKey = "shared"
 )
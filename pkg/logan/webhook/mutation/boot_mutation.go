@@ -6,12 +6,8 @@ import (
 	"fmt"
 	"github.com/appscode/jsonpatch"
 	v1 "github.com/logancloud/logan-app-operator/pkg/apis/app/v1"
-	"github.com/logancloud/logan-app-operator/pkg/controller/javaboot"
-	"github.com/logancloud/logan-app-operator/pkg/controller/nodejsboot"
-	"github.com/logancloud/logan-app-operator/pkg/controller/phpboot"
-	"github.com/logancloud/logan-app-operator/pkg/controller/pythonboot"
-	"github.com/logancloud/logan-app-operator/pkg/controller/webboot"
 	"github.com/logancloud/logan-app-operator/pkg/logan"
+	"github.com/logancloud/logan-app-operator/pkg/logan/config"
 	"github.com/logancloud/logan-app-operator/pkg/logan/operator"
 	"github.com/logancloud/logan-app-operator/pkg/logan/util"
 	"github.com/logancloud/logan-app-operator/pkg/logan/util/keys"
@@ -36,6 +32,8 @@ type BootMutator struct {
 	decoder  types.Decoder
 	Schema   *runtime.Scheme
 	Recorder record.EventRecorder
+	// ConfigProvider provides the operator config, which the Boot is handled with.
+	ConfigProvider config.ConfigProvider
 }
 
 var logger = logf.Log.WithName("logan_webhook_mutation")
@@ -65,89 +63,17 @@ func (mHandler *BootMutator) mutateBoot(ctx context.Context, req types.Request)
 	scheme := mHandler.Schema
 	recorder := mHandler.Recorder
 
-	bootType := req.AdmissionRequest.Kind.Kind
-
-	if bootType == webhook.ApiTypeJava {
-		javaBoot, err := webhook.DecodeJavaBoot(req, mHandler.decoder)
-		if err != nil {
-			logger.Error(err, "Decoding boot error.")
-		}
-		bootCopy := javaBoot.DeepCopy()
-
-		handler := javaboot.InitHandler(bootCopy, scheme, c, logger, recorder)
-
-		mutationDefault(handler, req, bootCopy.Name)
-		mutationBoot(&bootCopy.ObjectMeta, req)
-
-		marshaledBoot, err := json.Marshal(bootCopy)
-		if err != nil {
-			return admission.ErrorResponse(http.StatusInternalServerError, err), err
-		}
-		return PatchResponseFromRaw(req.AdmissionRequest.Object.Raw, marshaledBoot), nil
-	} else if bootType == webhook.ApiTypePhp {
-		phpBoot, err := webhook.DecodePhpBoot(req, mHandler.decoder)
-		if err != nil {
-			logger.Error(err, "Decoding boot error.")
-		}
-		bootCopy := phpBoot.DeepCopy()
-
-		handler := phpboot.InitHandler(bootCopy, scheme, c, logger, recorder)
-
-		mutationDefault(handler, req, bootCopy.Name)
-		mutationBoot(&bootCopy.ObjectMeta, req)
-
-		marshaledBoot, err := json.Marshal(bootCopy)
-		if err != nil {
-			return admission.ErrorResponse(http.StatusInternalServerError, err), err
-		}
-		return PatchResponseFromRaw(req.AdmissionRequest.Object.Raw, marshaledBoot), nil
-	} else if bootType == webhook.ApiTypePython {
-		pythonBoot, err := webhook.DecodePythonBoot(req, mHandler.decoder)
-		if err != nil {
-			logger.Error(err, "Decoding boot error.")
-		}
-		bootCopy := pythonBoot.DeepCopy()
-
-		handler := pythonboot.InitHandler(bootCopy, scheme, c, logger, recorder)
-
-		mutationDefault(handler, req, bootCopy.Name)
-		mutationBoot(&bootCopy.ObjectMeta, req)
-
-		marshaledBoot, err := json.Marshal(bootCopy)
-		if err != nil {
-			return admission.ErrorResponse(http.StatusInternalServerError, err), err
-		}
-		return PatchResponseFromRaw(req.AdmissionRequest.Object.Raw, marshaledBoot), nil
-	} else if bootType == webhook.ApiTypeNodeJS {
-		nodejsBoot, err := webhook.DecodeNodeJSBoot(req, mHandler.decoder)
-		if err != nil {
-			logger.Error(err, "Decoding boot error.")
-		}
-		bootCopy := nodejsBoot.DeepCopy()
-
-		handler := nodejsboot.InitHandler(bootCopy, scheme, c, logger, recorder)
-
-		mutationDefault(handler, req, bootCopy.Name)
-		mutationBoot(&bootCopy.ObjectMeta, req)
-
-		marshaledBoot, err := json.Marshal(bootCopy)
-		if err != nil {
-			return admission.ErrorResponse(http.StatusInternalServerError, err), err
-		}
-		return PatchResponseFromRaw(req.AdmissionRequest.Object.Raw, marshaledBoot), nil
-	} else if bootType == webhook.ApiTypeWeb {
-		webBoot, err := webhook.DecodeWebBoot(req, mHandler.decoder)
-		if err != nil {
-			logger.Error(err, "Decoding boot error.")
-		}
-		bootCopy := webBoot.DeepCopy()
-
-		handler := webboot.InitHandler(bootCopy, scheme, c, logger, recorder)
+	bootObj, err := webhook.DecodeBootObject(req, mHandler.decoder)
+	if err != nil {
+		logger.Error(err, "Decoding boot error.")
+	}
+	if bootObj != nil {
+		handler := operator.InitHandler(bootObj, mHandler.ConfigProvider, scheme, c, logger, recorder)
 
-		mutationDefault(handler, req, bootCopy.Name)
-		mutationBoot(&bootCopy.ObjectMeta, req)
+		mutationDefault(handler, req, bootObj.GetName())
+		mutationBoot(bootObj.GetBootMeta(), req)
 
-		marshaledBoot, err := json.Marshal(bootCopy)
+		marshaledBoot, err := json.Marshal(bootObj)
 		if err != nil {
 			return admission.ErrorResponse(http.StatusInternalServerError, err), err
 		}
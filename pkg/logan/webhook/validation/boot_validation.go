@@ -2,25 +2,25 @@ package validation
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/logancloud/logan-app-operator/pkg/logan"
 	"github.com/logancloud/logan-app-operator/pkg/logan/util/keys"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/tools/record"
 
 	appv1 "github.com/logancloud/logan-app-operator/pkg/apis/app/v1"
 	v1 "github.com/logancloud/logan-app-operator/pkg/apis/app/v1"
-	"github.com/logancloud/logan-app-operator/pkg/controller/javaboot"
-	"github.com/logancloud/logan-app-operator/pkg/controller/nodejsboot"
-	"github.com/logancloud/logan-app-operator/pkg/controller/phpboot"
-	"github.com/logancloud/logan-app-operator/pkg/controller/pythonboot"
-	"github.com/logancloud/logan-app-operator/pkg/controller/webboot"
 	"github.com/logancloud/logan-app-operator/pkg/logan/config"
 	"github.com/logancloud/logan-app-operator/pkg/logan/operator"
 	"github.com/logancloud/logan-app-operator/pkg/logan/util"
 	"github.com/logancloud/logan-app-operator/pkg/logan/webhook"
 	admssionv1beta1 "k8s.io/api/admission/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -47,6 +47,8 @@ type BootValidator struct {
 	decoder  types.Decoder
 	Schema   *runtime.Scheme
 	Recorder record.EventRecorder
+	// ConfigProvider provides the operator config, which the Boot is handled with.
+	ConfigProvider config.ConfigProvider
 }
 
 var _ admission.Handler = &BootValidator{}
@@ -112,10 +114,24 @@ func (vHandler *BootValidator) Validate(req types.Request) (string, bool, error)
 			logger.Info(msg)
 			return msg, false, nil
 		}
+
+		msg, valid = vHandler.CheckDeployName(boot)
+		if !valid {
+			logger.Info(msg)
+			return msg, false, nil
+		}
 	}
 
 	// Check Boot's envs when creating or updating.
 	// Check Boot's pvc when creating or updating.
+	// Check Boot's autoscaling when creating or updating.
+	// Check Boot's ports when creating or updating.
+	// Check Boot's probes when creating or updating.
+	// Check Boot's scheduling when creating or updating.
+	// Check Boot's strategy when creating or updating.
+	// Check Boot's workload type when creating or updating.
+	// Check Boot's job when creating or updating.
+	// Check Boot's rollback when creating or updating.
 	// Record a revision when creating or updating if validation Boot valid.
 	if operation == admssionv1beta1.Create || operation == admssionv1beta1.Update {
 		msg, valid := vHandler.CheckEnvKeys(boot, operation)
@@ -131,9 +147,72 @@ func (vHandler *BootValidator) Validate(req types.Request) (string, bool, error)
 			return msg, false, nil
 		}
 
-		flag, err := vHandler.recordRevision(boot, req)
-		if err != nil || flag == false {
-			return "create up revision error", flag, err
+		msg, valid = vHandler.CheckAutoscaling(boot)
+		if !valid {
+			logger.Info(msg)
+			return msg, false, nil
+		}
+
+		msg, valid = vHandler.CheckScalingSchedules(boot)
+		if !valid {
+			logger.Info(msg)
+			return msg, false, nil
+		}
+
+		msg, valid = vHandler.CheckRestartSchedule(boot)
+		if !valid {
+			logger.Info(msg)
+			return msg, false, nil
+		}
+
+		msg, valid = vHandler.CheckPorts(boot)
+		if !valid {
+			logger.Info(msg)
+			return msg, false, nil
+		}
+
+		msg, valid = vHandler.CheckProbes(boot)
+		if !valid {
+			logger.Info(msg)
+			return msg, false, nil
+		}
+
+		msg, valid = vHandler.CheckScheduling(boot)
+		if !valid {
+			logger.Info(msg)
+			return msg, false, nil
+		}
+
+		msg, valid = vHandler.CheckStrategy(boot)
+		if !valid {
+			logger.Info(msg)
+			return msg, false, nil
+		}
+
+		msg, valid = vHandler.CheckWorkload(boot, req)
+		if !valid {
+			logger.Info(msg)
+			return msg, false, nil
+		}
+
+		msg, valid = vHandler.CheckJob(boot)
+		if !valid {
+			logger.Info(msg)
+			return msg, false, nil
+		}
+
+		msg, valid = vHandler.CheckRollback(boot)
+		if !valid {
+			logger.Info(msg)
+			return msg, false, nil
+		}
+
+		// The revision is not recorded when suspended, the changes are recorded when resumed.
+		if !operator.Suspended(boot) {
+			flag, err := vHandler.recordRevision(boot, req)
+			if err != nil || flag == false {
+				return "create up revision error", flag, err
+			}
 		}
 	}
 
@@ -172,6 +251,9 @@ func (vHandler *BootValidator) recordRevision(inputBoot *v1.Boot, req types.Requ
 	hashcode := revisionBoot.BootHash()
 	logger.V(1).Info("RevisionBoot's BootHash", "BootHash", hashcode, "revision", revisionBoot)
 	revisionBoot.Annotations[keys.BootRevisionHashAnnotationKey] = hashcode
+	if rollbackId := rollbackRevisionId(inputBoot, req); rollbackId != "" {
+		revisionBoot.Annotations[keys.BootRevisionRollbackAnnotationKey] = rollbackId
+	}
 
 	// get ListRevision
 	c := vHandler.client
@@ -252,32 +334,12 @@ func (vHandler *BootValidator) recordRevision(inputBoot *v1.Boot, req types.Requ
 
 // mergeBootDefaultValue will merge boot config with operator app config
 func (vHandler *BootValidator) mergeBootDefaultValue(boot *v1.Boot, req types.Request) (*appv1.BootSpec, *metav1.ObjectMeta) {
-	appType := req.AdmissionRequest.Kind.Kind
-	if appType == webhook.ApiTypeJava {
-		javaBoot := boot.DeepCopyJava()
-		handler := javaboot.InitHandler(javaBoot, vHandler.Schema, vHandler.client, logger, vHandler.Recorder)
-		handler.DefaultValue()
-		return &javaBoot.Spec, &javaBoot.ObjectMeta
-	} else if appType == webhook.ApiTypePython {
-		pythonBoot := boot.DeepCopyPython()
-		handler := pythonboot.InitHandler(pythonBoot, vHandler.Schema, vHandler.client, logger, vHandler.Recorder)
+	bootType := appv1.GetBootType(req.AdmissionRequest.Kind.Kind)
+	if bootType != nil {
+		bootObj := bootType.BootObject(boot)
+		handler := operator.InitHandler(bootObj, vHandler.ConfigProvider, vHandler.Schema, vHandler.client, logger, vHandler.Recorder)
 		handler.DefaultValue()
-		return &pythonBoot.Spec, &pythonBoot.ObjectMeta
-	} else if appType == webhook.ApiTypePhp {
-		phpBoot := boot.DeepCopyPhp()
-		handler := phpboot.InitHandler(phpBoot, vHandler.Schema, vHandler.client, logger, vHandler.Recorder)
-		handler.DefaultValue()
-		return &phpBoot.Spec, &phpBoot.ObjectMeta
-	} else if appType == webhook.ApiTypeNodeJS {
-		nodejsBoot := boot.DeepCopyNodeJS()
-		handler := nodejsboot.InitHandler(nodejsBoot, vHandler.Schema, vHandler.client, logger, vHandler.Recorder)
-		handler.DefaultValue()
-		return &nodejsBoot.Spec, &nodejsBoot.ObjectMeta
-	} else if appType == webhook.ApiTypeWeb {
-		webBoot := boot.DeepCopyWeb()
-		handler := webboot.InitHandler(webBoot, vHandler.Schema, vHandler.client, logger, vHandler.Recorder)
-		handler.DefaultValue()
-		return &webBoot.Spec, &webBoot.ObjectMeta
+		return bootObj.GetBootSpec(), bootObj.GetBootMeta()
 	}
 
 	return nil, nil
@@ -340,32 +402,62 @@ func (vHandler *BootValidator) BootNameExist(boot *v1.Boot) (string, bool) {
 		Name:      boot.Name,
 	}
 
-	err := c.Get(context.TODO(), namespaceName, &appv1.JavaBoot{})
-	if err == nil {
-		return fmt.Sprintf("Boot's name %s exists in type %s", namespaceName, webhook.ApiTypeJava), false
+	for _, bootType := range appv1.BootTypes() {
+		err := c.Get(context.TODO(), namespaceName, bootType.New())
+		if err == nil {
+			return fmt.Sprintf("Boot's name %s exists in type %s", namespaceName, bootType.Kind), false
+		}
 	}
 
-	err = c.Get(context.TODO(), namespaceName, &appv1.PhpBoot{})
-	if err == nil {
-		return fmt.Sprintf("Boot's name %s exists in type %s", namespaceName, webhook.ApiTypePhp), false
-	}
+	return "", true
+}
 
-	err = c.Get(context.TODO(), namespaceName, &appv1.PythonBoot{})
-	if err == nil {
-		return fmt.Sprintf("Boot's name %s exists in type %s", namespaceName, webhook.ApiTypePython), false
+// derivedDeploySuffixes are the suffixes of the Deployments derived from the Boot's Deployment.
+var derivedDeploySuffixes = []string{
+	"-" + operator.GreenColor,
+	"-" + operator.CanaryTrack,
+}
+
+// CheckDeployName check the Boot's Deployment does not collide with the Deployments derived from another Boot,
+// such as the green and canary Deployments which are named as the Boot with suffix "-green" and "-canary".
+// Returns
+//    msg: error message
+//    valid: If collided false, otherwise true
+func (vHandler *BootValidator) CheckDeployName(boot *v1.Boot) (string, bool) {
+	for _, suffix := range derivedDeploySuffixes {
+		if strings.HasSuffix(boot.Name, suffix) {
+			ownerName := strings.TrimSuffix(boot.Name, suffix)
+			if kind, found := vHandler.findBoot(boot.Namespace, ownerName); found {
+				return fmt.Sprintf("Boot's name %s collides with the Deployment %s of %s %s",
+					boot.Name, boot.Name, kind, ownerName), false
+			}
+		}
+
+		derivedName := boot.Name + suffix
+		if kind, found := vHandler.findBoot(boot.Namespace, derivedName); found {
+			return fmt.Sprintf("Boot's Deployment %s collides with the Deployment of %s %s",
+				derivedName, kind, derivedName), false
+		}
 	}
 
-	err = c.Get(context.TODO(), namespaceName, &appv1.NodeJSBoot{})
-	if err == nil {
-		return fmt.Sprintf("Boot's name %s exists in type %s", namespaceName, webhook.ApiTypeNodeJS), false
+	return "", true
+}
+
+// findBoot return the kind of the Boot with the name in any type, false if not found.
+func (vHandler *BootValidator) findBoot(namespace string, name string) (string, bool) {
+	namespaceName := k8stypes.NamespacedName{
+		Namespace: namespace,
+		Name:      name,
 	}
 
-	err = c.Get(context.TODO(), namespaceName, &appv1.WebBoot{})
-	if err == nil {
-		return fmt.Sprintf("Boot's name %s exists in type %s", namespaceName, webhook.ApiTypeWeb), false
+	for _, bootType := range appv1.BootTypes() {
+		err := vHandler.client.Get(context.TODO(), namespaceName, bootType.New())
+		if err == nil {
+			return bootType.Kind, true
+		}
 	}
 
-	return "", true
+	return "", false
 }
 
 // CheckPvc check the boot's pvc, pvc should exist and the label match the boot.
@@ -399,6 +491,502 @@ func (vHandler *BootValidator) CheckPvc(boot *v1.Boot, operation admssionv1beta1
 	return "", true
 }
 
+// CheckAutoscaling check the boot's autoscaling, maxReplicas could be empty which will be set by config.
+// Returns
+//    msg: error message
+//    valid: If valid false, otherwise false
+func (vHandler *BootValidator) CheckAutoscaling(boot *v1.Boot) (string, bool) {
+	autoscaling := boot.Spec.Autoscaling
+	if autoscaling == nil {
+		return "", true
+	}
+
+	if autoscaling.MaxReplicas < 0 {
+		return fmt.Sprintf("the autoscaling maxReplicas %d must be greater than 0", autoscaling.MaxReplicas), false
+	}
+
+	if autoscaling.MinReplicas != nil {
+		if *autoscaling.MinReplicas <= 0 {
+			return fmt.Sprintf("the autoscaling minReplicas %d must be greater than 0", *autoscaling.MinReplicas), false
+		}
+
+		if autoscaling.MaxReplicas > 0 && *autoscaling.MinReplicas > autoscaling.MaxReplicas {
+			return fmt.Sprintf("the autoscaling minReplicas %d must be no more than maxReplicas %d",
+				*autoscaling.MinReplicas, autoscaling.MaxReplicas), false
+		}
+	}
+
+	if autoscaling.TargetCPUUtilizationPercentage != nil && *autoscaling.TargetCPUUtilizationPercentage <= 0 {
+		return "the autoscaling targetCPUUtilizationPercentage must be greater than 0", false
+	}
+
+	if autoscaling.TargetMemoryUtilizationPercentage != nil && *autoscaling.TargetMemoryUtilizationPercentage <= 0 {
+		return "the autoscaling targetMemoryUtilizationPercentage must be greater than 0", false
+	}
+
+	return "", true
+}
+
+// CheckScalingSchedules check the boot's scaling schedules, names must be unique,
+// schedules must be valid cron expressions and replicas must not be negative.
+// Returns
+//    msg: error message
+//    valid: If valid false, otherwise false
+func (vHandler *BootValidator) CheckScalingSchedules(boot *v1.Boot) (string, bool) {
+	names := make(map[string]bool)
+	for _, schedule := range boot.Spec.ScalingSchedules {
+		if schedule.Name == "" {
+			return "the scaling schedule's name must not be empty", false
+		}
+		if names[schedule.Name] {
+			return fmt.Sprintf("the scaling schedule's name %s is duplicated", schedule.Name), false
+		}
+		names[schedule.Name] = true
+
+		if _, err := util.ParseCron(schedule.Schedule); err != nil {
+			return fmt.Sprintf("the scaling schedule %s is invalid: %s", schedule.Name, err.Error()), false
+		}
+
+		if schedule.Replicas != nil && *schedule.Replicas < 0 {
+			return fmt.Sprintf("the scaling schedule %s's replicas %d must not be negative", schedule.Name, *schedule.Replicas), false
+		}
+	}
+
+	return "", true
+}
+
+// CheckRestartSchedule check the boot's restart schedule, schedule must be a valid cron expression
+// and maxJitterSeconds must not be negative.
+// Returns
+//    msg: error message
+//    valid: If valid false, otherwise false
+func (vHandler *BootValidator) CheckRestartSchedule(boot *v1.Boot) (string, bool) {
+	restart := boot.Spec.RestartSchedule
+	if restart == nil {
+		return "", true
+	}
+
+	if restart.Schedule != "" {
+		if _, err := util.ParseCron(restart.Schedule); err != nil {
+			return fmt.Sprintf("the restart schedule is invalid: %s", err.Error()), false
+		}
+	}
+
+	if restart.MaxJitterSeconds != nil && *restart.MaxJitterSeconds < 0 {
+		return fmt.Sprintf("the restart schedule's maxJitterSeconds %d must not be negative", *restart.MaxJitterSeconds), false
+	}
+
+	return "", true
+}
+
+// CheckPorts check the boot's ports, names must be valid port names and unique,
+// containerPort and servicePort must be valid and not duplicated.
+// Returns
+//    msg: error message
+//    valid: If valid false, otherwise false
+func (vHandler *BootValidator) CheckPorts(boot *v1.Boot) (string, bool) {
+	names := make(map[string]bool)
+	containerPorts := make(map[string]bool)
+	servicePorts := make(map[string]bool)
+	for _, port := range boot.Spec.Ports {
+		if errs := validation.IsValidPortName(port.Name); len(errs) > 0 {
+			return fmt.Sprintf("the port name %s is invalid: %s", port.Name, strings.Join(errs, ",")), false
+		}
+		if names[port.Name] {
+			return fmt.Sprintf("the port name %s is duplicated", port.Name), false
+		}
+		names[port.Name] = true
+
+		if errs := validation.IsValidPortNum(int(port.ContainerPort)); len(errs) > 0 {
+			return fmt.Sprintf("the port %s containerPort %d is invalid: %s",
+				port.Name, port.ContainerPort, strings.Join(errs, ",")), false
+		}
+		if port.ServicePort != 0 {
+			if errs := validation.IsValidPortNum(int(port.ServicePort)); len(errs) > 0 {
+				return fmt.Sprintf("the port %s servicePort %d is invalid: %s",
+					port.Name, port.ServicePort, strings.Join(errs, ",")), false
+			}
+		}
+
+		protocol := port.Protocol
+		if protocol == "" {
+			protocol = corev1.ProtocolTCP
+		}
+		if protocol != corev1.ProtocolTCP && protocol != corev1.ProtocolUDP && protocol != corev1.ProtocolSCTP {
+			return fmt.Sprintf("the port %s protocol %s must be TCP, UDP or SCTP", port.Name, port.Protocol), false
+		}
+
+		servicePort := port.ServicePort
+		if servicePort == 0 {
+			servicePort = port.ContainerPort
+		}
+
+		containerKey := fmt.Sprintf("%s/%d", protocol, port.ContainerPort)
+		if containerPorts[containerKey] {
+			return fmt.Sprintf("the port %s containerPort %s is duplicated", port.Name, containerKey), false
+		}
+		containerPorts[containerKey] = true
+
+		serviceKey := fmt.Sprintf("%s/%d", protocol, servicePort)
+		if servicePorts[serviceKey] {
+			return fmt.Sprintf("the port %s servicePort %s is duplicated", port.Name, serviceKey), false
+		}
+		servicePorts[serviceKey] = true
+	}
+
+	return "", true
+}
+
+// CheckProbes check the boot's livenessProbe and readinessProbe.
+// Returns
+//    msg: error message
+//    valid: If valid false, otherwise false
+func (vHandler *BootValidator) CheckProbes(boot *v1.Boot) (string, bool) {
+	names := []string{"livenessProbe", "readinessProbe"}
+	probes := []*appv1.BootProbe{boot.Spec.LivenessProbe, boot.Spec.ReadinessProbe}
+
+	for i, probe := range probes {
+		if probe == nil {
+			continue
+		}
+		name := names[i]
+
+		switch probe.Type {
+		case "", appv1.BootProbeHTTP, appv1.BootProbeTCP, appv1.BootProbeGRPC:
+		case appv1.BootProbeExec:
+			if len(probe.Command) == 0 {
+				return fmt.Sprintf("the %s command must be set for exec probe", name), false
+			}
+		default:
+			return fmt.Sprintf("the %s type %s must be http, tcp, exec or grpc", name, probe.Type), false
+		}
+
+		if probe.Port != 0 {
+			if errs := validation.IsValidPortNum(int(probe.Port)); len(errs) > 0 {
+				return fmt.Sprintf("the %s port %d is invalid: %s", name, probe.Port, strings.Join(errs, ",")), false
+			}
+		}
+
+		if probe.InitialDelaySeconds != nil && *probe.InitialDelaySeconds < 0 {
+			return fmt.Sprintf("the %s initialDelaySeconds must not be negative", name), false
+		}
+
+		fields := []string{"timeoutSeconds", "periodSeconds", "successThreshold", "failureThreshold"}
+		values := []*int32{probe.TimeoutSeconds, probe.PeriodSeconds, probe.SuccessThreshold, probe.FailureThreshold}
+		for j, value := range values {
+			if value != nil && *value <= 0 {
+				return fmt.Sprintf("the %s %s must be greater than 0", name, fields[j]), false
+			}
+		}
+	}
+
+	if probe := boot.Spec.LivenessProbe; probe != nil && probe.SuccessThreshold != nil && *probe.SuccessThreshold != 1 {
+		return "the livenessProbe successThreshold must be 1", false
+	}
+
+	return "", true
+}
+
+// CheckScheduling check the boot's topologySpread, topologyKey must be unique.
+// Tolerations and nodeAffinity are validated by kubernetes when updating the Deployment.
+// Returns
+//    msg: error message
+//    valid: If valid false, otherwise false
+func (vHandler *BootValidator) CheckScheduling(boot *v1.Boot) (string, bool) {
+	topologyKeys := make(map[string]bool)
+	for _, spread := range boot.Spec.TopologySpread {
+		if spread.TopologyKey == "" {
+			return "the topologySpread topologyKey must not be empty", false
+		}
+		if topologyKeys[spread.TopologyKey] {
+			return fmt.Sprintf("the topologySpread topologyKey %s is duplicated", spread.TopologyKey), false
+		}
+		topologyKeys[spread.TopologyKey] = true
+
+		if spread.WhenUnsatisfiable != "" && spread.WhenUnsatisfiable != appv1.DoNotSchedule &&
+			spread.WhenUnsatisfiable != appv1.ScheduleAnyway {
+			return fmt.Sprintf("the topologySpread %s whenUnsatisfiable %s must be DoNotSchedule or ScheduleAnyway",
+				spread.TopologyKey, spread.WhenUnsatisfiable), false
+		}
+
+		if spread.Weight < 0 || spread.Weight > 100 {
+			return fmt.Sprintf("the topologySpread %s weight %d must be in the range 1-100",
+				spread.TopologyKey, spread.Weight), false
+		}
+	}
+
+	return "", true
+}
+
+// CheckStrategy check the boot's strategy, Recreate could not set maxSurge and maxUnavailable,
+// maxSurge and maxUnavailable must be non-negative and could not be both 0.
+// The canary steps must be positive, and the canary action must be promote or abort.
+// The blue-green could not be used with canary or autoscaling, and the blue-green action must be switch or rollback.
+// Returns
+//    msg: error message
+//    valid: If valid false, otherwise false
+func (vHandler *BootValidator) CheckStrategy(boot *v1.Boot) (string, bool) {
+	if action, found := boot.Annotations[keys.CanaryAnnotationKey]; found &&
+		action != operator.CanaryActionPromote && action != operator.CanaryActionAbort {
+		return fmt.Sprintf("the canary action %s must be %s or %s", action, operator.CanaryActionPromote, operator.CanaryActionAbort), false
+	}
+
+	if action, found := boot.Annotations[keys.BlueGreenAnnotationKey]; found &&
+		action != operator.BlueGreenActionSwitch && action != operator.BlueGreenActionRollback {
+		return fmt.Sprintf("the blue-green action %s must be %s or %s", action, operator.BlueGreenActionSwitch, operator.BlueGreenActionRollback), false
+	}
+
+	strategy := boot.Spec.Strategy
+	if strategy == nil {
+		return "", true
+	}
+
+	switch strategy.Type {
+	case "", appsv1.RollingUpdateDeploymentStrategyType:
+	case appsv1.RecreateDeploymentStrategyType:
+		if strategy.MaxSurge != nil || strategy.MaxUnavailable != nil {
+			return "the strategy maxSurge and maxUnavailable could not be set when type is Recreate", false
+		}
+	default:
+		return fmt.Sprintf("the strategy type %s must be RollingUpdate or Recreate", strategy.Type), false
+	}
+
+	// Use 100 as the total to check the value
+	maxSurge := -1
+	if strategy.MaxSurge != nil {
+		value, err := intstr.GetValueFromIntOrPercent(strategy.MaxSurge, 100, true)
+		if err != nil || value < 0 {
+			return fmt.Sprintf("the strategy maxSurge %s must be a non-negative number or percentage", strategy.MaxSurge.String()), false
+		}
+		maxSurge = value
+	}
+
+	if strategy.MaxUnavailable != nil {
+		value, err := intstr.GetValueFromIntOrPercent(strategy.MaxUnavailable, 100, false)
+		if err != nil || value < 0 || value > 100 {
+			return fmt.Sprintf("the strategy maxUnavailable %s must be a non-negative number or percentage no more than 100%%", strategy.MaxUnavailable.String()), false
+		}
+		if value == 0 && maxSurge == 0 {
+			return "the strategy maxSurge and maxUnavailable could not be both 0", false
+		}
+	}
+
+	if strategy.MinReadySeconds != nil && *strategy.MinReadySeconds < 0 {
+		return "the strategy minReadySeconds must not be negative", false
+	}
+
+	if strategy.ProgressDeadlineSeconds != nil {
+		if *strategy.ProgressDeadlineSeconds <= 0 {
+			return "the strategy progressDeadlineSeconds must be greater than 0", false
+		}
+		if strategy.MinReadySeconds != nil && *strategy.ProgressDeadlineSeconds <= *strategy.MinReadySeconds {
+			return "the strategy progressDeadlineSeconds must be greater than minReadySeconds", false
+		}
+	}
+
+	if strategy.RevisionHistoryLimit != nil && *strategy.RevisionHistoryLimit < 0 {
+		return "the strategy revisionHistoryLimit must not be negative", false
+	}
+
+	canary := strategy.Canary
+	if canary != nil {
+		for _, step := range canary.Steps {
+			value, err := intstr.GetValueFromIntOrPercent(&step, 100, true)
+			if err != nil || value <= 0 || (step.Type == intstr.String && value > 100) {
+				return fmt.Sprintf("the canary step %s must be a positive number or percentage no more than 100%%", step.String()), false
+			}
+		}
+
+		if canary.BakeSeconds != nil && *canary.BakeSeconds < 0 {
+			return "the canary bakeSeconds must not be negative", false
+		}
+	}
+
+	blueGreen := strategy.BlueGreen
+	if blueGreen != nil {
+		if canary != nil {
+			return "the strategy blueGreen could not be used with canary", false
+		}
+
+		if operator.AutoscalingEnabled(boot) {
+			return "the strategy blueGreen could not be used with autoscaling", false
+		}
+
+		if blueGreen.ScaleDownDelaySeconds != nil && *blueGreen.ScaleDownDelaySeconds < 0 {
+			return "the blueGreen scaleDownDelaySeconds must not be negative", false
+		}
+	}
+
+	return "", true
+}
+
+// CheckWorkload check the boot's workloadType and podManagementPolicy, which could not be changed after created.
+// The StatefulSet is rolled out by rolling update, the canary, blue-green and Recreate strategy are not supported.
+// Returns
+//    msg: error message
+//    valid: If valid false, otherwise false
+func (vHandler *BootValidator) CheckWorkload(boot *v1.Boot, req types.Request) (string, bool) {
+	switch boot.Spec.WorkloadType {
+	case "", v1.WorkloadTypeDeployment, v1.WorkloadTypeStatefulSet, v1.WorkloadTypeJob, v1.WorkloadTypeCronJob:
+	default:
+		return fmt.Sprintf("the workloadType %s must be %s, %s, %s or %s", boot.Spec.WorkloadType,
+			v1.WorkloadTypeDeployment, v1.WorkloadTypeStatefulSet, v1.WorkloadTypeJob, v1.WorkloadTypeCronJob), false
+	}
+
+	switch boot.Spec.PodManagementPolicy {
+	case "", appsv1.OrderedReadyPodManagement, appsv1.ParallelPodManagement:
+	default:
+		return fmt.Sprintf("the podManagementPolicy %s must be %s or %s", boot.Spec.PodManagementPolicy,
+			appsv1.OrderedReadyPodManagement, appsv1.ParallelPodManagement), false
+	}
+
+	if req.AdmissionRequest.Operation == admssionv1beta1.Update {
+		oldBoot := &v1.Boot{}
+		err := json.Unmarshal(req.AdmissionRequest.OldObject.Raw, oldBoot)
+		if err == nil {
+			if oldBoot.Spec.WorkloadType != boot.Spec.WorkloadType {
+				return "the workloadType could not be changed", false
+			}
+			if oldBoot.Spec.PodManagementPolicy != boot.Spec.PodManagementPolicy {
+				return "the podManagementPolicy could not be changed", false
+			}
+		}
+	}
+
+	if !operator.StatefulSetEnabled(boot) {
+		if boot.Spec.PodManagementPolicy != "" {
+			return "the podManagementPolicy could only be set when workloadType is StatefulSet", false
+		}
+		return "", true
+	}
+
+	strategy := boot.Spec.Strategy
+	if strategy != nil {
+		if strategy.Canary != nil || strategy.BlueGreen != nil {
+			return "the strategy canary and blueGreen could not be used with StatefulSet", false
+		}
+		if strategy.Type == appsv1.RecreateDeploymentStrategyType {
+			return "the strategy type Recreate could not be used with StatefulSet", false
+		}
+	}
+
+	return "", true
+}
+
+// CheckJob check the boot's job settings, the CronJob requires a valid schedule.
+// The Job and CronJob have no Service and run to completion, the autoscaling, schedules, subDomain
+// and the canary, blue-green strategy are not supported.
+// Returns
+//    msg: error message
+//    valid: If valid false, otherwise false
+func (vHandler *BootValidator) CheckJob(boot *v1.Boot) (string, bool) {
+	if !operator.BatchEnabled(boot) {
+		if boot.Spec.Job != nil {
+			return "the job could only be set when workloadType is Job or CronJob", false
+		}
+		return "", true
+	}
+
+	if boot.Spec.Autoscaling != nil {
+		return "the autoscaling could not be used with Job or CronJob", false
+	}
+	if len(boot.Spec.ScalingSchedules) > 0 || boot.Spec.RestartSchedule != nil {
+		return "the scalingSchedules and restartSchedule could not be used with Job or CronJob", false
+	}
+	if boot.Spec.SubDomain != "" {
+		return "the subDomain could not be used with Job or CronJob", false
+	}
+	if strategy := boot.Spec.Strategy; strategy != nil && (strategy.Canary != nil || strategy.BlueGreen != nil) {
+		return "the strategy canary and blueGreen could not be used with Job or CronJob", false
+	}
+
+	job := boot.Spec.Job
+	if job == nil {
+		job = &v1.BootJob{}
+	}
+
+	if operator.CronJobEnabled(boot) {
+		if _, err := util.ParseCron(job.Schedule); err != nil {
+			return fmt.Sprintf("the job schedule is invalid: %s", err.Error()), false
+		}
+	} else if job.Schedule != "" {
+		return "the job schedule could only be set when workloadType is CronJob", false
+	}
+
+	switch job.ConcurrencyPolicy {
+	case "", batchv1beta1.AllowConcurrent, batchv1beta1.ForbidConcurrent, batchv1beta1.ReplaceConcurrent:
+	default:
+		return fmt.Sprintf("the job concurrencyPolicy %s must be %s, %s or %s", job.ConcurrencyPolicy,
+			batchv1beta1.AllowConcurrent, batchv1beta1.ForbidConcurrent, batchv1beta1.ReplaceConcurrent), false
+	}
+
+	switch job.RestartPolicy {
+	case "", corev1.RestartPolicyOnFailure, corev1.RestartPolicyNever:
+	default:
+		return fmt.Sprintf("the job restartPolicy %s must be %s or %s", job.RestartPolicy,
+			corev1.RestartPolicyOnFailure, corev1.RestartPolicyNever), false
+	}
+
+	if job.BackoffLimit != nil && *job.BackoffLimit < 0 {
+		return "the job backoffLimit must not be negative", false
+	}
+	if job.ActiveDeadlineSeconds != nil && *job.ActiveDeadlineSeconds <= 0 {
+		return "the job activeDeadlineSeconds must be greater than 0", false
+	}
+	if job.StartingDeadlineSeconds != nil && *job.StartingDeadlineSeconds < 0 {
+		return "the job startingDeadlineSeconds must not be negative", false
+	}
+	if (job.SuccessfulJobsHistoryLimit != nil && *job.SuccessfulJobsHistoryLimit < 0) ||
+		(job.FailedJobsHistoryLimit != nil && *job.FailedJobsHistoryLimit < 0) {
+		return "the job successfulJobsHistoryLimit and failedJobsHistoryLimit must not be negative", false
+	}
+
+	return "", true
+}
+
+// CheckRollback check the boot's rollback annotation, the revision to roll back to must exist.
+// Returns
+//    msg: error message
+//    valid: If valid false, otherwise false
+func (vHandler *BootValidator) CheckRollback(boot *v1.Boot) (string, bool) {
+	revisionId, found := operator.RollbackRevisionId(boot)
+	if !found {
+		return "", true
+	}
+
+	revisionList, err := vHandler.client.ListRevision(boot.Namespace, operator.PodLabels(boot))
+	if err != nil {
+		return fmt.Sprintf("can not list the revisions to roll back: %s", err.Error()), false
+	}
+
+	if operator.FindRevision(revisionList, revisionId) == nil {
+		return fmt.Sprintf("the revision %s to roll back to is not found", revisionId), false
+	}
+
+	return "", true
+}
+
+// rollbackRevisionId return the revision's ID which the Boot is rolled back to by the request.
+// When rolling back, the operator restores the revision's spec and removes the rollback annotation in one update.
+func rollbackRevisionId(boot *v1.Boot, req types.Request) string {
+	if req.AdmissionRequest.Operation != admssionv1beta1.Update {
+		return ""
+	}
+
+	if _, found := operator.RollbackRevisionId(boot); found {
+		return ""
+	}
+
+	oldBoot := &v1.Boot{}
+	err := json.Unmarshal(req.AdmissionRequest.OldObject.Raw, oldBoot)
+	if err != nil {
+		return ""
+	}
+
+	revisionId, _ := operator.RollbackRevisionId(oldBoot)
+	return revisionId
+}
+
 // validatePvc will validate the pvcName, mountPath
 func (vHandler *BootValidator) validatePvc(boot *appv1.Boot, pvcMount appv1.PersistentVolumeClaimMount) (bool, string) {
 	pvcName, _ := operator.Decode(boot, pvcMount.Name)
@@ -466,7 +1054,7 @@ func (vHandler *BootValidator) checkPvcOwner(boot *appv1.Boot, pvcMount appv1.Pe
 //    msg: error message
 //    valid: If valid false, otherwise false
 func (vHandler *BootValidator) CheckEnvKeys(boot *v1.Boot, operation admssionv1beta1.Operation) (string, bool) {
-	configSpec := operator.GetConfigSpec(boot)
+	configSpec := operator.GetConfigSpec(boot, vHandler.ConfigProvider.Config())
 	if configSpec == nil {
 		logger.Info("AppSpec is nil, valid is true.")
 		return "", true
@@ -88,7 +88,9 @@ if [ $(uname) == "Darwin" ]; then
     sed -i "" 's/image: localhost:5000\/logancloud\/logan-app-operator:latest-e2e/image: logancloud\/logan-app-operator:latest-e2e/g' test/resources/operator-e2e-dev.yaml
 fi
 
-oc replace configmap --filename test/resources/config.yaml
+# the operator config is migrated from the configmap at startup, replace it by the e2e LoganOperatorConfig
+kubectl delete loganoperatorconfig logan-app-operator-config --ignore-not-found=true
+kubectl create -f test/resources/config.yaml
 
 #run test
 function runTest()
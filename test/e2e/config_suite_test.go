@@ -1,11 +1,271 @@
 package e2e
 
 import (
+	bootv1 "github.com/logancloud/logan-app-operator/pkg/apis/app/v1"
+	"github.com/logancloud/logan-app-operator/pkg/logan"
+	"github.com/logancloud/logan-app-operator/pkg/logan/config"
+	operatorFramework "github.com/logancloud/logan-app-operator/test/framework"
 	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 var _ = Describe("Testing Configs", func() {
 	It("TestConfigs create", func() {
 
 	})
+
+	Describe("testing reload operator config", func() {
+		var bootKey types.NamespacedName
+		var javaBoot *bootv1.JavaBoot
+		var configNN = types.NamespacedName{
+			Name: "logan-app-operator-config",
+		}
+		var option = "-Dlogan.reload=true"
+
+		// updateJavaJVMOption updates the operator config's java jvm options with the option added or removed
+		updateJavaJVMOption := func(add bool) {
+			c := operatorFramework.GetConfig(configNN)
+			operator := c[logan.BootJava]
+			if operator.AppSpec.JVM == nil {
+				operator.AppSpec.JVM = &config.JVMConfig{}
+			}
+
+			options := make([]string, 0)
+			for _, value := range operator.AppSpec.JVM.Options {
+				if value != option {
+					options = append(options, value)
+				}
+			}
+			if add {
+				options = append(options, option)
+			}
+			operator.AppSpec.JVM.Options = options
+			c[logan.BootJava] = operator
+			operatorFramework.UpdateConfig(configNN, c)
+		}
+
+		BeforeEach(func() {
+			// Gen new namespace
+			bootKey = operatorFramework.GenResource()
+			operatorFramework.CreateNamespace(bootKey.Namespace)
+			javaBoot = operatorFramework.SampleBoot(bootKey)
+		})
+
+		AfterEach(func() {
+			// Clean config map and namespace
+			updateJavaJVMOption(false)
+			operatorFramework.DeleteNamespace(bootKey.Namespace)
+		})
+
+		It("testing the existing boots are reconciled with the reloaded config", func() {
+			(&(operatorFramework.E2E{
+				Build: func() {
+					operatorFramework.CreateBoot(javaBoot)
+				},
+				Check: func() {
+					deploy := operatorFramework.GetDeployment(bootKey)
+					env := containerEnv(deploy.Spec.Template.Spec.Containers[0], "JAVA_OPTS")
+					Expect(env).ShouldNot(BeNil())
+					Expect(env.Value).ShouldNot(ContainSubstring(option))
+				},
+				Update: func() {
+					updateJavaJVMOption(true)
+				},
+				Recheck: func() {
+					deploy := operatorFramework.GetDeployment(bootKey)
+					env := containerEnv(deploy.Spec.Template.Spec.Containers[0], "JAVA_OPTS")
+					Expect(env).ShouldNot(BeNil())
+					Expect(env.Value).Should(ContainSubstring(option))
+				},
+			})).Run()
+		})
+
+		It("testing the boots of other types are not changed by the reloaded config", func() {
+			var generation int64
+			(&(operatorFramework.E2E{
+				Build: func() {
+					operatorFramework.CreateBoot(operatorFramework.SamplePhpBoot(bootKey))
+				},
+				Check: func() {
+					deploy := operatorFramework.GetDeployment(bootKey)
+					generation = deploy.Generation
+				},
+				Update: func() {
+					updateJavaJVMOption(true)
+				},
+				Recheck: func() {
+					deploy := operatorFramework.GetDeployment(bootKey)
+					Expect(deploy.Generation).Should(Equal(generation))
+				},
+			})).Run()
+		})
+	})
+
+	Describe("testing operator config status and validation [Serial]", func() {
+		var configYamlStr string
+		var configNN = types.NamespacedName{
+			Name: "logan-app-operator-config",
+		}
+
+		BeforeEach(func() {
+			// backup operator config
+			configYamlStr = operatorFramework.GetConfigStr(configNN)
+		})
+
+		AfterEach(func() {
+			// recover operator config
+			operatorFramework.UpdateConfigStr(configNN, configYamlStr)
+		})
+
+		It("testing the status reports the loaded generation", func() {
+			(&(operatorFramework.E2E{
+				Update: func() {
+					c := operatorFramework.GetConfig(configNN)
+					c[logan.BootJava].AppSpec.Replicas = c[logan.BootJava].AppSpec.Replicas + 1
+					operatorFramework.UpdateConfig(configNN, c)
+				},
+				Recheck: func() {
+					operatorConfig := operatorFramework.GetOperatorConfig(configNN)
+					Expect(operatorConfig.Status.ObservedGeneration).Should(Equal(operatorConfig.Generation))
+					Expect(operatorConfig.Status.LoadedGeneration).Should(Equal(operatorConfig.Generation))
+					Expect(operatorConfig.Status.LoadedTime).ShouldNot(BeNil())
+					Expect(operatorConfig.Status.Message).Should(BeEmpty())
+				},
+			})).Run()
+		})
+
+		It("testing the invalid config is not loaded", func() {
+			var loaded int64
+			(&(operatorFramework.E2E{
+				Check: func() {
+					operatorConfig := operatorFramework.GetOperatorConfig(configNN)
+					loaded = operatorConfig.Status.LoadedGeneration
+					Expect(loaded).Should(Equal(operatorConfig.Generation))
+				},
+				Update: func() {
+					c := operatorFramework.GetConfig(configNN)
+					c["javaext"].Extends = config.Extends{"unknown"}
+					operatorFramework.UpdateConfig(configNN, c)
+				},
+				Recheck: func() {
+					operatorConfig := operatorFramework.GetOperatorConfig(configNN)
+					Expect(operatorConfig.Status.ObservedGeneration).Should(Equal(operatorConfig.Generation))
+					Expect(operatorConfig.Status.LoadedGeneration).Should(Equal(loaded))
+					Expect(operatorConfig.Status.Message).Should(ContainSubstring("unknown"))
+				},
+			})).Run()
+		})
+
+		It("testing the config is validated by the schema", func() {
+			(&(operatorFramework.E2E{
+				Update: func() {
+					c := operatorFramework.GetConfig(configNN)
+					c[logan.BootJava].AppSpec.JVM = &config.JVMConfig{HeapPercentage: 150}
+					err := operatorFramework.UpdateConfigWithError(configNN, c)
+					Expect(err).Should(HaveOccurred())
+
+					c = operatorFramework.GetConfig(configNN)
+					c[logan.BootJava].SidecarServices = &[]config.SidecarService{{Port: 8080}}
+					err = operatorFramework.UpdateConfigWithError(configNN, c)
+					Expect(err).Should(HaveOccurred())
+				},
+			})).Run()
+		})
+	})
+
+	Describe("testing profile extends", func() {
+		var bootKey types.NamespacedName
+
+		BeforeEach(func() {
+			// Gen new namespace
+			bootKey = operatorFramework.GenResource()
+			operatorFramework.CreateNamespace(bootKey.Namespace)
+		})
+
+		AfterEach(func() {
+			// Clean namespace
+			operatorFramework.DeleteNamespace(bootKey.Namespace)
+		})
+
+		It("testing the boot with the profile inherits the java config", func() {
+			(&(operatorFramework.E2E{
+				Build: func() {
+					javaBoot := operatorFramework.SampleBoot(bootKey)
+					javaBoot.Annotations = map[string]string{config.BootProfileAnnotationKey: "javaext"}
+					operatorFramework.CreateBoot(javaBoot)
+				},
+				Check: func() {
+					deploy := operatorFramework.GetDeployment(bootKey)
+					container := deploy.Spec.Template.Spec.Containers[0]
+					Expect(container.Env).Should(ContainElement(
+						corev1.EnvVar{Name: "SPRING_ZIPKIN_ENABLED", Value: "false"}))
+					Expect(container.Env).Should(ContainElement(
+						corev1.EnvVar{Name: "SPRING_APPLICATION_NAME", Value: bootKey.Name}))
+					Expect(container.Resources.Limits.Cpu().Cmp(resource.MustParse("2"))).Should(Equal(0))
+					Expect(container.Resources.Limits.Memory().Cmp(resource.MustParse("1Gi"))).Should(Equal(0))
+
+					env := containerEnv(container, "JAVA_OPTS")
+					Expect(env).ShouldNot(BeNil())
+					Expect(env.Value).Should(ContainSubstring("-Xmx768m"))
+					Expect(env.Value).Should(ContainSubstring("-XX:+UseG1GC"))
+				},
+			})).Run()
+		})
+	})
+
+	Describe("testing namespace overlays", func() {
+		var bootKey types.NamespacedName
+
+		BeforeEach(func() {
+			// Gen new namespace with the overlay's labels
+			bootKey = operatorFramework.GenResource()
+			operatorFramework.CreateNamespaceWithLabels(bootKey.Namespace, map[string]string{"logan/team": "payments"})
+		})
+
+		AfterEach(func() {
+			// Clean namespace
+			operatorFramework.DeleteNamespace(bootKey.Namespace)
+		})
+
+		It("testing the boot in the selected namespace applies the overlay", func() {
+			(&(operatorFramework.E2E{
+				Build: func() {
+					operatorFramework.CreateBoot(operatorFramework.SampleBoot(bootKey))
+				},
+				Check: func() {
+					deploy := operatorFramework.GetDeployment(bootKey)
+					container := deploy.Spec.Template.Spec.Containers[0]
+					Expect(container.Env).Should(ContainElement(corev1.EnvVar{Name: "TEAM", Value: "payments"}))
+					Expect(container.Resources.Limits.Memory().Cmp(resource.MustParse("1Gi"))).Should(Equal(0))
+
+					boot := operatorFramework.GetBoot(bootKey)
+					Expect(boot.Status.Config).Should(Equal("type=java;overlays=team-payments"))
+				},
+				Update: func() {
+					_, err := operatorFramework.UpdateNamespaceLabels(bootKey.Namespace, map[string]string{"logan/team": "orders"})
+					Expect(err).NotTo(HaveOccurred())
+				},
+				Recheck: func() {
+					boot := operatorFramework.GetBoot(bootKey)
+					Expect(boot.Status.Config).Should(Equal("type=java"))
+				},
+			})).Run()
+		})
+
+		It("testing the boot of other types is not applied the overlay", func() {
+			(&(operatorFramework.E2E{
+				Build: func() {
+					operatorFramework.CreateBoot(operatorFramework.SamplePhpBoot(bootKey))
+				},
+				Check: func() {
+					deploy := operatorFramework.GetDeployment(bootKey)
+					container := deploy.Spec.Template.Spec.Containers[0]
+					Expect(containerEnv(container, "TEAM")).Should(BeNil())
+				},
+			})).Run()
+		})
+	})
 })
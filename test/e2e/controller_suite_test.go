@@ -8,6 +8,7 @@ import (
 	. "github.com/onsi/gomega"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -47,6 +48,31 @@ var _ = Describe("Testing Boot[CONTROLLER]", func() {
 				operatorFramework.DeleteService(svr)
 			})
 
+			It("testing create boot with status", func() {
+				operatorFramework.CreateBoot(javaBoot)
+
+				boot := operatorFramework.GetBoot(bootKey)
+				Expect(boot.Status.Deploy).Should(Equal(bootKey.Name))
+				Expect(boot.Status.Type).Should(Equal(keys.AppTypeAnnotationDeploy))
+				Expect(boot.Status.ObservedGeneration).Should(Equal(boot.Generation))
+				Expect(boot.Status.Revision).Should(Equal("1"))
+				Expect(boot.Status.Phase).ShouldNot(BeEmpty())
+				Expect(boot.Status.IsConditionTrue(bootv1.BootConfigValid)).Should(BeTrue())
+				Expect(boot.Status.IsConditionTrue(bootv1.BootReconcileError)).Should(BeFalse())
+				Expect(boot.Status.GetCondition(bootv1.BootAvailable)).ShouldNot(BeNil())
+				Expect(boot.Status.GetCondition(bootv1.BootProgressing)).ShouldNot(BeNil())
+			})
+
+			It("testing create boot with scale status", func() {
+				operatorFramework.CreateBoot(javaBoot)
+
+				boot := operatorFramework.GetBoot(bootKey)
+				deploy := operatorFramework.GetDeployment(bootKey)
+				selector := metav1.FormatLabelSelector(deploy.Spec.Selector)
+				Expect(boot.Status.Selector).Should(Equal(selector))
+				Expect(boot.Status.Replicas).Should(Equal(deploy.Status.Replicas))
+			})
+
 			It("testing create boot after deployments create", func() {
 				// get deploy data
 				operatorFramework.CreateBoot(javaBoot)
@@ -1463,4 +1489,647 @@ var _ = Describe("Testing Boot[CONTROLLER]", func() {
 		})
 	})
 
+	Describe("testing boot ports", func() {
+		It("testing create multiple named ports", func() {
+			(&(operatorFramework.E2E{
+				Build: func() {
+					javaBoot.Spec.Ports = []bootv1.BootPort{
+						{Name: "http", ContainerPort: 8080},
+						{Name: "grpc", ContainerPort: 9090, ServicePort: 19090, AppProtocol: "grpc"},
+						{Name: "management", ContainerPort: 8081},
+					}
+					operatorFramework.CreateBoot(javaBoot)
+				},
+				Check: func() {
+					boot := operatorFramework.GetBoot(bootKey)
+					Expect(boot.Spec.Port).Should(Equal(int32(8080)))
+
+					deploy := operatorFramework.GetDeployment(bootKey)
+					containerPorts := deploy.Spec.Template.Spec.Containers[0].Ports
+					Expect(len(containerPorts)).Should(Equal(3))
+					Expect(containerPorts[1].Name).Should(Equal("grpc"))
+					Expect(containerPorts[1].ContainerPort).Should(Equal(int32(9090)))
+
+					svc := operatorFramework.GetService(bootKey)
+					Expect(len(svc.Spec.Ports)).Should(Equal(3))
+					Expect(svc.Spec.Ports[1].Name).Should(Equal("grpc"))
+					Expect(svc.Spec.Ports[1].Port).Should(Equal(int32(19090)))
+					Expect(svc.Spec.Ports[1].TargetPort.IntVal).Should(Equal(int32(9090)))
+					Expect(svc.Annotations[keys.PrometheusPortAnnotationKey]).Should(Equal("8081"))
+					Expect(svc.Annotations[keys.AppProtocolsAnnotationKey]).Should(Equal("grpc=grpc"))
+				},
+				Update: func() {
+					boot := operatorFramework.GetBoot(bootKey)
+					boot.Spec.Ports = boot.Spec.Ports[:1]
+					operatorFramework.UpdateBoot(boot)
+				},
+				Recheck: func() {
+					deploy := operatorFramework.GetDeployment(bootKey)
+					Expect(len(deploy.Spec.Template.Spec.Containers[0].Ports)).Should(Equal(1))
+
+					svc := operatorFramework.GetService(bootKey)
+					Expect(len(svc.Spec.Ports)).Should(Equal(1))
+					Expect(svc.Spec.Ports[0].Port).Should(Equal(int32(8080)))
+					Expect(svc.Annotations[keys.PrometheusPortAnnotationKey]).Should(Equal("8080"))
+					Expect(svc.Annotations).ShouldNot(HaveKey(keys.AppProtocolsAnnotationKey))
+				},
+			})).Run()
+		})
+	})
+
+	Describe("testing boot probes", func() {
+		It("testing create tcp liveness probe", func() {
+			(&(operatorFramework.E2E{
+				Build: func() {
+					initialDelaySeconds := int32(300)
+					javaBoot.Spec.LivenessProbe = &bootv1.BootProbe{
+						Type:                bootv1.BootProbeTCP,
+						InitialDelaySeconds: &initialDelaySeconds,
+					}
+					operatorFramework.CreateBoot(javaBoot)
+				},
+				Check: func() {
+					boot := operatorFramework.GetBoot(bootKey)
+					deploy := operatorFramework.GetDeployment(bootKey)
+					liveness := deploy.Spec.Template.Spec.Containers[0].LivenessProbe
+					Expect(liveness.HTTPGet).Should(BeNil())
+					Expect(liveness.TCPSocket.Port.IntVal).Should(Equal(boot.Spec.Port))
+					Expect(liveness.InitialDelaySeconds).Should(Equal(int32(300)))
+
+					readiness := deploy.Spec.Template.Spec.Containers[0].ReadinessProbe
+					Expect(readiness.HTTPGet.Path).Should(Equal(*boot.Spec.Health))
+				},
+				Update: func() {
+					boot := operatorFramework.GetBoot(bootKey)
+					timeoutSeconds := int32(3)
+					boot.Spec.ReadinessProbe = &bootv1.BootProbe{
+						Type:           bootv1.BootProbeExec,
+						Command:        []string{"cat", "/tmp/ready"},
+						TimeoutSeconds: &timeoutSeconds,
+					}
+					operatorFramework.UpdateBoot(boot)
+				},
+				Recheck: func() {
+					deploy := operatorFramework.GetDeployment(bootKey)
+					readiness := deploy.Spec.Template.Spec.Containers[0].ReadinessProbe
+					Expect(readiness.HTTPGet).Should(BeNil())
+					Expect(readiness.Exec.Command).Should(Equal([]string{"cat", "/tmp/ready"}))
+					Expect(readiness.TimeoutSeconds).Should(Equal(int32(3)))
+				},
+			})).Run()
+		})
+	})
+
+	Describe("testing boot scheduling", func() {
+		It("testing create tolerations and topology spread", func() {
+			(&(operatorFramework.E2E{
+				Build: func() {
+					javaBoot.Spec.Tolerations = []corev1.Toleration{
+						{Key: "logan/dedicated", Operator: corev1.TolerationOpEqual, Value: "app", Effect: corev1.TaintEffectNoSchedule},
+					}
+					javaBoot.Spec.TopologySpread = []bootv1.BootTopologySpread{
+						{TopologyKey: "failure-domain.beta.kubernetes.io/zone", Weight: 50},
+					}
+					operatorFramework.CreateBoot(javaBoot)
+				},
+				Check: func() {
+					deploy := operatorFramework.GetDeployment(bootKey)
+					podSpec := deploy.Spec.Template.Spec
+					Expect(podSpec.Tolerations).Should(HaveLen(1))
+					Expect(podSpec.Tolerations[0].Key).Should(Equal("logan/dedicated"))
+
+					preferred := podSpec.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution
+					Expect(preferred).Should(HaveLen(1))
+					Expect(preferred[0].Weight).Should(Equal(int32(50)))
+					Expect(preferred[0].PodAffinityTerm.TopologyKey).Should(Equal("failure-domain.beta.kubernetes.io/zone"))
+				},
+				Update: func() {
+					boot := operatorFramework.GetBoot(bootKey)
+					boot.Spec.Tolerations = nil
+					boot.Spec.TopologySpread = []bootv1.BootTopologySpread{
+						{TopologyKey: "kubernetes.io/hostname", WhenUnsatisfiable: bootv1.DoNotSchedule},
+					}
+					operatorFramework.UpdateBoot(boot)
+				},
+				Recheck: func() {
+					deploy := operatorFramework.GetDeployment(bootKey)
+					podSpec := deploy.Spec.Template.Spec
+					Expect(podSpec.Tolerations).Should(BeEmpty())
+					Expect(podSpec.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution).Should(BeEmpty())
+
+					required := podSpec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+					Expect(required).Should(HaveLen(1))
+					Expect(required[0].TopologyKey).Should(Equal("kubernetes.io/hostname"))
+				},
+			})).Run()
+		})
+	})
+
+	Describe("testing boot strategy", func() {
+		It("testing update strategy without rolling update", func() {
+			var template corev1.PodTemplateSpec
+			(&(operatorFramework.E2E{
+				Build: func() {
+					operatorFramework.CreateBoot(javaBoot)
+				},
+				Check: func() {
+					deploy := operatorFramework.GetDeployment(bootKey)
+					Expect(deploy.Spec.Strategy.Type).Should(Equal(appsv1.RollingUpdateDeploymentStrategyType))
+					Expect(deploy.Spec.Strategy.RollingUpdate.MaxUnavailable.String()).Should(Equal("1%"))
+					Expect(*deploy.Spec.RevisionHistoryLimit).Should(Equal(int32(5)))
+					template = deploy.Spec.Template
+				},
+				Update: func() {
+					boot := operatorFramework.GetBoot(bootKey)
+					minReadySeconds := int32(10)
+					boot.Spec.Strategy = &bootv1.BootStrategy{
+						Type:            appsv1.RecreateDeploymentStrategyType,
+						MinReadySeconds: &minReadySeconds,
+					}
+					operatorFramework.UpdateBoot(boot)
+				},
+				Recheck: func() {
+					deploy := operatorFramework.GetDeployment(bootKey)
+					Expect(deploy.Spec.Strategy.Type).Should(Equal(appsv1.RecreateDeploymentStrategyType))
+					Expect(deploy.Spec.Strategy.RollingUpdate).Should(BeNil())
+					Expect(deploy.Spec.MinReadySeconds).Should(Equal(int32(10)))
+					Expect(deploy.Spec.Template).Should(Equal(template))
+				},
+			})).Run()
+		})
+	})
+
+	Describe("testing boot suspend", func() {
+		It("testing suspend and resume the boot", func() {
+			(&(operatorFramework.E2E{
+				Build: func() {
+					operatorFramework.CreateBoot(javaBoot)
+				},
+				Check: func() {
+					deploy := operatorFramework.GetDeployment(bootKey)
+					Expect(*deploy.Spec.Replicas).Should(Equal(*javaBoot.Spec.Replicas))
+				},
+				Update: func() {
+					boot := operatorFramework.GetBoot(bootKey)
+					suspend := true
+					boot.Spec.Suspend = &suspend
+					operatorFramework.UpdateBoot(boot)
+				},
+				Recheck: func() {
+					// Suspended: scaled to zero, the Boot's replicas is kept.
+					deploy := operatorFramework.GetDeployment(bootKey)
+					Expect(*deploy.Spec.Replicas).Should(Equal(int32(0)))
+					Expect(deploy.Annotations[keys.SuspendedReplicasAnnotationKey]).
+						Should(Equal(strconv.Itoa(int(*javaBoot.Spec.Replicas))))
+
+					boot := operatorFramework.GetBoot(bootKey)
+					Expect(*boot.Spec.Replicas).Should(Equal(*javaBoot.Spec.Replicas))
+					Expect(boot.Status.Phase).Should(Equal(bootv1.BootPhaseSuspended))
+
+					// Resumed: the prior replicas are restored.
+					suspend := false
+					boot.Spec.Suspend = &suspend
+					operatorFramework.UpdateBoot(boot)
+
+					deploy = operatorFramework.GetDeployment(bootKey)
+					Expect(*deploy.Spec.Replicas).Should(Equal(*javaBoot.Spec.Replicas))
+					_, found := deploy.Annotations[keys.SuspendedReplicasAnnotationKey]
+					Expect(found).Should(BeFalse())
+				},
+			})).Run()
+		})
+	})
+
+	Describe("testing boot scaling schedules", func() {
+		It("testing scale to zero by the active schedule", func() {
+			(&(operatorFramework.E2E{
+				Build: func() {
+					operatorFramework.CreateBoot(javaBoot)
+				},
+				Check: func() {
+					deploy := operatorFramework.GetDeployment(bootKey)
+					Expect(*deploy.Spec.Replicas).Should(Equal(*javaBoot.Spec.Replicas))
+				},
+				Update: func() {
+					// The schedule fires every minute, it is always active.
+					boot := operatorFramework.GetBoot(bootKey)
+					replicas := int32(0)
+					boot.Spec.ScalingSchedules = []bootv1.BootScalingSchedule{
+						{Name: "night", Schedule: "* * * * *", Replicas: &replicas},
+					}
+					operatorFramework.UpdateBoot(boot)
+				},
+				Recheck: func() {
+					deploy := operatorFramework.GetDeployment(bootKey)
+					Expect(*deploy.Spec.Replicas).Should(Equal(int32(0)))
+
+					boot := operatorFramework.GetBoot(bootKey)
+					Expect(*boot.Spec.Replicas).Should(Equal(*javaBoot.Spec.Replicas))
+					Expect(boot.Status.ScalingSchedule).Should(Equal("night"))
+					Expect(boot.Status.NextScheduleTime).ShouldNot(BeNil())
+
+					// The schedules are removed, scaled back to the Boot's replicas.
+					boot.Spec.ScalingSchedules = nil
+					operatorFramework.UpdateBoot(boot)
+
+					deploy = operatorFramework.GetDeployment(bootKey)
+					Expect(*deploy.Spec.Replicas).Should(Equal(*javaBoot.Spec.Replicas))
+					boot = operatorFramework.GetBoot(bootKey)
+					Expect(boot.Status.ScalingSchedule).Should(BeEmpty())
+				},
+			})).Run()
+		})
+	})
+
+	Describe("testing boot restart schedule", func() {
+		It("testing restart by the restart schedule", func() {
+			(&(operatorFramework.E2E{
+				Build: func() {
+					operatorFramework.CreateBoot(javaBoot)
+				},
+				Check: func() {
+					boot := operatorFramework.GetBoot(bootKey)
+					_, found := boot.Annotations[keys.BootRestartedAtAnnotationKey]
+					Expect(found).Should(BeFalse())
+				},
+				Update: func() {
+					// The schedule fires every minute, the first fired time is only recorded.
+					boot := operatorFramework.GetBoot(bootKey)
+					boot.Spec.RestartSchedule = &bootv1.BootRestartSchedule{
+						Schedule: "* * * * *",
+					}
+					operatorFramework.UpdateBoot(boot)
+				},
+				Recheck: func() {
+					boot := operatorFramework.GetBoot(bootKey)
+					Expect(boot.Status.LastScheduledRestartTime).ShouldNot(BeNil())
+					Expect(boot.Status.NextScheduledRestartTime).ShouldNot(BeNil())
+
+					// Restarted at the next minute, the restartedAt is copied into the pod template.
+					operatorFramework.WaitUpdate(70)
+					boot = operatorFramework.GetBoot(bootKey)
+					restartedAt, found := boot.Annotations[keys.BootRestartedAtAnnotationKey]
+					Expect(found).Should(BeTrue())
+
+					deploy := operatorFramework.GetDeployment(bootKey)
+					Expect(deploy.Spec.Template.Annotations[keys.BootRestartedAtAnnotationKey]).Should(Equal(restartedAt))
+				},
+			})).Run()
+		})
+	})
+
+	Describe("testing boot canary", func() {
+		It("testing canary rollout and promote manually", func() {
+			var image string
+			(&(operatorFramework.E2E{
+				Build: func() {
+					javaBoot.Spec.Strategy = &bootv1.BootStrategy{
+						Canary: &bootv1.BootCanary{},
+					}
+					operatorFramework.CreateBoot(javaBoot)
+				},
+				Check: func() {
+					deploy := operatorFramework.GetDeployment(bootKey)
+					image = deploy.Spec.Template.Spec.Containers[0].Image
+				},
+				Update: func() {
+					boot := operatorFramework.GetBoot(bootKey)
+					boot.Spec.Version = "canary"
+					operatorFramework.UpdateBoot(boot)
+				},
+				Recheck: func() {
+					// The stable Deployment is kept, the new revision is deployed to the canary Deployment.
+					deploy := operatorFramework.GetDeployment(bootKey)
+					Expect(deploy.Spec.Template.Spec.Containers[0].Image).Should(Equal(image))
+
+					canaryKey := types.NamespacedName{Name: bootKey.Name + "-canary", Namespace: bootKey.Namespace}
+					canary := operatorFramework.GetDeployment(canaryKey)
+					Expect(*canary.Spec.Replicas).Should(Equal(int32(1)))
+					Expect(canary.Spec.Template.Spec.Containers[0].Image).Should(HaveSuffix(":canary"))
+					Expect(canary.Spec.Template.Labels[keys.BootTrackKey]).Should(Equal("canary"))
+					Expect(canary.Spec.Template.Labels[keys.BootRevisionKey]).Should(Equal("2"))
+
+					// Promote the canary, the stable Deployment is updated.
+					boot := operatorFramework.GetBoot(bootKey)
+					boot.Annotations[keys.CanaryAnnotationKey] = "promote"
+					operatorFramework.UpdateBoot(boot)
+
+					deploy = operatorFramework.GetDeployment(bootKey)
+					Expect(deploy.Spec.Template.Spec.Containers[0].Image).Should(HaveSuffix(":canary"))
+					boot = operatorFramework.GetBoot(bootKey)
+					_, found := boot.Annotations[keys.CanaryAnnotationKey]
+					Expect(found).Should(BeFalse())
+				},
+			})).Run()
+		})
+	})
+
+	Describe("testing boot blue-green", func() {
+		It("testing blue-green rollout to the other color", func() {
+			var image string
+			(&(operatorFramework.E2E{
+				Build: func() {
+					javaBoot.Spec.Strategy = &bootv1.BootStrategy{
+						BlueGreen: &bootv1.BootBlueGreen{},
+					}
+					operatorFramework.CreateBoot(javaBoot)
+				},
+				Check: func() {
+					deploy := operatorFramework.GetDeployment(bootKey)
+					image = deploy.Spec.Template.Spec.Containers[0].Image
+					Expect(deploy.Spec.Template.Labels[keys.BootColorKey]).Should(Equal("blue"))
+
+					svc := operatorFramework.GetService(bootKey)
+					Expect(svc.Spec.Selector[keys.BootColorKey]).Should(Equal("blue"))
+				},
+				Update: func() {
+					boot := operatorFramework.GetBoot(bootKey)
+					boot.Spec.Version = "green"
+					operatorFramework.UpdateBoot(boot)
+				},
+				Recheck: func() {
+					// The blue Deployment keeps serving, the new revision is deployed to the green Deployment.
+					deploy := operatorFramework.GetDeployment(bootKey)
+					Expect(deploy.Spec.Template.Spec.Containers[0].Image).Should(Equal(image))
+
+					greenKey := types.NamespacedName{Name: bootKey.Name + "-green", Namespace: bootKey.Namespace}
+					green := operatorFramework.GetDeployment(greenKey)
+					Expect(*green.Spec.Replicas).Should(Equal(*javaBoot.Spec.Replicas))
+					Expect(green.Spec.Template.Spec.Containers[0].Image).Should(HaveSuffix(":green"))
+					Expect(green.Spec.Template.Labels[keys.BootColorKey]).Should(Equal("green"))
+
+					svc := operatorFramework.GetService(bootKey)
+					Expect(svc.Spec.Selector[keys.BootColorKey]).Should(Equal("blue"))
+				},
+			})).Run()
+		})
+
+		It("testing blue-green could not be used with canary", func() {
+			javaBoot.Spec.Strategy = &bootv1.BootStrategy{
+				BlueGreen: &bootv1.BootBlueGreen{},
+				Canary:    &bootv1.BootCanary{},
+			}
+			err := operatorFramework.CreateBootWithError(javaBoot)
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+
+	Describe("testing boot ingress", func() {
+		It("testing create ingress by subDomain", func() {
+			(&(operatorFramework.E2E{
+				Build: func() {
+					javaBoot.Spec.SubDomain = "logan.local"
+					operatorFramework.CreateBoot(javaBoot)
+				},
+				Check: func() {
+					boot := operatorFramework.GetBoot(bootKey)
+					ingress := operatorFramework.GetIngress(bootKey)
+					Expect(ingress.OwnerReferences[0].UID).Should(Equal(boot.UID))
+					Expect(len(ingress.Spec.Rules)).Should(Equal(1))
+
+					rule := ingress.Spec.Rules[0]
+					Expect(rule.Host).Should(Equal(bootKey.Name + ".logan.local"))
+					Expect(rule.HTTP.Paths[0].Path).Should(Equal("/"))
+					Expect(rule.HTTP.Paths[0].Backend.ServiceName).Should(Equal(bootKey.Name))
+					Expect(rule.HTTP.Paths[0].Backend.ServicePort.IntVal).Should(Equal(boot.Spec.Port))
+				},
+				Update: func() {
+					boot := operatorFramework.GetBoot(bootKey)
+					boot.Spec.SubDomain = "logan.com"
+					operatorFramework.UpdateBoot(boot)
+				},
+				Recheck: func() {
+					ingress := operatorFramework.GetIngress(bootKey)
+					Expect(ingress.Spec.Rules[0].Host).Should(Equal(bootKey.Name + ".logan.com"))
+				},
+			})).Run()
+		})
+	})
+
+	Describe("testing boot autoscaling", func() {
+		It("testing create and disable autoscaling", func() {
+			minReplicas := int32(2)
+			targetCPU := int32(60)
+			(&(operatorFramework.E2E{
+				Build: func() {
+					javaBoot.Spec.Autoscaling = &bootv1.BootAutoscaling{
+						MinReplicas:                    &minReplicas,
+						MaxReplicas:                    4,
+						TargetCPUUtilizationPercentage: &targetCPU,
+					}
+					operatorFramework.CreateBoot(javaBoot)
+				},
+				Check: func() {
+					boot := operatorFramework.GetBoot(bootKey)
+					hpa := operatorFramework.GetHorizontalPodAutoscaler(bootKey)
+					Expect(*hpa.Spec.MinReplicas).Should(Equal(minReplicas))
+					Expect(hpa.Spec.MaxReplicas).Should(Equal(int32(4)))
+					Expect(hpa.Spec.ScaleTargetRef.APIVersion).Should(Equal("app.logancloud.com/v1"))
+					Expect(hpa.Spec.ScaleTargetRef.Kind).Should(Equal("JavaBoot"))
+					Expect(hpa.Spec.ScaleTargetRef.Name).Should(Equal(bootKey.Name))
+					Expect(len(hpa.Spec.Metrics)).Should(Equal(1))
+					Expect(*hpa.Spec.Metrics[0].Resource.Target.AverageUtilization).Should(Equal(targetCPU))
+					Expect(hpa.OwnerReferences[0].UID).Should(Equal(boot.UID))
+
+					deploy := operatorFramework.GetDeployment(bootKey)
+					Expect(*deploy.Spec.Replicas).Should(Equal(minReplicas))
+				},
+				Update: func() {
+					boot := operatorFramework.GetBoot(bootKey)
+					disabled := false
+					boot.Spec.Autoscaling.Enabled = &disabled
+					operatorFramework.UpdateBoot(boot)
+				},
+				Recheck: func() {
+					boot := operatorFramework.GetBoot(bootKey)
+					_, err := operatorFramework.GetHorizontalPodAutoscalerWithError(bootKey)
+					Expect(errors.IsNotFound(err)).Should(BeTrue())
+
+					deploy := operatorFramework.GetDeployment(bootKey)
+					Expect(*deploy.Spec.Replicas).Should(Equal(*boot.Spec.Replicas))
+				},
+			})).Run()
+		})
+	})
+
+	Describe("testing boot statefulset", func() {
+		It("testing create and update the statefulset", func() {
+			(&(operatorFramework.E2E{
+				Build: func() {
+					javaBoot.Spec.WorkloadType = bootv1.WorkloadTypeStatefulSet
+					javaBoot.Spec.PodManagementPolicy = appsv1.ParallelPodManagement
+					operatorFramework.CreateBoot(javaBoot)
+				},
+				Check: func() {
+					sts := operatorFramework.GetStatefulSet(bootKey)
+					Expect(*sts.Spec.Replicas).Should(Equal(*javaBoot.Spec.Replicas))
+					Expect(sts.Spec.ServiceName).Should(Equal(bootKey.Name + "-headless"))
+					Expect(sts.Spec.PodManagementPolicy).Should(Equal(appsv1.ParallelPodManagement))
+
+					svc := operatorFramework.GetService(types.NamespacedName{
+						Namespace: bootKey.Namespace, Name: sts.Spec.ServiceName})
+					Expect(svc.Spec.ClusterIP).Should(Equal(corev1.ClusterIPNone))
+
+					boot := operatorFramework.GetBoot(bootKey)
+					Expect(boot.Status.Type).Should(Equal(keys.AppTypeAnnotationStatefulSet))
+					Expect(boot.Status.Deploy).Should(Equal(sts.Name))
+				},
+				Update: func() {
+					boot := operatorFramework.GetBoot(bootKey)
+					boot.Spec.Env = append(boot.Spec.Env, corev1.EnvVar{Name: "STATEFULSET", Value: "true"})
+					operatorFramework.UpdateBoot(boot)
+				},
+				Recheck: func() {
+					sts := operatorFramework.GetStatefulSet(bootKey)
+					Expect(sts.Spec.Template.Spec.Containers[0].Env).
+						Should(ContainElement(corev1.EnvVar{Name: "STATEFULSET", Value: "true"}))
+
+					// The workloadType could not be changed.
+					boot := operatorFramework.GetBoot(bootKey)
+					boot.Spec.WorkloadType = bootv1.WorkloadTypeDeployment
+					err := operatorFramework.UpdateBootWithError(boot)
+					Expect(err).Should(HaveOccurred())
+				},
+			})).Run()
+		})
+	})
+
+	Describe("testing boot job", func() {
+		It("testing run the boot as a job", func() {
+			(&(operatorFramework.E2E{
+				Build: func() {
+					javaBoot.Spec.WorkloadType = bootv1.WorkloadTypeJob
+					operatorFramework.CreateBoot(javaBoot)
+				},
+				Check: func() {
+					job := operatorFramework.GetJob(bootKey)
+					Expect(job.Spec.Template.Spec.RestartPolicy).Should(Equal(corev1.RestartPolicyOnFailure))
+					Expect(job.Spec.Template.Spec.Containers[0].LivenessProbe).Should(BeNil())
+
+					_, err := operatorFramework.GetServiceWithError(bootKey)
+					Expect(errors.IsNotFound(err)).Should(BeTrue())
+
+					boot := operatorFramework.GetBoot(bootKey)
+					Expect(boot.Status.Type).Should(Equal(keys.AppTypeAnnotationJob))
+					Expect(boot.Status.Deploy).Should(Equal(job.Name))
+				},
+				Update: func() {
+					boot := operatorFramework.GetBoot(bootKey)
+					boot.Spec.WorkloadType = bootv1.WorkloadTypeCronJob
+					err := operatorFramework.UpdateBootWithError(boot)
+					Expect(err).Should(HaveOccurred())
+				},
+				Recheck: func() {
+					boot := operatorFramework.GetBoot(bootKey)
+					Expect(boot.Spec.WorkloadType).Should(Equal(bootv1.WorkloadTypeJob))
+				},
+			})).Run()
+		})
+
+		It("testing run the boot as a cronjob", func() {
+			(&(operatorFramework.E2E{
+				Build: func() {
+					javaBoot.Spec.WorkloadType = bootv1.WorkloadTypeCronJob
+					javaBoot.Spec.Job = &bootv1.BootJob{Schedule: "0 3 * * *"}
+					operatorFramework.CreateBoot(javaBoot)
+				},
+				Check: func() {
+					cronJob := operatorFramework.GetCronJob(bootKey)
+					Expect(cronJob.Spec.Schedule).Should(Equal("0 3 * * *"))
+					Expect(*cronJob.Spec.Suspend).Should(BeFalse())
+
+					boot := operatorFramework.GetBoot(bootKey)
+					Expect(boot.Status.Type).Should(Equal(keys.AppTypeAnnotationCronJob))
+					Expect(boot.Status.Phase).Should(Equal(bootv1.BootPhaseRunning))
+				},
+				Update: func() {
+					boot := operatorFramework.GetBoot(bootKey)
+					boot.Spec.Job.Schedule = "30 4 * * *"
+					suspend := true
+					boot.Spec.Suspend = &suspend
+					operatorFramework.UpdateBoot(boot)
+				},
+				Recheck: func() {
+					cronJob := operatorFramework.GetCronJob(bootKey)
+					Expect(*cronJob.Spec.Suspend).Should(BeTrue())
+
+					boot := operatorFramework.GetBoot(bootKey)
+					Expect(boot.Status.Phase).Should(Equal(bootv1.BootPhaseSuspended))
+
+					// Resumed: the changed schedule is updated.
+					suspend := false
+					boot.Spec.Suspend = &suspend
+					operatorFramework.UpdateBoot(boot)
+
+					cronJob = operatorFramework.GetCronJob(bootKey)
+					Expect(*cronJob.Spec.Suspend).Should(BeFalse())
+					Expect(cronJob.Spec.Schedule).Should(Equal("30 4 * * *"))
+				},
+			})).Run()
+		})
+	})
+
+	Describe("testing boot jvm flags", func() {
+		It("testing create and update the jvm flags by resources", func() {
+			(&(operatorFramework.E2E{
+				Build: func() {
+					operatorFramework.CreateBoot(javaBoot)
+				},
+				Check: func() {
+					deploy := operatorFramework.GetDeployment(bootKey)
+					env := containerEnv(deploy.Spec.Template.Spec.Containers[0], "JAVA_OPTS")
+					Expect(env).ShouldNot(BeNil())
+					Expect(env.Value).Should(Equal(
+						"-Xmx1536m -XX:+UseG1GC -XX:ActiveProcessorCount=2 -XX:+ExitOnOutOfMemoryError"))
+
+					// The flags are not stored in the Boot's spec.
+					boot := operatorFramework.GetBoot(bootKey)
+					for _, bootEnv := range boot.Spec.Env {
+						Expect(bootEnv.Name).ShouldNot(Equal("JAVA_OPTS"))
+					}
+				},
+				Update: func() {
+					boot := operatorFramework.GetBoot(bootKey)
+					boot.Spec.Resources.Limits = corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("500m"),
+						corev1.ResourceMemory: resource.MustParse("1Gi"),
+					}
+					operatorFramework.UpdateBoot(boot)
+				},
+				Recheck: func() {
+					deploy := operatorFramework.GetDeployment(bootKey)
+					env := containerEnv(deploy.Spec.Template.Spec.Containers[0], "JAVA_OPTS")
+					Expect(env).ShouldNot(BeNil())
+					Expect(env.Value).Should(Equal(
+						"-Xmx768m -XX:+UseG1GC -XX:ActiveProcessorCount=1 -XX:+ExitOnOutOfMemoryError"))
+				},
+			})).Run()
+		})
+
+		It("testing merge the jvm flags with the boot's env", func() {
+			(&(operatorFramework.E2E{
+				Build: func() {
+					javaBoot.Spec.Env = append(javaBoot.Spec.Env, corev1.EnvVar{
+						Name:  "JAVA_OPTS",
+						Value: "-Xmx512m -XX:+UseParallelGC -Duser.timezone=Asia/Shanghai",
+					})
+					operatorFramework.CreateBoot(javaBoot)
+				},
+				Check: func() {
+					deploy := operatorFramework.GetDeployment(bootKey)
+					env := containerEnv(deploy.Spec.Template.Spec.Containers[0], "JAVA_OPTS")
+					Expect(env).ShouldNot(BeNil())
+					Expect(env.Value).Should(Equal("-XX:ActiveProcessorCount=2 -XX:+ExitOnOutOfMemoryError " +
+						"-Xmx512m -XX:+UseParallelGC -Duser.timezone=Asia/Shanghai"))
+
+					boot := operatorFramework.GetBoot(bootKey)
+					Expect(boot.Spec.Env).Should(ContainElement(corev1.EnvVar{
+						Name:  "JAVA_OPTS",
+						Value: "-Xmx512m -XX:+UseParallelGC -Duser.timezone=Asia/Shanghai",
+					}))
+				},
+			})).Run()
+		})
+	})
+
 })
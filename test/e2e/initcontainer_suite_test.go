@@ -1,7 +1,6 @@
 package e2e
 
 import (
-	ghodssyaml "github.com/ghodss/yaml"
 	bootv1 "github.com/logancloud/logan-app-operator/pkg/apis/app/v1"
 	"github.com/logancloud/logan-app-operator/pkg/logan"
 	"github.com/logancloud/logan-app-operator/pkg/logan/config"
@@ -9,7 +8,6 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
 
@@ -18,8 +16,7 @@ var _ = Describe("Test initContainer", func() {
 	var phpBoot *bootv1.PhpBoot
 
 	var configNN = types.NamespacedName{
-		Name:      "logan-app-operator-config",
-		Namespace: "logan",
+		Name: "logan-app-operator-config",
 	}
 	var env = corev1.EnvVar{
 		Name:  "testKey",
@@ -53,13 +50,7 @@ var _ = Describe("Test initContainer", func() {
 
 		AfterEach(func() {
 			// recover config map: config.yaml
-			data := make(map[string]string)
-			data["config.yaml"] = configYamlStr
-			var configMap = corev1.ConfigMap{
-				ObjectMeta: metav1.ObjectMeta{Name: configNN.Name, Namespace: configNN.Namespace},
-				Data:       data,
-			}
-			operatorFramework.UpdateConfigmap(&configMap)
+			operatorFramework.UpdateConfigStr(configNN, configYamlStr)
 		})
 
 		It("testing InitContainer create and update", func() {
@@ -94,15 +85,7 @@ var _ = Describe("Test initContainer", func() {
 					((*operator).AppSpec.PodSpec.InitContainers)[0].Env = append(((*operator).AppSpec.PodSpec.InitContainers)[0].Env, env)
 					c[logan.BootPhp] = operator
 
-					updatedInitContainerContent, _ := ghodssyaml.Marshal(&c)
-
-					data := make(map[string]string)
-					data["config.yaml"] = string(updatedInitContainerContent)
-					var configMap = corev1.ConfigMap{
-						ObjectMeta: metav1.ObjectMeta{Name: configNN.Name, Namespace: configNN.Namespace},
-						Data:       data,
-					}
-					operatorFramework.UpdateConfigmap(&configMap)
+					operatorFramework.UpdateConfig(configNN, c)
 
 					phpBoot.Spec.Env = append(phpBoot.Spec.Env, envForUpdateBoot)
 					operatorFramework.UpdatePhpBoot(phpBoot)
@@ -170,15 +153,7 @@ var _ = Describe("Test initContainer", func() {
 
 					c[logan.BootPhp] = operator
 
-					updatedInitContainerContent, _ := ghodssyaml.Marshal(&c)
-
-					data := make(map[string]string)
-					data["config.yaml"] = string(updatedInitContainerContent)
-					var configMap = corev1.ConfigMap{
-						ObjectMeta: metav1.ObjectMeta{Name: configNN.Name, Namespace: configNN.Namespace},
-						Data:       data,
-					}
-					operatorFramework.UpdateConfigmap(&configMap)
+					operatorFramework.UpdateConfig(configNN, c)
 
 					phpBoot.Spec.Env = append(phpBoot.Spec.Env, envForUpdateBoot)
 					operatorFramework.UpdatePhpBoot(phpBoot)
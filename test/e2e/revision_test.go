@@ -186,6 +186,104 @@ var _ = Describe("Testing Boot Revision [Revision]", func() {
 		})
 	})
 
+	Context("test rollback the boot with revision", func() {
+		It("testing rollback boot to the previous revision is ok", func() {
+			var image string
+			e2eCase.Update = func() {
+				boot := operatorFramework.GetBoot(bootKey)
+				image = boot.Spec.Image
+				boot.Spec.Image = boot.Spec.Image + "123"
+				operatorFramework.UpdateBoot(boot)
+
+				boot = operatorFramework.GetBoot(bootKey)
+				Expect(boot.Spec.Image).Should(Equal(image + "123"))
+				boot.Annotations[keys.BootRollbackToAnnotationKey] = "1"
+				operatorFramework.UpdateBoot(boot)
+			}
+
+			e2eCase.Recheck = func() {
+				boot := operatorFramework.GetBoot(bootKey)
+				Expect(boot.Spec.Image).Should(Equal(image))
+				_, found := boot.Annotations[keys.BootRollbackToAnnotationKey]
+				Expect(found).Should(BeFalse())
+				Expect(boot.Annotations[keys.BootRevisionIdAnnotationKey]).Should(Equal("3"))
+
+				podLabels := operator.PodLabels(boot.DeepCopyBoot())
+				lst, err := k8sClient.ListRevision(boot.Namespace, podLabels)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(len(lst.Items)).Should(Equal(3))
+				latest := lst.SelectLatestRevision()
+				Expect(latest.GetRevisionId()).Should(Equal(3))
+				Expect(latest.Spec.Image).Should(Equal(image))
+				Expect(latest.Annotations[keys.BootRevisionRollbackAnnotationKey]).Should(Equal("1"))
+			}
+
+			e2eCase.Run()
+		})
+
+		It("testing rollback boot automatically when rollout failed", func() {
+			var image string
+			e2eCase.Build = func() {
+				autoRollback := true
+				progressDeadlineSeconds := int32(30)
+				javaBoot.Spec.Strategy = &bootv1.BootStrategy{
+					ProgressDeadlineSeconds: &progressDeadlineSeconds,
+					AutoRollback:            &autoRollback,
+				}
+				operatorFramework.CreateBoot(javaBoot)
+			}
+
+			e2eCase.Update = func() {
+				// Wait for the first revision to become Active
+				operatorFramework.WaitUpdate(60)
+				boot := operatorFramework.GetBoot(bootKey)
+				image = boot.Spec.Image
+				boot.Spec.Image = boot.Spec.Image + "-not-exist"
+				operatorFramework.UpdateBoot(boot)
+
+				// Wait for the progress deadline exceeded
+				operatorFramework.WaitUpdate(60)
+			}
+
+			e2eCase.Recheck = func() {
+				boot := operatorFramework.GetBoot(bootKey)
+				Expect(boot.Spec.Image).Should(Equal(image))
+				Expect(boot.Annotations[keys.BootRevisionIdAnnotationKey]).Should(Equal("3"))
+
+				podLabels := operator.PodLabels(boot.DeepCopyBoot())
+				lst, err := k8sClient.ListRevision(boot.Namespace, podLabels)
+				Expect(err).ShouldNot(HaveOccurred())
+				for _, r := range lst.Items {
+					if r.GetRevisionId() == 2 {
+						Expect(r.Annotations[keys.BootRevisionPhaseAnnotationKey]).Should(Equal(operator.RevisionPhaseFailed))
+					}
+				}
+				latest := lst.SelectLatestRevision()
+				Expect(latest.Annotations[keys.BootRevisionRollbackAnnotationKey]).Should(Equal("1"))
+			}
+
+			e2eCase.Run()
+		})
+
+		It("testing rollback boot to a not found revision is invalid", func() {
+			e2eCase.Update = func() {
+				boot := operatorFramework.GetBoot(bootKey)
+				boot.Annotations[keys.BootRollbackToAnnotationKey] = "10"
+				err := operatorFramework.UpdateBootWithError(boot)
+				Expect(err).Should(HaveOccurred())
+			}
+
+			e2eCase.Recheck = func() {
+				boot := operatorFramework.GetBoot(bootKey)
+				_, found := boot.Annotations[keys.BootRollbackToAnnotationKey]
+				Expect(found).Should(BeFalse())
+				Expect(boot.Annotations[keys.BootRevisionIdAnnotationKey]).Should(Equal("1"))
+			}
+
+			e2eCase.Run()
+		})
+	})
+
 	Context("test delete the boot with revision", func() {
 		It("test delete the boot with revision, revision should also deleted", func() {
 
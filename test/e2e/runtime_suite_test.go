@@ -0,0 +1,158 @@
+package e2e
+
+import (
+	"github.com/logancloud/logan-app-operator/pkg/logan/operator"
+	"github.com/logancloud/logan-app-operator/pkg/logan/util"
+	operatorFramework "github.com/logancloud/logan-app-operator/test/framework"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// containerEnv returns the env named in the container, nil if not found.
+func containerEnv(container corev1.Container, name string) *corev1.EnvVar {
+	for i := range container.Env {
+		if container.Env[i].Name == name {
+			return &container.Env[i]
+		}
+	}
+	return nil
+}
+
+var _ = Describe("Testing Runtime Boots [Runtime]", func() {
+	var bootKey types.NamespacedName
+	var k8sClient util.K8SClient
+
+	BeforeEach(func() {
+		// Gen new namespace
+		bootKey = operatorFramework.GenResource()
+		operatorFramework.CreateNamespace(bootKey.Namespace)
+		k8sClient = util.NewClient(framework.Mgr.GetClient())
+	})
+
+	AfterEach(func() {
+		// Clean namespace
+		operatorFramework.DeleteNamespace(bootKey.Namespace)
+	})
+
+	Describe("testing GoBoot", func() {
+		It("testing create GoBoot with the runtime defaults", func() {
+			(&(operatorFramework.E2E{
+				Build: func() {
+					operatorFramework.CreateBoot(operatorFramework.SampleGoBoot(bootKey))
+				},
+				Check: func() {
+					boot := operatorFramework.GetGoBoot(bootKey)
+					Expect(boot.Spec.Port).Should(Equal(int32(8080)))
+					Expect(*boot.Spec.Health).Should(Equal("/health"))
+
+					deploy := operatorFramework.GetDeployment(bootKey)
+					container := deploy.Spec.Template.Spec.Containers[0]
+					Expect(container.Resources.Limits.Cpu().Cmp(resource.MustParse("1"))).Should(Equal(0))
+					Expect(container.Resources.Limits.Memory().Cmp(resource.MustParse("256Mi"))).Should(Equal(0))
+					Expect(container.Resources.Requests.Cpu().Cmp(resource.MustParse("10m"))).Should(Equal(0))
+
+					env := containerEnv(container, "GOMAXPROCS")
+					Expect(env).ShouldNot(BeNil())
+					Expect(env.ValueFrom.ResourceFieldRef.Resource).Should(Equal("limits.cpu"))
+
+					Expect(container.LivenessProbe.InitialDelaySeconds).Should(Equal(int32(5)))
+					Expect(container.LivenessProbe.PeriodSeconds).Should(Equal(int32(5)))
+
+					svc := operatorFramework.GetService(bootKey)
+					Expect(svc.Spec.Ports[0].Port).Should(Equal(int32(8080)))
+
+					revisions, err := k8sClient.ListRevision(bootKey.Namespace, operator.PodLabels(boot.DeepCopyBoot()))
+					Expect(err).Should(BeNil())
+					Expect(len(revisions.Items)).Should(Equal(1))
+				},
+			})).Run()
+		})
+
+		It("testing create GoBoot with GOMAXPROCS specified", func() {
+			(&(operatorFramework.E2E{
+				Build: func() {
+					goBoot := operatorFramework.SampleGoBoot(bootKey)
+					goBoot.Spec.Env = []corev1.EnvVar{{Name: "GOMAXPROCS", Value: "2"}}
+					operatorFramework.CreateBoot(goBoot)
+				},
+				Check: func() {
+					deploy := operatorFramework.GetDeployment(bootKey)
+					env := containerEnv(deploy.Spec.Template.Spec.Containers[0], "GOMAXPROCS")
+					Expect(env).ShouldNot(BeNil())
+					Expect(env.Value).Should(Equal("2"))
+					Expect(env.ValueFrom).Should(BeNil())
+				},
+			})).Run()
+		})
+
+		It("testing create GoBoot with the same name as JavaBoot", func() {
+			(&(operatorFramework.E2E{
+				Build: func() {
+					operatorFramework.CreateBoot(operatorFramework.SampleBoot(bootKey))
+				},
+				Check: func() {
+					boot := operatorFramework.GetBoot(bootKey)
+					Expect(boot.Name).Should(Equal(bootKey.Name))
+				},
+				Update: func() {
+					err := operatorFramework.CreateBootWithError(operatorFramework.SampleGoBoot(bootKey))
+					Expect(err).Should(HaveOccurred())
+				},
+			})).Run()
+		})
+	})
+
+	Describe("testing DotnetBoot", func() {
+		It("testing create DotnetBoot with the runtime defaults", func() {
+			(&(operatorFramework.E2E{
+				Build: func() {
+					operatorFramework.CreateBoot(operatorFramework.SampleDotnetBoot(bootKey))
+				},
+				Check: func() {
+					boot := operatorFramework.GetDotnetBoot(bootKey)
+					Expect(boot.Spec.Env).Should(ContainElement(
+						corev1.EnvVar{Name: "ASPNETCORE_URLS", Value: "http://+:8080"}))
+
+					deploy := operatorFramework.GetDeployment(bootKey)
+					container := deploy.Spec.Template.Spec.Containers[0]
+					Expect(container.Resources.Limits.Cpu().Cmp(resource.MustParse("1"))).Should(Equal(0))
+					Expect(container.Resources.Limits.Memory().Cmp(resource.MustParse("512Mi"))).Should(Equal(0))
+					Expect(container.Resources.Requests.Cpu().Cmp(resource.MustParse("50m"))).Should(Equal(0))
+
+					env := containerEnv(container, "DOTNET_PROCESSOR_COUNT")
+					Expect(env).ShouldNot(BeNil())
+					Expect(env.ValueFrom.ResourceFieldRef.Resource).Should(Equal("limits.cpu"))
+
+					Expect(container.LivenessProbe.InitialDelaySeconds).Should(Equal(int32(15)))
+					Expect(container.LivenessProbe.FailureThreshold).Should(Equal(int32(6)))
+
+					svc := operatorFramework.GetService(bootKey)
+					Expect(svc.Spec.Ports[0].Port).Should(Equal(int32(8080)))
+
+					revisions, err := k8sClient.ListRevision(bootKey.Namespace, operator.PodLabels(boot.DeepCopyBoot()))
+					Expect(err).Should(BeNil())
+					Expect(len(revisions.Items)).Should(Equal(1))
+				},
+			})).Run()
+		})
+
+		It("testing create DotnetBoot with the same name as GoBoot", func() {
+			(&(operatorFramework.E2E{
+				Build: func() {
+					operatorFramework.CreateBoot(operatorFramework.SampleGoBoot(bootKey))
+				},
+				Check: func() {
+					boot := operatorFramework.GetGoBoot(bootKey)
+					Expect(boot.Name).Should(Equal(bootKey.Name))
+				},
+				Update: func() {
+					err := operatorFramework.CreateBootWithError(operatorFramework.SampleDotnetBoot(bootKey))
+					Expect(err).Should(HaveOccurred())
+				},
+			})).Run()
+		})
+	})
+})
@@ -1,7 +1,6 @@
 package e2e
 
 import (
-	ghodssyaml "github.com/ghodss/yaml"
 	bootv1 "github.com/logancloud/logan-app-operator/pkg/apis/app/v1"
 	"github.com/logancloud/logan-app-operator/pkg/logan"
 	"github.com/logancloud/logan-app-operator/pkg/logan/config"
@@ -9,7 +8,6 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
 
@@ -17,8 +15,7 @@ var _ = Describe("Test sidecar", func() {
 	var bootKey types.NamespacedName
 	var phpBoot *bootv1.PhpBoot
 	var configNN = types.NamespacedName{
-		Name:      "logan-app-operator-config",
-		Namespace: "logan",
+		Name: "logan-app-operator-config",
 	}
 	var env = corev1.EnvVar{
 		Name:  "testKey",
@@ -52,13 +49,7 @@ var _ = Describe("Test sidecar", func() {
 
 		AfterEach(func() {
 			// recover config map: config.yaml
-			data := make(map[string]string)
-			data["config.yaml"] = configYamlStr
-			var configMap = corev1.ConfigMap{
-				ObjectMeta: metav1.ObjectMeta{Name: configNN.Name, Namespace: configNN.Namespace},
-				Data:       data,
-			}
-			operatorFramework.UpdateConfigmap(&configMap)
+			operatorFramework.UpdateConfigStr(configNN, configYamlStr)
 		})
 
 		It("testing sidecar create and update", func() {
@@ -110,15 +101,7 @@ var _ = Describe("Test sidecar", func() {
 
 					(*operator.SidecarContainers)[0].Env = append((*operator.SidecarContainers)[0].Env, env)
 					c[logan.BootPhp] = operator
-					updatedConfigContent, _ := ghodssyaml.Marshal(&c)
-
-					data := make(map[string]string)
-					data["config.yaml"] = string(updatedConfigContent)
-					var configMap = corev1.ConfigMap{
-						ObjectMeta: metav1.ObjectMeta{Name: configNN.Name, Namespace: configNN.Namespace},
-						Data:       data,
-					}
-					operatorFramework.UpdateConfigmap(&configMap)
+					operatorFramework.UpdateConfig(configNN, c)
 
 					phpBoot.Spec.Env = append(phpBoot.Spec.Env, envForUpdateBoot)
 					operatorFramework.UpdatePhpBoot(phpBoot)
@@ -193,15 +176,7 @@ var _ = Describe("Test sidecar", func() {
 					operator.OEnvs["sidecar"] = sideCarEnvs
 
 					c[logan.BootPhp] = operator
-					updatedConfigContent, _ := ghodssyaml.Marshal(&c)
-
-					data := make(map[string]string)
-					data["config.yaml"] = string(updatedConfigContent)
-					var configMap = corev1.ConfigMap{
-						ObjectMeta: metav1.ObjectMeta{Name: configNN.Name, Namespace: configNN.Namespace},
-						Data:       data,
-					}
-					operatorFramework.UpdateConfigmap(&configMap)
+					operatorFramework.UpdateConfig(configNN, c)
 
 					phpBoot.Spec.Env = append(phpBoot.Spec.Env, envForUpdateBoot)
 					operatorFramework.UpdatePhpBoot(phpBoot)
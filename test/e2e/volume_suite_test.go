@@ -1,14 +1,12 @@
 package e2e
 
 import (
-	ghodssyaml "github.com/ghodss/yaml"
 	bootv1 "github.com/logancloud/logan-app-operator/pkg/apis/app/v1"
 	"github.com/logancloud/logan-app-operator/pkg/logan/config"
 	operatorFramework "github.com/logancloud/logan-app-operator/test/framework"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
 
@@ -40,8 +38,7 @@ var _ = Describe("Testing Volume", func() {
 	Describe("Testing PVC when operator config update [Serial]", func() {
 		var configYamlStr string
 		var configNN = types.NamespacedName{
-			Name:      "logan-app-operator-config",
-			Namespace: "logan",
+			Name: "logan-app-operator-config",
 		}
 		BeforeEach(func() {
 			// backup config map: config.yaml
@@ -50,13 +47,7 @@ var _ = Describe("Testing Volume", func() {
 
 		AfterEach(func() {
 			// recover config map: config.yaml
-			data := make(map[string]string)
-			data["config.yaml"] = configYamlStr
-			var configMap = corev1.ConfigMap{
-				ObjectMeta: metav1.ObjectMeta{Name: configNN.Name, Namespace: configNN.Namespace},
-				Data:       data,
-			}
-			operatorFramework.UpdateConfigmap(&configMap)
+			operatorFramework.UpdateConfigStr(configNN, configYamlStr)
 		})
 
 		It("Testing PVC when operator config update [Serial]", func() {
@@ -78,14 +69,7 @@ var _ = Describe("Testing Volume", func() {
 					(*operator).AppSpec.Container.VolumeMounts[0].MountPath = "/opt/abc"
 					c["vol"] = operator
 
-					updatedInitContainerContent, _ := ghodssyaml.Marshal(&c)
-					data := make(map[string]string)
-					data["config.yaml"] = string(updatedInitContainerContent)
-					var configMap = corev1.ConfigMap{
-						ObjectMeta: metav1.ObjectMeta{Name: configNN.Name, Namespace: configNN.Namespace},
-						Data:       data,
-					}
-					operatorFramework.UpdateConfigmap(&configMap)
+					operatorFramework.UpdateConfig(configNN, c)
 
 					boot := operatorFramework.GetPhpBoot(bootKey)
 					r := int32(3)
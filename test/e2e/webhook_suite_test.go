@@ -2,7 +2,6 @@ package e2e
 
 import (
 	"fmt"
-	ghodssyaml "github.com/ghodss/yaml"
 	bootv1 "github.com/logancloud/logan-app-operator/pkg/apis/app/v1"
 	"github.com/logancloud/logan-app-operator/pkg/logan"
 	"github.com/logancloud/logan-app-operator/pkg/logan/config"
@@ -228,8 +227,7 @@ var _ = Describe("Testing Webhook", func() {
 
 		Context("testing env with update operation annotation [Serial]", func() {
 			var configNN = types.NamespacedName{
-				Name:      "logan-app-operator-config",
-				Namespace: "logan",
+				Name: "logan-app-operator-config",
 			}
 			var env = corev1.EnvVar{
 				Name:  "testKey",
@@ -242,14 +240,7 @@ var _ = Describe("Testing Webhook", func() {
 
 				operator.AppSpec.Env = append(operator.AppSpec.Env, env)
 				c[logan.BootJava] = operator
-				updatedConfigContent, _ := ghodssyaml.Marshal(&c)
-
-				var configMap corev1.ConfigMap
-				configMap.Namespace = configNN.Namespace
-				configMap.Name = configNN.Name
-				configMap.Data = make(map[string]string)
-				configMap.Data["config.yaml"] = string(updatedConfigContent)
-				operatorFramework.UpdateConfigmap(&configMap)
+				operatorFramework.UpdateConfig(configNN, c)
 			})
 
 			AfterEach(func() {
@@ -265,14 +256,7 @@ var _ = Describe("Testing Webhook", func() {
 				}
 				operator.AppSpec.Env = tmp
 				c[logan.BootJava] = operator
-				updatedConfigContent, _ := ghodssyaml.Marshal(&c)
-
-				var configMap corev1.ConfigMap
-				configMap.Namespace = configNN.Namespace
-				configMap.Name = configNN.Name
-				configMap.Data = make(map[string]string)
-				configMap.Data["config.yaml"] = string(updatedConfigContent)
-				operatorFramework.UpdateConfigmap(&configMap)
+				operatorFramework.UpdateConfig(configNN, c)
 			})
 
 			It("check modify/add env with update operation annotation", func() {
@@ -608,44 +592,6 @@ var _ = Describe("Testing Webhook", func() {
 		})
 	})
 
-	Context("testing config reload", func() {
-		configNN := types.NamespacedName{
-			Name:      "logan-app-operator-config",
-			Namespace: "logan"}
-
-		It("config.yaml not found", func() {
-			(&(operatorFramework.E2E{
-				Update: func() {
-					configmap := operatorFramework.GetConfigmap(configNN)
-					delete(configmap.Data, "config.yaml")
-					_, err := framework.KubeClient.CoreV1().ConfigMaps(configmap.Namespace).Update(configmap)
-					Expect(err).Should(HaveOccurred())
-				},
-			})).Run()
-		})
-
-		It("config.yaml is blank", func() {
-			(&(operatorFramework.E2E{
-				Update: func() {
-					configmap := operatorFramework.GetConfigmap(configNN)
-					configmap.Data["config.yaml"] = ""
-					_, err := framework.KubeClient.CoreV1().ConfigMaps(configmap.Namespace).Update(configmap)
-					Expect(err).Should(HaveOccurred())
-				},
-			})).Run()
-		})
-		It("config.yaml error format", func() {
-			(&(operatorFramework.E2E{
-				Update: func() {
-					configmap := operatorFramework.GetConfigmap(configNN)
-					configmap.Data["config.yaml"] = "{xx:123,}"
-					_, err := framework.KubeClient.CoreV1().ConfigMaps(configmap.Namespace).Update(configmap)
-					Expect(err).Should(HaveOccurred())
-				},
-			})).Run()
-		})
-	})
-
 	Describe("testing validating webhook with env and secret", func() {
 		var secret *corev1.Secret
 		BeforeEach(func() {
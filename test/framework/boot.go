@@ -40,6 +40,34 @@ func SamplePhpBoot(bootKey types.NamespacedName) *bootv1.PhpBoot {
 	return phpBoot
 }
 
+// SampleGoBoot will generate a sample GoBoot with boot key
+func SampleGoBoot(bootKey types.NamespacedName) *bootv1.GoBoot {
+	replicas := int32(1)
+	goBoot := &bootv1.GoBoot{
+		ObjectMeta: metav1.ObjectMeta{Name: bootKey.Name, Namespace: bootKey.Namespace},
+		Spec: bootv1.BootSpec{
+			Replicas: &replicas,
+			Image:    "logan-startkit-boot",
+			Version:  "1.2.1",
+		},
+	}
+	return goBoot
+}
+
+// SampleDotnetBoot will generate a sample DotnetBoot with boot key
+func SampleDotnetBoot(bootKey types.NamespacedName) *bootv1.DotnetBoot {
+	replicas := int32(1)
+	dotnetBoot := &bootv1.DotnetBoot{
+		ObjectMeta: metav1.ObjectMeta{Name: bootKey.Name, Namespace: bootKey.Namespace},
+		Spec: bootv1.BootSpec{
+			Replicas: &replicas,
+			Image:    "logan-startkit-boot",
+			Version:  "1.2.1",
+		},
+	}
+	return dotnetBoot
+}
+
 // CreateBoot will create Boot in kubernetes
 func CreateBoot(obj runtime.Object) {
 	err := framework.Mgr.GetClient().Create(context.TODO(), obj)
@@ -135,6 +163,26 @@ func GetPhpBoot(bootKey types.NamespacedName) *bootv1.PhpBoot {
 	return boot
 }
 
+// GetGoBoot will get GoBoot with boot key from kubernetes
+func GetGoBoot(bootKey types.NamespacedName) *bootv1.GoBoot {
+	boot := &bootv1.GoBoot{}
+	gomega.Eventually(func() error {
+		return framework.Mgr.GetClient().Get(context.TODO(), bootKey, boot)
+	}, defaultTimeout).
+		Should(gomega.Succeed())
+	return boot
+}
+
+// GetDotnetBoot will get DotnetBoot with boot key from kubernetes
+func GetDotnetBoot(bootKey types.NamespacedName) *bootv1.DotnetBoot {
+	boot := &bootv1.DotnetBoot{}
+	gomega.Eventually(func() error {
+		return framework.Mgr.GetClient().Get(context.TODO(), bootKey, boot)
+	}, defaultTimeout).
+		Should(gomega.Succeed())
+	return boot
+}
+
 // GetBootWithError will get JavaBoot with boot key from kubernetes, return JavaBoot and error
 func GetBootWithError(bootKey types.NamespacedName) (*bootv1.JavaBoot, error) {
 	boot := &bootv1.JavaBoot{}
@@ -1,38 +1,78 @@
 package framework
 
 import (
+	"context"
+	ghodssyaml "github.com/ghodss/yaml"
+	bootv1 "github.com/logancloud/logan-app-operator/pkg/apis/app/v1"
 	"github.com/logancloud/logan-app-operator/pkg/logan/config"
 	"github.com/onsi/gomega"
-	"io/ioutil"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
-	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+	"log"
 	"strings"
 )
 
-// GetConfigStr will return operator config map string
+// GetOperatorConfig will return the operator's LoganOperatorConfig from kubernetes by name
+func GetOperatorConfig(configNN types.NamespacedName) *bootv1.LoganOperatorConfig {
+	operatorConfig := &bootv1.LoganOperatorConfig{}
+	gomega.Eventually(func() error {
+		return framework.Mgr.GetClient().Get(context.TODO(), types.NamespacedName{Name: configNN.Name}, operatorConfig)
+	}, defaultTimeout).
+		Should(gomega.Succeed())
+	return operatorConfig
+}
+
+// GetConfigStr will return operator config yaml string
 func GetConfigStr(configNN types.NamespacedName) string {
-	configMap := GetConfigmap(configNN)
-	configContent := ""
-	for k, v := range configMap.Data {
-		if k == "config.yaml" {
-			configContent = v
-			break
-		}
-	}
+	content, err := ghodssyaml.Marshal(GetConfig(configNN))
+	gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
 
-	return configContent
+	return string(content)
 }
 
 // GetConfig will return operator GlobalConfig struct object
 func GetConfig(configNN types.NamespacedName) config.GlobalConfig {
-	configContent := GetConfigStr(configNN)
+	operatorConfig := GetOperatorConfig(configNN)
+	gomega.Expect(operatorConfig.Spec.Config).ShouldNot(gomega.BeEmpty())
 
-	gomega.Expect(configContent).ShouldNot(gomega.Equal(""))
-	content := ioutil.NopCloser(strings.NewReader(configContent))
-	c := config.GlobalConfig{}
+	return operatorConfig.DeepCopy().Spec.Config
+}
 
-	err := k8syaml.NewYAMLOrJSONDecoder(content, 100).Decode(&c)
+// UpdateConfig will update the operator's LoganOperatorConfig with the GlobalConfig
+func UpdateConfig(configNN types.NamespacedName, c config.GlobalConfig) *bootv1.LoganOperatorConfig {
+	operatorConfig := &bootv1.LoganOperatorConfig{}
+	gomega.Eventually(func() error {
+		operatorConfig = GetOperatorConfig(configNN)
+		operatorConfig.Spec.Config = c
+		err := framework.Mgr.GetClient().Update(context.TODO(), operatorConfig)
+		if apierrors.IsConflict(err) {
+			log.Printf("failed to update object, got an Conflict error: ")
+		}
+		if apierrors.IsInvalid(err) {
+			log.Printf("failed to update object, got an invalid object error: ")
+		}
+		return err
+	}, defaultTimeout).
+		Should(gomega.Succeed())
+	WaitDefaultUpdate()
+	return operatorConfig
+}
+
+// UpdateConfigStr will update the operator's LoganOperatorConfig with the config yaml string
+func UpdateConfigStr(configNN types.NamespacedName, content string) *bootv1.LoganOperatorConfig {
+	c, err := config.DecodeGlobalConfig(strings.NewReader(content))
 	gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
 
-	return c
+	return UpdateConfig(configNN, c)
+}
+
+// UpdateConfigWithError will update the operator's LoganOperatorConfig with the GlobalConfig, and return the error
+func UpdateConfigWithError(configNN types.NamespacedName, c config.GlobalConfig) error {
+	operatorConfig := GetOperatorConfig(configNN)
+	operatorConfig.Spec.Config = c
+	err := framework.Mgr.GetClient().Update(context.TODO(), operatorConfig)
+	if apierrors.IsInvalid(err) {
+		log.Printf("failed to update object, got an invalid object error: ")
+	}
+	return err
 }
@@ -0,0 +1,27 @@
+package framework
+
+import (
+	"github.com/onsi/gomega"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// GetHorizontalPodAutoscaler will get hpa with NamespacedName from kubernetes, return hpa
+func GetHorizontalPodAutoscaler(nn types.NamespacedName) *autoscalingv2beta2.HorizontalPodAutoscaler {
+	hpa := &autoscalingv2beta2.HorizontalPodAutoscaler{}
+	var err error
+	gomega.Eventually(func() error {
+		hpa, err = framework.KubeClient.AutoscalingV2beta2().HorizontalPodAutoscalers(nn.Namespace).Get(nn.Name, metav1.GetOptions{})
+		return err
+	}, defaultTimeout).
+		Should(gomega.Succeed())
+	WaitDefaultUpdate()
+	return hpa
+}
+
+// GetHorizontalPodAutoscalerWithError will get hpa with NamespacedName from kubernetes, return error if occur
+func GetHorizontalPodAutoscalerWithError(nn types.NamespacedName) (*autoscalingv2beta2.HorizontalPodAutoscaler, error) {
+	WaitDefaultUpdate()
+	return framework.KubeClient.AutoscalingV2beta2().HorizontalPodAutoscalers(nn.Namespace).Get(nn.Name, metav1.GetOptions{})
+}
@@ -0,0 +1,21 @@
+package framework
+
+import (
+	"github.com/onsi/gomega"
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// GetIngress will get ingress with NamespacedName from kubernetes, return ingress
+func GetIngress(nn types.NamespacedName) *extensionsv1beta1.Ingress {
+	ingress := &extensionsv1beta1.Ingress{}
+	var err error
+	gomega.Eventually(func() error {
+		ingress, err = framework.KubeClient.ExtensionsV1beta1().Ingresses(nn.Namespace).Get(nn.Name, metav1.GetOptions{})
+		return err
+	}, defaultTimeout).
+		Should(gomega.Succeed())
+	WaitDefaultUpdate()
+	return ingress
+}
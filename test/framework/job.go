@@ -0,0 +1,33 @@
+package framework
+
+import (
+	"github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// GetJob will return specific job from kubernetes by NamespacedName
+func GetJob(nn types.NamespacedName) *batchv1.Job {
+	job := &batchv1.Job{}
+	var err error
+	gomega.Eventually(func() error {
+		job, err = framework.KubeClient.BatchV1().Jobs(nn.Namespace).Get(nn.Name, metav1.GetOptions{})
+		return err
+	}, defaultTimeout).
+		Should(gomega.Succeed())
+	return job
+}
+
+// GetCronJob will return specific cronjob from kubernetes by NamespacedName
+func GetCronJob(nn types.NamespacedName) *batchv1beta1.CronJob {
+	cronJob := &batchv1beta1.CronJob{}
+	var err error
+	gomega.Eventually(func() error {
+		cronJob, err = framework.KubeClient.BatchV1beta1().CronJobs(nn.Namespace).Get(nn.Name, metav1.GetOptions{})
+		return err
+	}, defaultTimeout).
+		Should(gomega.Succeed())
+	return cronJob
+}
@@ -20,6 +20,36 @@ func CreateNamespace(name string) (*v1.Namespace, error) {
 	return namespace, nil
 }
 
+// CreateNamespaceWithLabels will create specific namespace with the labels in kubernetes
+func CreateNamespaceWithLabels(name string, labels map[string]string) (*v1.Namespace, error) {
+	namespace, err := framework.KubeClient.CoreV1().Namespaces().Create(&v1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: labels,
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to create namespace with name %v", name))
+	}
+	return namespace, nil
+}
+
+// UpdateNamespaceLabels will replace the labels of specific namespace in kubernetes
+func UpdateNamespaceLabels(name string, labels map[string]string) (*v1.Namespace, error) {
+	namespace, err := framework.KubeClient.CoreV1().Namespaces().Get(name, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to get namespace with name %v", name))
+	}
+
+	namespace.Labels = labels
+	namespace, err = framework.KubeClient.CoreV1().Namespaces().Update(namespace)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to update namespace with name %v", name))
+	}
+	WaitDefaultUpdate()
+	return namespace, nil
+}
+
 // DeleteNamespace will delete specific namespace from kubernetes
 func DeleteNamespace(name string) {
 	option := &metav1.DeleteOptions{}
@@ -0,0 +1,26 @@
+package framework
+
+import (
+	"github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// GetStatefulSet will return specific statefulset from kubernetes by NamespacedName
+func GetStatefulSet(nn types.NamespacedName) *appsv1.StatefulSet {
+	sts := &appsv1.StatefulSet{}
+	var err error
+	gomega.Eventually(func() error {
+		sts, err = framework.KubeClient.AppsV1().StatefulSets(nn.Namespace).Get(nn.Name, metav1.GetOptions{})
+		return err
+	}, defaultTimeout).
+		Should(gomega.Succeed())
+	return sts
+}
+
+// GetStatefulSetWithError will return specific statefulset from kubernetes by NamespacedName, return error if occurs
+func GetStatefulSetWithError(nn types.NamespacedName) (*appsv1.StatefulSet, error) {
+	WaitDefaultUpdate()
+	return framework.KubeClient.AppsV1().StatefulSets(nn.Namespace).Get(nn.Name, metav1.GetOptions{})
+}